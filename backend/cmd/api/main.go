@@ -71,7 +71,9 @@ func main() {
 	case httpServerMode:
 		// start server, scheduler, worker, websocket
 		appContainer.GeocodeWorker.Start(1)
+		appContainer.PayslipEmailWorker.Start(3)
 		appContainer.LeaveScheduler.Start()
+		appContainer.AttendanceScheduler.Start()
 		appContainer.NotificationScheduler.Start()
 		go appContainer.WebsocketHub.Run()
 
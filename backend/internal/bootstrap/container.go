@@ -6,15 +6,22 @@ import (
 	"basekarya-backend/internal/middleware"
 	"basekarya-backend/internal/modules/attendance"
 	"basekarya-backend/internal/modules/auth"
+	"basekarya-backend/internal/modules/bpjs"
 	"basekarya-backend/internal/modules/company"
+	"basekarya-backend/internal/modules/correction"
 	"basekarya-backend/internal/modules/health"
+	"basekarya-backend/internal/modules/holiday"
+	"basekarya-backend/internal/modules/latepolicy"
 	"basekarya-backend/internal/modules/leave"
 	"basekarya-backend/internal/modules/loan"
 	"basekarya-backend/internal/modules/master"
 	"basekarya-backend/internal/modules/notification"
+	"basekarya-backend/internal/modules/office"
 	"basekarya-backend/internal/modules/overtime"
 	"basekarya-backend/internal/modules/payroll"
 	"basekarya-backend/internal/modules/reimbursement"
+	"basekarya-backend/internal/modules/roster"
+	"basekarya-backend/internal/modules/salarycomponent"
 	"basekarya-backend/internal/modules/user"
 )
 
@@ -30,24 +37,33 @@ type Container struct {
 	Email        *infrastructure.EmailProvider
 	Excel        infrastructure.ExcelProvider
 
-	HealthCheckHandler   *health.Handler
-	AuthHandler          *auth.Handler
-	UserHandler          *user.Handler
-	AttendanceHandler    *attendance.Handler
-	MasterHandler        *master.Handler
-	ReimbursementHandler *reimbursement.Handler
-	PayrollHandler       *payroll.Handler
-	LeaveHandler         *leave.Handler
-	NotificationHandler  *notification.Handler
-	CompanyHandler       *company.Handler
-	LoanHandler          *loan.Handler
-	OvertimeHandler      *overtime.Handler
+	HealthCheckHandler     *health.Handler
+	AuthHandler            *auth.Handler
+	UserHandler            *user.Handler
+	AttendanceHandler      *attendance.Handler
+	MasterHandler          *master.Handler
+	ReimbursementHandler   *reimbursement.Handler
+	PayrollHandler         *payroll.Handler
+	LeaveHandler           *leave.Handler
+	NotificationHandler    *notification.Handler
+	CompanyHandler         *company.Handler
+	LoanHandler            *loan.Handler
+	OvertimeHandler        *overtime.Handler
+	SalaryComponentHandler *salarycomponent.Handler
+	BPJSHandler            *bpjs.Handler
+	LatePolicyHandler      *latepolicy.Handler
+	HolidayHandler         *holiday.Handler
+	OfficeHandler          *office.Handler
+	CorrectionHandler      *correction.Handler
+	RosterHandler          *roster.Handler
 
 	AuthMiddleware        *middleware.AuthMiddleware
 	RateLimiterMiddleware *middleware.RateLimiterMiddleware
 
 	GeocodeWorker         attendance.GeocodeWorker
+	PayslipEmailWorker    payroll.PayslipEmailWorker
 	LeaveScheduler        leave.Scheduler
+	AttendanceScheduler   attendance.Scheduler
 	NotificationScheduler notification.Scheduler
 }
 
@@ -80,19 +96,35 @@ func NewContainer() (*Container, error) {
 	companyRepo := company.NewRepository(db.GetDB())
 	loanRepo := loan.NewRepository(db.GetDB())
 	overtimeRepo := overtime.NewRepository(db.GetDB())
+	salaryComponentRepo := salarycomponent.NewRepository(db.GetDB())
+	bpjsRepo := bpjs.NewRepository(db.GetDB())
+	latePolicyRepo := latepolicy.NewRepository(db.GetDB())
+	holidayRepo := holiday.NewRepository(db.GetDB())
+	officeRepo := office.NewRepository(db.GetDB())
+	correctionRepo := correction.NewRepository(db.GetDB())
+	rosterRepo := roster.NewRepository(db.GetDB())
 
 	healthSvc := health.NewService(healthRepo)
 	notificationSvc := notification.NewService(wsHub, notificationRepo)
 	authSvc := auth.NewService(userRepo, bcrypt, jwt)
-	attendanceSvc := attendance.NewService(attendanceRepo, userRepo, storage, geocodeWorker, transactionManager, excel)
+	attendanceSvc := attendance.NewService(attendanceRepo, userRepo, storage, geocodeWorker, transactionManager, excel, latePolicyRepo, officeRepo, leaveRepo, companyRepo, holidayRepo, notificationSvc, rosterRepo)
 	masterSvc := master.NewService(masterRepo)
-	payrollSvc := payroll.NewService(payrollRepo, userRepo, reimburseRepo, attendanceRepo, companyRepo, notificationSvc, transactionManager, httpClient.GetClient(), email, loanRepo, overtimeRepo)
+	payrollSvc := payroll.NewService(payrollRepo, userRepo, reimburseRepo, attendanceRepo, companyRepo, notificationSvc, transactionManager, httpClient.GetClient(), email, loanRepo, overtimeRepo, salaryComponentRepo, bpjsRepo, excel, latePolicyRepo, holidayRepo, leaveRepo)
 	leaveSvc := leave.NewService(leaveRepo, storage, notificationSvc, userRepo, transactionManager, excel)
 	userSvc := user.NewService(userRepo, bcrypt, storage, leaveSvc, transactionManager)
-	reimburseSvc := reimbursement.NewService(reimburseRepo, storage, notificationSvc, userRepo, transactionManager, excel)
+	reimburseSvc := reimbursement.NewService(reimburseRepo, storage, notificationSvc, userRepo, transactionManager, excel, payrollRepo)
 	companySvc := company.NewService(companyRepo, storage)
-	loanSvc := loan.NewService(loanRepo, notificationSvc, userRepo, transactionManager, excel)
+	loanSvc := loan.NewService(loanRepo, notificationSvc, userRepo, transactionManager, excel, payrollRepo)
 	overtimeSvc := overtime.NewService(overtimeRepo, notificationSvc, userRepo, transactionManager, excel)
+	salaryComponentSvc := salarycomponent.NewService(salaryComponentRepo)
+	bpjsSvc := bpjs.NewService(bpjsRepo)
+	latePolicySvc := latepolicy.NewService(latePolicyRepo, transactionManager)
+	holidaySvc := holiday.NewService(holidayRepo)
+	officeSvc := office.NewService(officeRepo, transactionManager)
+	correctionSvc := correction.NewService(correctionRepo, attendanceRepo, userRepo, latePolicyRepo, payrollRepo, notificationSvc, transactionManager, rosterRepo)
+	rosterSvc := roster.NewService(rosterRepo, userRepo, transactionManager, excel)
+
+	payslipEmailWorker := payroll.NewPayslipEmailWorker(payrollRepo, payrollSvc, wsHub, 500)
 
 	healthHandler := health.NewHandler(healthSvc)
 	authHandler := auth.NewHandler(authSvc)
@@ -100,17 +132,25 @@ func NewContainer() (*Container, error) {
 	attendanceHandler := attendance.NewHandler(attendanceSvc)
 	masterHandler := master.NewHandler(masterSvc)
 	reimburseHandler := reimbursement.NewHandler(reimburseSvc)
-	payrollHandler := payroll.NewHandler(payrollSvc)
+	payrollHandler := payroll.NewHandler(payrollSvc, payslipEmailWorker)
 	leaveHandler := leave.NewHandler(leaveSvc)
 	notificationHandler := notification.NewHandler(wsHub, notificationSvc)
 	companyHandler := company.NewHandler(companySvc)
 	loanHandler := loan.NewHandler(loanSvc)
 	overtimeHandler := overtime.NewHandler(overtimeSvc)
+	salaryComponentHandler := salarycomponent.NewHandler(salaryComponentSvc)
+	bpjsHandler := bpjs.NewHandler(bpjsSvc)
+	latePolicyHandler := latepolicy.NewHandler(latePolicySvc)
+	holidayHandler := holiday.NewHandler(holidaySvc)
+	officeHandler := office.NewHandler(officeSvc)
+	correctionHandler := correction.NewHandler(correctionSvc)
+	rosterHandler := roster.NewHandler(rosterSvc)
 
 	authMiddleware := middleware.NewAuthMiddleware(jwt)
 	rateLimiterMiddleware := middleware.NewRateLimiterMiddleware()
 
 	leaveScheduler := leave.NewScheduler(cronScheduler, leaveSvc)
+	attendanceScheduler := attendance.NewScheduler(cronScheduler, attendanceSvc)
 	notificationScheduler := notification.NewScheduler(cronScheduler, notificationSvc)
 
 	return &Container{
@@ -125,24 +165,33 @@ func NewContainer() (*Container, error) {
 		Email:        email,
 		Excel:        excel,
 
-		HealthCheckHandler:   healthHandler,
-		AuthHandler:          authHandler,
-		UserHandler:          userHandler,
-		AttendanceHandler:    attendanceHandler,
-		MasterHandler:        masterHandler,
-		ReimbursementHandler: reimburseHandler,
-		PayrollHandler:       payrollHandler,
-		LeaveHandler:         leaveHandler,
-		NotificationHandler:  notificationHandler,
-		CompanyHandler:       companyHandler,
-		LoanHandler:          loanHandler,
-		OvertimeHandler:      overtimeHandler,
+		HealthCheckHandler:     healthHandler,
+		AuthHandler:            authHandler,
+		UserHandler:            userHandler,
+		AttendanceHandler:      attendanceHandler,
+		MasterHandler:          masterHandler,
+		ReimbursementHandler:   reimburseHandler,
+		PayrollHandler:         payrollHandler,
+		LeaveHandler:           leaveHandler,
+		NotificationHandler:    notificationHandler,
+		CompanyHandler:         companyHandler,
+		LoanHandler:            loanHandler,
+		OvertimeHandler:        overtimeHandler,
+		SalaryComponentHandler: salaryComponentHandler,
+		BPJSHandler:            bpjsHandler,
+		LatePolicyHandler:      latePolicyHandler,
+		HolidayHandler:         holidayHandler,
+		OfficeHandler:          officeHandler,
+		CorrectionHandler:      correctionHandler,
+		RosterHandler:          rosterHandler,
 
 		AuthMiddleware:        authMiddleware,
 		RateLimiterMiddleware: rateLimiterMiddleware,
 
 		GeocodeWorker:         geocodeWorker,
+		PayslipEmailWorker:    payslipEmailWorker,
 		LeaveScheduler:        leaveScheduler,
+		AttendanceScheduler:   attendanceScheduler,
 		NotificationScheduler: notificationScheduler,
 	}, nil
 }
@@ -157,10 +206,18 @@ func (c *Container) Close() error {
 		c.GeocodeWorker.Stop()
 	}
 
+	if c.PayslipEmailWorker != nil {
+		c.PayslipEmailWorker.Stop()
+	}
+
 	if c.LeaveScheduler != nil {
 		c.LeaveScheduler.Stop()
 	}
 
+	if c.AttendanceScheduler != nil {
+		c.AttendanceScheduler.Stop()
+	}
+
 	if c.NotificationScheduler != nil {
 		c.NotificationScheduler.Stop()
 	}
@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"bytes"
+	"io"
 
 	"github.com/xuri/excelize/v2"
 )
@@ -10,6 +11,7 @@ type ExcelProvider interface {
 	GenerateSimpleExcel(sheetName string, headers []string, rows [][]interface{}) ([]byte, error)
 	NewFile() *excelize.File
 	WriteToBuffer(file *excelize.File) ([]byte, error)
+	ReadRows(reader io.Reader) ([][]string, error)
 }
 
 type excelProvider struct{}
@@ -80,3 +82,14 @@ func (p *excelProvider) WriteToBuffer(file *excelize.File) ([]byte, error) {
 
 	return buffer.Bytes(), nil
 }
+
+// ReadRows return every row of the first sheet
+func (p *excelProvider) ReadRows(reader io.Reader) ([][]string, error) {
+	f, err := excelize.OpenReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return f.GetRows(f.GetSheetName(0))
+}
@@ -0,0 +1,157 @@
+package attendance
+
+import (
+	"basekarya-backend/internal/modules/master"
+	"basekarya-backend/internal/modules/roster"
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/logger"
+	"context"
+	"fmt"
+	"time"
+)
+
+// MarkAbsent insert ABSENT attendance for employees that did not clock in until their shift ended,
+// employee on approved leave & holiday are skipped, rest day of the work week only apply to employee without roster entry.
+// it is safe to run repeatedly
+func (s *service) MarkAbsent(ctx context.Context, now time.Time) (int, error) {
+	employees, err := s.user.FindAllEmployeeActive(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch employees: %w", err)
+	}
+
+	comp, err := s.company.FindByID(ctx, 1)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch company: %w", err)
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var absences []Attendance
+	// yesterday is checked again for overnight shift & shift that end right before midnight
+	for _, date := range []time.Time{today.AddDate(0, 0, -1), today} {
+		workingDay := isWorkingDay(date, comp.WorkDaysPerWeek)
+
+		holidays, err := s.holiday.FindByDateRange(ctx, date, date)
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch holidays: %w", err)
+		}
+		if len(holidays) > 0 {
+			continue
+		}
+
+		recorded, err := s.repo.GetRecordedEmployeeIDs(ctx, date)
+		if err != nil {
+			return 0, err
+		}
+
+		onLeave, err := s.leave.GetEmployeeIDsOnLeave(ctx, date)
+		if err != nil {
+			return 0, err
+		}
+
+		rosters, err := s.roster.FindByDate(ctx, date)
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch roster: %w", err)
+		}
+
+		for i := range employees {
+			emp := &employees[i]
+			if recorded[emp.ID] || onLeave[emp.ID] {
+				continue
+			}
+
+			var entry *roster.ShiftRoster
+			if r, ok := rosters[emp.ID]; ok {
+				entry = &r
+			}
+
+			// roster entry decide the working day itself, the work week is the fallback of employee without roster
+			if entry == nil && !workingDay {
+				continue
+			}
+
+			// day off on the roster is never an absence
+			shift := roster.ResolveShift(emp, entry)
+			if shift == nil {
+				continue
+			}
+			if emp.JoinDate != nil && date.Before(*emp.JoinDate) {
+				continue
+			}
+			if emp.EndDate != nil && date.After(*emp.EndDate) {
+				continue
+			}
+
+			ended, err := shiftEnded(shift, date, now)
+			if err != nil {
+				logger.Errorf("invalid time of shift %d: %v", shift.ID, err)
+				continue
+			}
+			if !ended {
+				continue
+			}
+
+			absences = append(absences, Attendance{
+				EmployeeID:      emp.ID,
+				ShiftID:         shift.ID,
+				Date:            date,
+				CheckInTime:     date,
+				CheckInAddress:  "SYSTEM_GENERATED",
+				CheckInImageURL: "",
+				Status:          string(constants.AttendanceStatusAbsent),
+				Notes:           "[AUTO ABSENT] No clock-in until the end of shift.",
+			})
+		}
+	}
+
+	if len(absences) == 0 {
+		return 0, nil
+	}
+
+	if err := s.repo.CreateAbsences(ctx, absences); err != nil {
+		return 0, err
+	}
+
+	return len(absences), nil
+}
+
+// shiftEnded report whether the shift starting on date has ended by now
+func shiftEnded(shift *master.Shift, date, now time.Time) (bool, error) {
+	end, err := shiftEndTime(shift, date)
+	if err != nil {
+		return false, err
+	}
+
+	return !now.Before(end), nil
+}
+
+// shiftEndTime return end of the shift starting on date, overnight shift end on the next day
+func shiftEndTime(shift *master.Shift, date time.Time) (time.Time, error) {
+	start, err := combineDateAndTime(date, shift.StartTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	end, err := combineDateAndTime(date, shift.EndTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	return end, nil
+}
+
+// isWorkingDay return false on sunday, and on saturday unless the company work 6 days a week
+func isWorkingDay(date time.Time, workDaysPerWeek int) bool {
+	switch date.Weekday() {
+	case time.Sunday:
+		return false
+	case time.Saturday:
+		return workDaysPerWeek == 6
+	default:
+		return true
+	}
+}
@@ -0,0 +1,52 @@
+package attendance
+
+import (
+	"basekarya-backend/internal/modules/master"
+	"testing"
+	"time"
+)
+
+func TestShiftEnded(t *testing.T) {
+	date := time.Date(2026, 10, 14, 0, 0, 0, 0, time.Local)
+	day := &master.Shift{StartTime: "08:00:00", EndTime: "17:00:00"}
+	night := &master.Shift{StartTime: "22:00:00", EndTime: "06:00:00"}
+
+	tests := []struct {
+		name  string
+		shift *master.Shift
+		now   time.Time
+		want  bool
+	}{
+		{"day shift still running", day, time.Date(2026, 10, 14, 16, 59, 0, 0, time.Local), false},
+		{"day shift ended", day, time.Date(2026, 10, 14, 17, 0, 0, 0, time.Local), true},
+		{"night shift before midnight", night, time.Date(2026, 10, 14, 23, 30, 0, 0, time.Local), false},
+		{"night shift still running next day", night, time.Date(2026, 10, 15, 5, 0, 0, 0, time.Local), false},
+		{"night shift ended next day", night, time.Date(2026, 10, 15, 6, 15, 0, 0, time.Local), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := shiftEnded(tt.shift, date, tt.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("shiftEnded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsWorkingDay(t *testing.T) {
+	saturday := time.Date(2026, 10, 17, 0, 0, 0, 0, time.Local)
+
+	if isWorkingDay(saturday, 5) {
+		t.Error("saturday should be rest day on 5 days work week")
+	}
+	if !isWorkingDay(saturday, 6) {
+		t.Error("saturday should be working day on 6 days work week")
+	}
+	if isWorkingDay(saturday.AddDate(0, 0, 1), 6) {
+		t.Error("sunday should always be rest day")
+	}
+}
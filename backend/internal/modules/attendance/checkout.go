@@ -0,0 +1,90 @@
+package attendance
+
+import (
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/logger"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CloseMissingCheckouts check-out attendance still open after its shift end plus the company grace period,
+// check-out time is set to the shift end & the employee is notified to file a correction
+func (s *service) CloseMissingCheckouts(ctx context.Context, now time.Time) (int, error) {
+	comp, err := s.company.FindByID(ctx, 1)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch company: %w", err)
+	}
+	grace := time.Duration(comp.AutoCheckoutGraceMinutes) * time.Minute
+
+	openAttendances, err := s.repo.FindOpenAttendances(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, att := range openAttendances {
+		if att.Shift == nil {
+			continue
+		}
+
+		checkOutTime, err := missingCheckoutTime(&att, grace, now)
+		if err != nil {
+			logger.Errorf("invalid time of shift %d: %v", att.Shift.ID, err)
+			continue
+		}
+		if checkOutTime == nil {
+			continue
+		}
+
+		// preloaded associations must not be saved back
+		employee := att.Employee
+		att.Employee, att.Shift = nil, nil
+
+		address := "SYSTEM_GENERATED"
+		att.CheckOutTime = checkOutTime
+		att.CheckOutAddress = &address
+		att.IsMissingCheckout = true
+		att.Notes = strings.TrimSpace(att.Notes + " [MISSING_CHECKOUT] Checked out by system at end of shift.")
+
+		if err := s.repo.Update(ctx, &att); err != nil {
+			return total, err
+		}
+		total++
+
+		if employee != nil {
+			err := s.notification.SendNotification(
+				employee.UserID,
+				string(constants.NotificationTypeMissingCheckout),
+				"Lupa Absen Pulang",
+				fmt.Sprintf("Absen pulang tanggal %s ditutup otomatis oleh sistem, silakan ajukan koreksi absensi", att.Date.Format(constants.DefaultTimeFormat)),
+				att.ID,
+			)
+			if err != nil {
+				logger.Errorf("failed to notify missing check-out of attendance %d: %v", att.ID, err)
+			}
+		}
+	}
+
+	return total, nil
+}
+
+// missingCheckoutTime return the system check-out time when the attendance is still open after its shift end plus grace,
+// check-in after the shift end (overtime) is closed at its check-in time so the duration is never negative
+func missingCheckoutTime(att *Attendance, grace time.Duration, now time.Time) (*time.Time, error) {
+	end, err := shiftEndTime(att.Shift, att.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	if end.Before(att.CheckInTime) {
+		end = att.CheckInTime
+	}
+
+	if now.Before(end.Add(grace)) {
+		return nil, nil
+	}
+
+	return &end, nil
+}
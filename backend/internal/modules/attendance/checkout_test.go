@@ -0,0 +1,41 @@
+package attendance
+
+import (
+	"basekarya-backend/internal/modules/master"
+	"testing"
+	"time"
+)
+
+func TestMissingCheckoutTime(t *testing.T) {
+	date := time.Date(2026, 10, 14, 0, 0, 0, 0, time.Local)
+	shift := &master.Shift{StartTime: "08:00:00", EndTime: "17:00:00"}
+	grace := 2 * time.Hour
+
+	att := &Attendance{Date: date, CheckInTime: time.Date(2026, 10, 14, 8, 5, 0, 0, time.Local), Shift: shift}
+
+	got, err := missingCheckoutTime(att, grace, time.Date(2026, 10, 14, 18, 59, 0, 0, time.Local))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Errorf("within grace period should stay open, got %v", got)
+	}
+
+	got, err = missingCheckoutTime(att, grace, time.Date(2026, 10, 14, 19, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2026, 10, 14, 17, 0, 0, 0, time.Local); got == nil || !got.Equal(want) {
+		t.Errorf("check-out = %v, want shift end %v", got, want)
+	}
+
+	// check-in after the shift end is closed at its check-in time
+	att.CheckInTime = time.Date(2026, 10, 14, 18, 0, 0, 0, time.Local)
+	got, err = missingCheckoutTime(att, grace, time.Date(2026, 10, 14, 20, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || !got.Equal(att.CheckInTime) {
+		t.Errorf("check-out = %v, want check-in %v", got, att.CheckInTime)
+	}
+}
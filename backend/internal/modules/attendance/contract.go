@@ -1,9 +1,15 @@
 package attendance
 
 import (
-	"context"
+	"basekarya-backend/internal/modules/company"
+	"basekarya-backend/internal/modules/holiday"
+	"basekarya-backend/internal/modules/latepolicy"
+	"basekarya-backend/internal/modules/office"
+	"basekarya-backend/internal/modules/roster"
 	"basekarya-backend/internal/modules/user"
+	"context"
 	"io"
+	"time"
 )
 
 type StorageProvider interface {
@@ -17,4 +23,38 @@ type LocationFetcher interface {
 type UserProvider interface {
 	FindByID(ctx context.Context, id uint) (*user.User, error)
 	CountActiveEmployee(ctx context.Context) (int64, error)
+	FindAllEmployeeActive(ctx context.Context) ([]user.Employee, error)
+}
+
+type LatePolicyProvider interface {
+	FindActive(ctx context.Context) (*latepolicy.LatePolicy, error)
+}
+
+type NotificationProvider interface {
+	SendNotification(userID uint,
+		Type string,
+		Title string,
+		Message string, relatedID uint) error
+}
+
+type OfficeLocationProvider interface {
+	FindActiveByEmployee(ctx context.Context, employeeID, departmentID uint) ([]office.OfficeLocation, error)
+}
+
+type LeaveProvider interface {
+	IsRemoteWorkApproved(ctx context.Context, employeeID uint, date time.Time) (bool, error)
+	GetEmployeeIDsOnLeave(ctx context.Context, date time.Time) (map[uint]bool, error)
+}
+
+type HolidayProvider interface {
+	FindByDateRange(ctx context.Context, start, end time.Time) ([]holiday.Holiday, error)
+}
+
+type CompanyProvider interface {
+	FindByID(ctx context.Context, id uint) (*company.Company, error)
+}
+
+type RosterProvider interface {
+	FindByEmployeeAndDate(ctx context.Context, employeeID uint, date time.Time) (*roster.ShiftRoster, error)
+	FindByDate(ctx context.Context, date time.Time) (map[uint]roster.ShiftRoster, error)
 }
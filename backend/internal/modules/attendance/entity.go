@@ -32,6 +32,9 @@ type Attendance struct {
 	IsSuspicious bool   `gorm:"default:false;index" json:"is_suspicious"`
 	Notes        string `gorm:"type:varchar(500)" json:"notes"`
 
+	// IsMissingCheckout mean the employee forgot to clock out, check-out is set by system to the end of shift
+	IsMissingCheckout bool `gorm:"default:false;index" json:"is_missing_checkout"`
+
 	LateDurationMinute int `gorm:"default:0" json:"late_duration_minute"`
 
 	CreatedAt time.Time `json:"created_at"`
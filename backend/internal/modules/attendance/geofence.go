@@ -0,0 +1,72 @@
+package attendance
+
+import (
+	"basekarya-backend/internal/modules/office"
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/constants"
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// geofenceViolation is the nearest assigned office of a clock outside every office radius
+type geofenceViolation struct {
+	Office         office.OfficeLocation
+	DistanceMeters float64
+}
+
+// checkGeofence return nil when the coordinate is inside any of the locations,
+// employee without assigned location is not restricted
+func checkGeofence(locations []office.OfficeLocation, lat, long float64) *geofenceViolation {
+	if len(locations) == 0 {
+		return nil
+	}
+
+	nearest := geofenceViolation{DistanceMeters: math.Inf(1)}
+	for _, l := range locations {
+		distance := l.DistanceMeter(lat, long)
+		if distance <= float64(l.RadiusMeter) {
+			return nil
+		}
+
+		if distance < nearest.DistanceMeters {
+			nearest = geofenceViolation{Office: l, DistanceMeters: distance}
+		}
+	}
+
+	return &nearest
+}
+
+// validateGeofence check the clock coordinate against the employee assigned offices, the returned note is
+// appended to the attendance & mark it suspicious. Approved remote work day is not restricted
+func (s *service) validateGeofence(ctx context.Context, employee *user.Employee, date time.Time, lat, long float64) (string, error) {
+	locations, err := s.officeLocation.FindActiveByEmployee(ctx, employee.ID, employee.DepartmentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch office location: %w", err)
+	}
+
+	violation := checkGeofence(locations, lat, long)
+	if violation == nil {
+		return "", nil
+	}
+
+	remote, err := s.leave.IsRemoteWorkApproved(ctx, employee.ID, date)
+	if err != nil {
+		return "", fmt.Errorf("failed to check remote work: %w", err)
+	}
+	if remote {
+		return "", nil
+	}
+
+	comp, err := s.company.FindByID(ctx, 1)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch company: %w", err)
+	}
+
+	if comp.GeofencePolicy == constants.GeofencePolicyReject {
+		return "", fmt.Errorf("you are %.0f m from %s, outside of the allowed radius", violation.DistanceMeters, violation.Office.Name)
+	}
+
+	return fmt.Sprintf("[OUTSIDE GEOFENCE] %.0f m from %s.", violation.DistanceMeters, violation.Office.Name), nil
+}
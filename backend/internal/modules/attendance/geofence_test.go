@@ -0,0 +1,32 @@
+package attendance
+
+import (
+	"basekarya-backend/internal/modules/office"
+	"testing"
+)
+
+func TestCheckGeofence(t *testing.T) {
+	locations := []office.OfficeLocation{
+		{Name: "Jakarta", Latitude: -6.200000, Longitude: 106.816666, RadiusMeter: 100},
+		{Name: "Bandung", Latitude: -6.914744, Longitude: 107.609810, RadiusMeter: 200},
+	}
+
+	if v := checkGeofence(nil, -6.2, 106.8); v != nil {
+		t.Errorf("no assigned location should not be restricted, got %+v", v)
+	}
+
+	if v := checkGeofence(locations, -6.200300, 106.816666); v != nil {
+		t.Errorf("~33m from Jakarta office should be inside, got %+v", v)
+	}
+
+	v := checkGeofence(locations, -6.210000, 106.816666)
+	if v == nil {
+		t.Fatal("~1.1km from Jakarta office should be outside")
+	}
+	if v.Office.Name != "Jakarta" {
+		t.Errorf("nearest office = %s, want Jakarta", v.Office.Name)
+	}
+	if v.DistanceMeters < 1000 || v.DistanceMeters > 1200 {
+		t.Errorf("distance = %.0f, want ~1110", v.DistanceMeters)
+	}
+}
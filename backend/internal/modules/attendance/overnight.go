@@ -0,0 +1,74 @@
+package attendance
+
+import (
+	"basekarya-backend/internal/modules/master"
+	"basekarya-backend/internal/modules/roster"
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/constants"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// findOpenOvernightAttendance return the still open attendance of yesterday when its shift run past midnight,
+// clock after midnight until the shift end plus the auto check-out grace is the check-out of that attendance
+func (s *service) findOpenOvernightAttendance(ctx context.Context, employee *user.Employee, now time.Time) (*Attendance, error) {
+	yesterday := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, now.Location())
+
+	att, err := s.repo.FindByEmployeeAndDate(ctx, employee.ID, yesterday)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if att.CheckOutTime != nil || att.Status == string(constants.AttendanceStatusAbsent) || att.Status == string(constants.AttendanceStatusExcused) {
+		return nil, nil
+	}
+
+	entry, err := s.roster.FindByEmployeeAndDate(ctx, employee.ID, yesterday)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch roster: %w", err)
+	}
+
+	shift := roster.ResolveShift(employee, entry)
+	if shift == nil {
+		return nil, nil
+	}
+
+	comp, err := s.company.FindByID(ctx, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch company: %w", err)
+	}
+	grace := time.Duration(comp.AutoCheckoutGraceMinutes) * time.Minute
+
+	within, err := withinOvernightShift(shift, yesterday, now, grace)
+	if err != nil {
+		return nil, errors.New("invalid shift time configuration")
+	}
+	if !within {
+		return nil, nil
+	}
+
+	return att, nil
+}
+
+// withinOvernightShift report whether now is after midnight of an overnight shift starting on date,
+// up to the shift end plus grace
+func withinOvernightShift(shift *master.Shift, date, now time.Time, grace time.Duration) (bool, error) {
+	end, err := shiftEndTime(shift, date)
+	if err != nil {
+		return false, err
+	}
+
+	midnight := time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, date.Location())
+	if end.Before(midnight) {
+		return false, nil
+	}
+
+	return !now.Before(midnight) && now.Before(end.Add(grace)), nil
+}
@@ -0,0 +1,39 @@
+package attendance
+
+import (
+	"basekarya-backend/internal/modules/master"
+	"testing"
+	"time"
+)
+
+func TestWithinOvernightShift(t *testing.T) {
+	date := time.Date(2026, 10, 14, 0, 0, 0, 0, time.Local)
+	night := &master.Shift{StartTime: "22:00:00", EndTime: "06:00:00"}
+	day := &master.Shift{StartTime: "08:00:00", EndTime: "17:00:00"}
+	grace := 2 * time.Hour
+
+	tests := []struct {
+		name  string
+		shift *master.Shift
+		now   time.Time
+		want  bool
+	}{
+		{"before midnight is still the same day", night, time.Date(2026, 10, 14, 23, 30, 0, 0, time.Local), false},
+		{"after midnight before shift end", night, time.Date(2026, 10, 15, 5, 55, 0, 0, time.Local), true},
+		{"after shift end within grace", night, time.Date(2026, 10, 15, 7, 59, 0, 0, time.Local), true},
+		{"after grace is a new day", night, time.Date(2026, 10, 15, 8, 0, 0, 0, time.Local), false},
+		{"day shift never run past midnight", day, time.Date(2026, 10, 15, 1, 0, 0, 0, time.Local), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := withinOvernightShift(tt.shift, date, tt.now, grace)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
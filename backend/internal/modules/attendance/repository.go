@@ -8,17 +8,23 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
 	GetTodayAttendance(ctx context.Context, employeeID uint) (*Attendance, error)
+	FindByEmployeeAndDate(ctx context.Context, employeeID uint, date time.Time) (*Attendance, error)
 	Create(ctx context.Context, attendance *Attendance) error
 	Update(ctx context.Context, attendance *Attendance) error
 	GetHistory(ctx context.Context, employeeID uint, month, year, limit int, cursor string) ([]Attendance, *response.Cursor, error)
 	FindAll(ctx context.Context, filter *FilterParams) ([]Attendance, *response.Cursor, error)
 	CountByStatus(ctx context.Context, status constants.AttendanceStatus, todayDate string) (int64, error)
 	CountAttendanceToday(ctx context.Context, todayDate string) (int64, error)
-	GetBulkLateDuration(ctx context.Context, month, year int) (map[uint]int, error)
+	GetBulkLateOccurrences(ctx context.Context, month, year int) (map[uint][]int, error)
+	GetBulkAbsentDays(ctx context.Context, month, year int) (map[uint]int, error)
+	GetRecordedEmployeeIDs(ctx context.Context, date time.Time) (map[uint]bool, error)
+	CreateAbsences(ctx context.Context, attendances []Attendance) error
+	FindOpenAttendances(ctx context.Context) ([]Attendance, error)
 }
 
 type repository struct {
@@ -42,6 +48,19 @@ func (r *repository) GetTodayAttendance(ctx context.Context, employeeID uint) (*
 	return &att, nil
 }
 
+func (r *repository) FindByEmployeeAndDate(ctx context.Context, employeeID uint, date time.Time) (*Attendance, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var att Attendance
+
+	err := db.Where("employee_id = ? AND date = ?", employeeID, date.Format(constants.DefaultTimeFormat)).
+		First(&att).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &att, nil
+}
+
 func (r *repository) Create(ctx context.Context, attendance *Attendance) error {
 	db := utils.GetDBFromContext(ctx, r.db)
 	return db.Create(attendance).Error
@@ -180,6 +199,7 @@ func (r *repository) CountAttendanceToday(ctx context.Context, todayDate string)
 	var totalStatus int64
 	if err := db.Model(&Attendance{}).
 		Where("date = ?", todayDate).
+		Where("status IN ?", []constants.AttendanceStatus{constants.AttendanceStatusPresent, constants.AttendanceStatusLate}).
 		Count(&totalStatus).Error; err != nil {
 		return 0, err
 	}
@@ -187,17 +207,46 @@ func (r *repository) CountAttendanceToday(ctx context.Context, todayDate string)
 	return totalStatus, nil
 }
 
-func (r *repository) GetBulkLateDuration(ctx context.Context, month, year int) (map[uint]int, error) {
+// GetBulkLateOccurrences return late minute of every late check-in of a period, keyed by employee id
+func (r *repository) GetBulkLateOccurrences(ctx context.Context, month, year int) (map[uint][]int, error) {
 	db := utils.GetDBFromContext(ctx, r.db)
 	type Result struct {
-		UserID      uint
-		TotalMinute int
+		EmployeeID         uint
+		LateDurationMinute int
 	}
 	var results []Result
 
 	err := db.Model(&Attendance{}).
-		Select("employee_id, COALESCE(SUM(late_duration_minute), 0) as total_minute").
+		Select("employee_id, late_duration_minute").
 		Where("MONTH(check_in_time) = ? AND YEAR(check_in_time) = ?", month, year).
+		Where("late_duration_minute > 0").
+		Order("check_in_time ASC").
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	dataMap := make(map[uint][]int)
+	for _, res := range results {
+		dataMap[res.EmployeeID] = append(dataMap[res.EmployeeID], res.LateDurationMinute)
+	}
+
+	return dataMap, nil
+}
+
+// GetBulkAbsentDays return number of ABSENT attendance of a period, keyed by employee id
+func (r *repository) GetBulkAbsentDays(ctx context.Context, month, year int) (map[uint]int, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	type Result struct {
+		EmployeeID uint
+		Total      int
+	}
+	var results []Result
+
+	err := db.Model(&Attendance{}).
+		Select("employee_id, COUNT(*) as total").
+		Where("MONTH(date) = ? AND YEAR(date) = ?", month, year).
+		Where("status = ?", constants.AttendanceStatusAbsent).
 		Group("employee_id").
 		Scan(&results).Error
 	if err != nil {
@@ -206,8 +255,52 @@ func (r *repository) GetBulkLateDuration(ctx context.Context, month, year int) (
 
 	dataMap := make(map[uint]int)
 	for _, res := range results {
-		dataMap[res.UserID] = res.TotalMinute
+		dataMap[res.EmployeeID] = res.Total
+	}
+
+	return dataMap, nil
+}
+
+// GetRecordedEmployeeIDs return employees that already have attendance of any status on the date
+func (r *repository) GetRecordedEmployeeIDs(ctx context.Context, date time.Time) (map[uint]bool, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+
+	var employeeIDs []uint
+	err := db.Model(&Attendance{}).
+		Where("date = ?", date.Format(constants.DefaultTimeFormat)).
+		Pluck("employee_id", &employeeIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	dataMap := make(map[uint]bool)
+	for _, id := range employeeIDs {
+		dataMap[id] = true
+	}
+
+	return dataMap, nil
+}
+
+// CreateAbsences insert ABSENT attendance, employee that clocked in meanwhile keep its attendance
+func (r *repository) CreateAbsences(ctx context.Context, attendances []Attendance) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(attendances, 100).Error
+}
+
+// FindOpenAttendances return check-in without check-out, system generated attendance is never open
+func (r *repository) FindOpenAttendances(ctx context.Context) ([]Attendance, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var attendances []Attendance
+
+	err := db.
+		Preload("Employee").
+		Preload("Shift").
+		Where("check_out_time IS NULL").
+		Where("status IN ?", []constants.AttendanceStatus{constants.AttendanceStatusPresent, constants.AttendanceStatusLate}).
+		Find(&attendances).Error
+	if err != nil {
+		return nil, err
 	}
 
-	return dataMap, err
+	return attendances, nil
 }
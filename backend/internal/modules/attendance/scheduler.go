@@ -0,0 +1,56 @@
+package attendance
+
+import (
+	"basekarya-backend/internal/infrastructure"
+	"basekarya-backend/pkg/logger"
+	"context"
+	"time"
+)
+
+type Scheduler interface {
+	Start()
+	Stop()
+}
+
+type scheduler struct {
+	cronProvider *infrastructure.CronProvider
+	service      Service
+}
+
+func NewScheduler(cronProvider *infrastructure.CronProvider, service Service) Scheduler {
+	return &scheduler{cronProvider, service}
+}
+
+func (sch *scheduler) Start() {
+	logger.Info("Attendance Scheduler Started...")
+
+	// shifts end at different time, absence & missing check-out is checked every 15 minutes
+	_, err := sch.cronProvider.GetCron().AddFunc("*/15 * * * *", func() {
+		total, err := sch.service.MarkAbsent(context.Background(), time.Now())
+		if err != nil {
+			logger.Errorf("[SCHEDULER] Mark Absent Failed: %v\n", err)
+		} else if total > 0 {
+			logger.Infof("[SCHEDULER] Success! %d employees marked absent.", total)
+		}
+
+		total, err = sch.service.CloseMissingCheckouts(context.Background(), time.Now())
+		if err != nil {
+			logger.Errorf("[SCHEDULER] Close Missing Check-out Failed: %v\n", err)
+		} else if total > 0 {
+			logger.Infof("[SCHEDULER] Success! %d attendances checked out by system.", total)
+		}
+	})
+
+	if err != nil {
+		logger.Errorf("Failed to start scheduler ", err)
+	}
+
+	sch.cronProvider.GetCron().Start()
+}
+
+func (sch *scheduler) Stop() {
+	if sch.cronProvider != nil && sch.cronProvider.GetCron() != nil {
+		sch.cronProvider.GetCron().Stop()
+		logger.Info("Attendance Scheduler Stopped.")
+	}
+}
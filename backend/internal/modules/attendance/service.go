@@ -2,6 +2,8 @@ package attendance
 
 import (
 	"basekarya-backend/internal/infrastructure"
+	"basekarya-backend/internal/modules/roster"
+	"basekarya-backend/internal/modules/user"
 	"basekarya-backend/pkg/constants"
 	"basekarya-backend/pkg/response"
 	"basekarya-backend/pkg/utils"
@@ -9,6 +11,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/xuri/excelize/v2"
@@ -22,6 +25,8 @@ type Service interface {
 	GetAllRecap(ctx context.Context, filter *FilterParams) ([]RecapResponse, *response.Meta, error)
 	GenerateExcel(ctx context.Context, filter *FilterParams) ([]byte, error)
 	GetDashboardStats(ctx context.Context) (*DashboardStatResponse, error)
+	MarkAbsent(ctx context.Context, now time.Time) (int, error)
+	CloseMissingCheckouts(ctx context.Context, now time.Time) (int, error)
 }
 
 type service struct {
@@ -31,10 +36,17 @@ type service struct {
 	geocodeWorker      GeocodeWorker
 	transactionManager infrastructure.TransactionManager
 	excel              infrastructure.ExcelProvider
+	latePolicy         LatePolicyProvider
+	officeLocation     OfficeLocationProvider
+	leave              LeaveProvider
+	company            CompanyProvider
+	holiday            HolidayProvider
+	notification       NotificationProvider
+	roster             RosterProvider
 }
 
-func NewService(repo Repository, user UserProvider, storage StorageProvider, geocodeWorker GeocodeWorker, transactionManager infrastructure.TransactionManager, excel infrastructure.ExcelProvider) Service {
-	return &service{repo, user, storage, geocodeWorker, transactionManager, excel}
+func NewService(repo Repository, user UserProvider, storage StorageProvider, geocodeWorker GeocodeWorker, transactionManager infrastructure.TransactionManager, excel infrastructure.ExcelProvider, latePolicy LatePolicyProvider, officeLocation OfficeLocationProvider, leave LeaveProvider, company CompanyProvider, holiday HolidayProvider, notification NotificationProvider, roster RosterProvider) Service {
+	return &service{repo, user, storage, geocodeWorker, transactionManager, excel, latePolicy, officeLocation, leave, company, holiday, notification, roster}
 }
 
 func (s *service) Clock(ctx context.Context, userID uint, req *ClockRequest) (*AttendanceResponse, error) {
@@ -47,15 +59,10 @@ func (s *service) Clock(ctx context.Context, userID uint, req *ClockRequest) (*A
 
 		employee := u.Employee
 
-		if employee.Shift == nil {
-			return errors.New("employee shift not assigned")
-		}
-
 		imgBytes, err := utils.DecodeBase64Image(req.ImageBase64)
 		if err != nil {
 			return errors.New("invalid image")
 		}
-		imageReader := bytes.NewReader(imgBytes)
 
 		// set address temporary
 		tempAddress := fmt.Sprintf("Processing location... (%f, %f)", req.Latitude, req.Longitude)
@@ -64,7 +71,31 @@ func (s *service) Clock(ctx context.Context, userID uint, req *ClockRequest) (*A
 		todayString := now.Format(constants.DefaultTimeFormat)
 		fileName := fmt.Sprintf("attendance/%d/%s-%d.jpg", employee.ID, todayString, now.Unix())
 
-		shiftStartToday, err := combineDateAndTime(now, employee.Shift.StartTime)
+		// clock after midnight of an overnight shift is the check-out of the attendance of yesterday
+		overnightAtt, err := s.findOpenOvernightAttendance(ctx, employee, now)
+		if err != nil {
+			return err
+		}
+		if overnightAtt != nil {
+			resp, err = s.checkOut(ctx, employee, overnightAtt, req, imgBytes, fileName, tempAddress, now)
+			return err
+		}
+
+		// the roster entry of today override the fixed shift of the employee
+		entry, err := s.roster.FindByEmployeeAndDate(ctx, employee.ID, now)
+		if err != nil {
+			return fmt.Errorf("failed to fetch roster: %w", err)
+		}
+
+		shift := roster.ResolveShift(employee, entry)
+		if shift == nil {
+			if entry != nil {
+				return errors.New("you are not scheduled to work today")
+			}
+			return errors.New("employee shift not assigned")
+		}
+
+		shiftStartToday, err := combineDateAndTime(now, shift.StartTime)
 		if err != nil {
 			return errors.New("invalid shift time configuration")
 		}
@@ -89,23 +120,30 @@ func (s *service) Clock(ctx context.Context, userID uint, req *ClockRequest) (*A
 		todayAtt, err := s.repo.GetTodayAttendance(ctx, employee.ID)
 		// if today no data, its check-in of that employee
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			// calculate status is LATE or PRESENT
-			lateThreshold := shiftStartToday.Add(15 * time.Minute)
-			status := string(constants.AttendanceStatusPresent)
+			policy, err := s.latePolicy.FindActive(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to fetch late policy: %w", err)
+			}
 
-			// compare current time with shift time, if more than late threshold, status will changed to LATE
-			if now.After(lateThreshold) {
+			// calculate status is LATE or PRESENT, late within grace minutes of the policy still PRESENT
+			status := string(constants.AttendanceStatusPresent)
+			if policy.IsLate(lateMinute) {
 				status = string(constants.AttendanceStatusLate)
 			}
 
-			imgUrl, err := s.storage.UploadFileByte(ctx, fmt.Sprintf("in-%s", fileName), imageReader, int64(len(imgBytes)), "image/jpg")
+			geofenceNote, err := s.validateGeofence(ctx, employee, now, req.Latitude, req.Longitude)
+			if err != nil {
+				return err
+			}
+
+			imgUrl, err := s.storage.UploadFileByte(ctx, fmt.Sprintf("in-%s", fileName), bytes.NewReader(imgBytes), int64(len(imgBytes)), "image/jpg")
 			if err != nil {
 				return err
 			}
 
 			newAtt := &Attendance{
 				EmployeeID:         employee.ID,
-				ShiftID:            employee.ShiftID,
+				ShiftID:            shift.ID,
 				Date:               time.Now(),
 				CheckInTime:        now,
 				CheckInLat:         req.Latitude,
@@ -118,6 +156,11 @@ func (s *service) Clock(ctx context.Context, userID uint, req *ClockRequest) (*A
 				IsSuspicious:       false,
 			}
 
+			if geofenceNote != "" {
+				newAtt.IsSuspicious = true
+				newAtt.Notes = strings.TrimSpace(newAtt.Notes + " " + geofenceNote)
+			}
+
 			if err := s.repo.Create(ctx, newAtt); err != nil {
 				return err
 			}
@@ -139,71 +182,88 @@ func (s *service) Clock(ctx context.Context, userID uint, req *ClockRequest) (*A
 			return nil
 		}
 
+		if todayAtt != nil && todayAtt.Status == string(constants.AttendanceStatusAbsent) {
+			return errors.New("you have been marked absent for today, please submit an attendance correction")
+		}
+
 		// if today already have attendance, but the checkout time is still null, its checkout of that employee
 		if todayAtt != nil && todayAtt.CheckOutTime == nil {
+			resp, err = s.checkOut(ctx, employee, todayAtt, req, imgBytes, fileName, tempAddress, now)
+			return err
+		}
 
-			// Calculate Teleportation Check (to detect distance between location check-in & check-out employee, will get mark if suspicious )
-			distanceMeters := utils.CalculateDistance(todayAtt.CheckInLat, todayAtt.CheckInLong, req.Latitude, req.Longitude)
-			distanceKm := distanceMeters / 1000.0
-
-			durationHours := time.Since(todayAtt.CheckInTime).Hours()
+		return errors.New("you have already completed attendance for today")
+	})
 
-			isSuspicious := false
-			notes := ""
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
 
-			if durationHours > 0.01 {
-				speedKmH := distanceKm / durationHours
+// checkOut close the open attendance with the clock of now, marking it suspicious on teleportation or outside geofence
+func (s *service) checkOut(ctx context.Context, employee *user.Employee, att *Attendance, req *ClockRequest, imgBytes []byte, fileName, tempAddress string, now time.Time) (*AttendanceResponse, error) {
+	// Calculate Teleportation Check (to detect distance between location check-in & check-out employee, will get mark if suspicious )
+	distanceMeters := utils.CalculateDistance(att.CheckInLat, att.CheckInLong, req.Latitude, req.Longitude)
+	distanceKm := distanceMeters / 1000.0
 
-				if speedKmH > 200 && distanceKm > 2.0 {
-					isSuspicious = true
-					notes = fmt.Sprintf("[SUSPICIOUS] Speed %.2f km/h detected. Teleportation check failed.", speedKmH)
-				}
-			}
+	durationHours := now.Sub(att.CheckInTime).Hours()
 
-			imgUrl, err := s.storage.UploadFileByte(ctx, fmt.Sprintf("out-%s", fileName), imageReader, int64(len(imgBytes)), "image/jpg")
-			if err != nil {
-				return err
-			}
+	isSuspicious := false
+	notes := ""
 
-			todayAtt.CheckOutTime = &now
-			todayAtt.CheckOutLat = &req.Latitude
-			todayAtt.CheckOutLong = &req.Longitude
-			todayAtt.CheckOutImageURL = &imgUrl
-			todayAtt.CheckOutAddress = &tempAddress
+	if durationHours > 0.01 {
+		speedKmH := distanceKm / durationHours
 
-			if isSuspicious {
-				todayAtt.IsSuspicious = true
-				todayAtt.Notes = todayAtt.Notes + " " + notes
-			}
+		if speedKmH > 200 && distanceKm > 2.0 {
+			isSuspicious = true
+			notes = fmt.Sprintf("[SUSPICIOUS] Speed %.2f km/h detected. Teleportation check failed.", speedKmH)
+		}
+	}
 
-			if err := s.repo.Update(ctx, todayAtt); err != nil {
-				return err
-			}
+	geofenceNote, err := s.validateGeofence(ctx, employee, now, req.Latitude, req.Longitude)
+	if err != nil {
+		return nil, err
+	}
+	if geofenceNote != "" {
+		isSuspicious = true
+		notes = strings.TrimSpace(notes + " " + geofenceNote)
+	}
 
-			// process this attendance (check-out) to geocode worker queue
-			s.geocodeWorker.Enqueue(GeocodeJob{
-				AttendanceID: todayAtt.ID,
-				Latitude:     req.Latitude,
-				Longitude:    req.Longitude,
-				IsCheckout:   true,
-			})
+	imgUrl, err := s.storage.UploadFileByte(ctx, fmt.Sprintf("out-%s", fileName), bytes.NewReader(imgBytes), int64(len(imgBytes)), "image/jpg")
+	if err != nil {
+		return nil, err
+	}
 
-			resp = &AttendanceResponse{
-				Type:    string(constants.AttendanceTypeCheckOut),
-				Status:  todayAtt.Status,
-				Time:    now,
-				Message: "Check-out successful",
-			}
-			return nil
-		}
+	att.CheckOutTime = &now
+	att.CheckOutLat = &req.Latitude
+	att.CheckOutLong = &req.Longitude
+	att.CheckOutImageURL = &imgUrl
+	att.CheckOutAddress = &tempAddress
 
-		return errors.New("you have already completed attendance for today")
-	})
+	if isSuspicious {
+		att.IsSuspicious = true
+		att.Notes = att.Notes + " " + notes
+	}
 
-	if err != nil {
+	if err := s.repo.Update(ctx, att); err != nil {
 		return nil, err
 	}
-	return resp, nil
+
+	// process this attendance (check-out) to geocode worker queue
+	s.geocodeWorker.Enqueue(GeocodeJob{
+		AttendanceID: att.ID,
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
+		IsCheckout:   true,
+	})
+
+	return &AttendanceResponse{
+		Type:    string(constants.AttendanceTypeCheckOut),
+		Status:  att.Status,
+		Time:    now,
+		Message: "Check-out successful",
+	}, nil
 }
 
 func (s *service) GetTodayStatus(ctx context.Context, userID uint) (*TodayStatusResponse, error) {
@@ -214,6 +274,19 @@ func (s *service) GetTodayStatus(ctx context.Context, userID uint) (*TodayStatus
 
 	att, err := s.repo.GetTodayAttendance(ctx, user.Employee.ID)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// overnight shift of yesterday is still waiting for its check-out
+		overnightAtt, err := s.findOpenOvernightAttendance(ctx, user.Employee, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if overnightAtt != nil {
+			return &TodayStatusResponse{
+				Status:      overnightAtt.Status,
+				Type:        string(constants.AttendanceTypeCheckIn),
+				CheckInTime: &overnightAtt.CheckInTime,
+			}, nil
+		}
+
 		return &TodayStatusResponse{
 			Status: string(constants.AttendanceStatusAbsent),
 			Type:   string(constants.AttendanceTypeNone),
@@ -223,6 +296,13 @@ func (s *service) GetTodayStatus(ctx context.Context, userID uint) (*TodayStatus
 		return nil, err
 	}
 
+	if att.Status == string(constants.AttendanceStatusAbsent) {
+		return &TodayStatusResponse{
+			Status: att.Status,
+			Type:   string(constants.AttendanceTypeNone),
+		}, nil
+	}
+
 	if att.CheckOutTime == nil {
 		return &TodayStatusResponse{
 			Status:      att.Status,
@@ -413,16 +493,16 @@ func (s *service) GetDashboardStats(ctx context.Context) (*DashboardStatResponse
 		return nil, err
 	}
 
+	totalAbsentToday, err := s.repo.CountByStatus(ctx, constants.AttendanceStatusAbsent, todayDate)
+	if err != nil {
+		return nil, err
+	}
+
 	stats := &DashboardStatResponse{
 		TotalEmployees: totalActiveEmployee,
 		PresentToday:   totalPresentToday,
 		LateToday:      totalLateToday,
-	}
-
-	if stats.TotalEmployees >= stats.PresentToday {
-		stats.AbsentToday = stats.TotalEmployees - stats.PresentToday
-	} else {
-		stats.AbsentToday = 0
+		AbsentToday:    totalAbsentToday,
 	}
 
 	return stats, nil
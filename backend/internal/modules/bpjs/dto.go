@@ -0,0 +1,10 @@
+package bpjs
+
+import "basekarya-backend/pkg/money"
+
+type UpdateRateRequest struct {
+	EmployeeRate float64     `json:"employee_rate" validate:"min=0,max=100"`
+	EmployerRate float64     `json:"employer_rate" validate:"min=0,max=100"`
+	SalaryCap    money.Money `json:"salary_cap" validate:"min=0"`
+	IsActive     *bool       `json:"is_active"`
+}
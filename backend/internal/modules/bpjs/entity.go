@@ -0,0 +1,44 @@
+package bpjs
+
+import (
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"time"
+)
+
+type ContributionRate struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Program constants.BPJSProgram `gorm:"type:varchar(20);uniqueIndex;not null" json:"program"`
+
+	// rates are percentage of contribution base
+	EmployeeRate float64 `gorm:"type:decimal(5,2);default:0" json:"employee_rate"`
+	EmployerRate float64 `gorm:"type:decimal(5,2);default:0" json:"employer_rate"`
+
+	// SalaryCap is the maximum contribution base, zero means no cap
+	SalaryCap money.Money `gorm:"type:decimal(15,2);default:0" json:"salary_cap"`
+
+	IsActive bool `gorm:"default:true" json:"is_active"`
+}
+
+func (ContributionRate) TableName() string {
+	return "bpjs_contribution_rates"
+}
+
+// ContributionBase return the salary used as contribution base after applying cap
+func (r *ContributionRate) ContributionBase(salary money.Money) money.Money {
+	if r.SalaryCap > 0 && salary > r.SalaryCap {
+		return r.SalaryCap
+	}
+
+	return salary
+}
+
+// Calculate return employee & employer share of the given salary, rounded to rupiah
+func (r *ContributionRate) Calculate(salary money.Money) (money.Money, money.Money) {
+	base := r.ContributionBase(salary)
+
+	return base.Percent(r.EmployeeRate).Round(), base.Percent(r.EmployerRate).Round()
+}
@@ -0,0 +1,54 @@
+package bpjs
+
+import (
+	"basekarya-backend/pkg/logger"
+	"basekarya-backend/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service}
+}
+
+func (h *Handler) GetRates(ctx echo.Context) error {
+	resp, err := h.service.GetAll(ctx.Request().Context())
+	if err != nil {
+		logger.Errorw("get bpjs rates failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Get BPJS Rates Successfully", resp, nil, nil)
+}
+
+func (h *Handler) UpdateRate(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "invalid id", nil, err, nil)
+	}
+
+	var req UpdateRateRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	err = h.service.Update(ctx.Request().Context(), uint(id), &req)
+	if err != nil {
+		logger.Errorw("update bpjs rate failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "BPJS rate updated successfully", nil, nil, nil)
+}
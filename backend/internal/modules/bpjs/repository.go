@@ -0,0 +1,64 @@
+package bpjs
+
+import (
+	"basekarya-backend/pkg/utils"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindAll(ctx context.Context) ([]ContributionRate, error)
+	FindAllActive(ctx context.Context) ([]ContributionRate, error)
+	FindByID(ctx context.Context, id uint) (*ContributionRate, error)
+	Update(ctx context.Context, rate *ContributionRate) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db}
+}
+
+func (r *repository) FindAll(ctx context.Context) ([]ContributionRate, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var rates []ContributionRate
+
+	err := db.Order("id ASC").Find(&rates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+func (r *repository) FindAllActive(ctx context.Context) ([]ContributionRate, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var rates []ContributionRate
+
+	err := db.Where("is_active = ?", true).Order("id ASC").Find(&rates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+func (r *repository) FindByID(ctx context.Context, id uint) (*ContributionRate, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var rate ContributionRate
+
+	err := db.First(&rate, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &rate, nil
+}
+
+func (r *repository) Update(ctx context.Context, rate *ContributionRate) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Save(rate).Error
+}
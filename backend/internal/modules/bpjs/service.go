@@ -0,0 +1,39 @@
+package bpjs
+
+import (
+	"context"
+	"errors"
+)
+
+type Service interface {
+	GetAll(ctx context.Context) ([]ContributionRate, error)
+	Update(ctx context.Context, id uint, req *UpdateRateRequest) error
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo}
+}
+
+func (s *service) GetAll(ctx context.Context) ([]ContributionRate, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *service) Update(ctx context.Context, id uint, req *UpdateRateRequest) error {
+	rate, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return errors.New("contribution rate not found")
+	}
+
+	rate.EmployeeRate = req.EmployeeRate
+	rate.EmployerRate = req.EmployerRate
+	rate.SalaryCap = req.SalaryCap
+	if req.IsActive != nil {
+		rate.IsActive = *req.IsActive
+	}
+
+	return s.repo.Update(ctx, rate)
+}
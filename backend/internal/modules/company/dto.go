@@ -9,6 +9,23 @@ type CompanyProfileResponse struct {
 	Website     string `json:"website"`
 	TaxNumber   string `json:"tax_number"`
 	LogoURL     string `json:"logo_url"`
+
+	BankName          string `json:"bank_name"`
+	BankAccountNumber string `json:"bank_account_number"`
+
+	ProrationBasis  string `json:"proration_basis"`
+	WorkDaysPerWeek int    `json:"work_days_per_week"`
+
+	AbsenceDeductionEnabled     bool   `json:"absence_deduction_enabled"`
+	UnpaidLeaveDeductionEnabled bool   `json:"unpaid_leave_deduction_enabled"`
+	DailyRateBasis              string `json:"daily_rate_basis"`
+	DailyRateDivisor            int    `json:"daily_rate_divisor"`
+
+	GeofencePolicy           string `json:"geofence_policy"`
+	AutoCheckoutGraceMinutes int    `json:"auto_checkout_grace_minutes"`
+
+	PayslipPasswordEnabled bool   `json:"payslip_password_enabled"`
+	PayslipPasswordRule    string `json:"payslip_password_rule"`
 }
 
 type UpdateCompanyProfileRequest struct {
@@ -18,4 +35,21 @@ type UpdateCompanyProfileRequest struct {
 	PhoneNumber string `form:"phone_number"`
 	Website     string `form:"website"`
 	TaxNumber   string `form:"tax_number"`
+
+	BankName          string `form:"bank_name"`
+	BankAccountNumber string `form:"bank_account_number" validate:"omitempty,numeric"`
+
+	ProrationBasis  string `form:"proration_basis" validate:"omitempty,oneof=WORKING_DAYS CALENDAR_DAYS"`
+	WorkDaysPerWeek int    `form:"work_days_per_week" validate:"omitempty,oneof=5 6"`
+
+	AbsenceDeductionEnabled     *bool  `form:"absence_deduction_enabled"`
+	UnpaidLeaveDeductionEnabled *bool  `form:"unpaid_leave_deduction_enabled"`
+	DailyRateBasis              string `form:"daily_rate_basis" validate:"omitempty,oneof=WORKING_DAYS FIXED_DIVISOR"`
+	DailyRateDivisor            int    `form:"daily_rate_divisor" validate:"omitempty,min=1,max=31"`
+
+	GeofencePolicy           string `form:"geofence_policy" validate:"omitempty,oneof=REJECT FLAG"`
+	AutoCheckoutGraceMinutes int    `form:"auto_checkout_grace_minutes" validate:"omitempty,min=15,max=720"`
+
+	PayslipPasswordEnabled *bool  `form:"payslip_password_enabled"`
+	PayslipPasswordRule    string `form:"payslip_password_rule" validate:"omitempty,oneof=NIK_BIRTH_DATE BIRTH_DATE NIK"`
 }
@@ -1,18 +1,50 @@
 package company
 
-import "time"
+import (
+	"basekarya-backend/pkg/constants"
+	"time"
+)
 
 type Company struct {
-	ID          uint      `gorm:"primaryKey" json:"id"`
-	Name        string    `gorm:"type:varchar(255);not null" json:"name"`
-	Address     string    `gorm:"type:text" json:"address"`
-	Email       string    `gorm:"type:varchar(255)" json:"email"`
-	PhoneNumber string    `gorm:"type:varchar(50)" json:"phone_number"`
-	Website     string    `json:"website"`
-	TaxNumber   string    `json:"tax_number"`
-	LogoURL     string    `json:"logo_url"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	Name        string `gorm:"type:varchar(255);not null" json:"name"`
+	Address     string `gorm:"type:text" json:"address"`
+	Email       string `gorm:"type:varchar(255)" json:"email"`
+	PhoneNumber string `gorm:"type:varchar(50)" json:"phone_number"`
+	Website     string `json:"website"`
+	TaxNumber   string `json:"tax_number"`
+	LogoURL     string `json:"logo_url"`
+
+	// source account of payroll bulk transfer
+	BankName          string `gorm:"type:varchar(50)" json:"bank_name"`
+	BankAccountNumber string `gorm:"type:varchar(50)" json:"bank_account_number"`
+
+	// ProrationBasis decide how salary of mid-period joiner & leaver is prorated
+	ProrationBasis constants.ProrationBasis `gorm:"type:varchar(20);default:'WORKING_DAYS'" json:"proration_basis"`
+
+	// WorkDaysPerWeek is 5 (monday - friday) or 6 (monday - saturday), decide rest day of overtime pay
+	WorkDaysPerWeek int `gorm:"default:5" json:"work_days_per_week"`
+
+	// ABSENT attendance & approved unpaid leave are deducted from salary at daily rate,
+	// daily rate is base salary divided by working days of the period or by a fixed divisor
+	AbsenceDeductionEnabled     bool                     `gorm:"default:true" json:"absence_deduction_enabled"`
+	UnpaidLeaveDeductionEnabled bool                     `gorm:"default:true" json:"unpaid_leave_deduction_enabled"`
+	DailyRateBasis              constants.DailyRateBasis `gorm:"type:varchar(20);default:'WORKING_DAYS'" json:"daily_rate_basis"`
+	DailyRateDivisor            int                      `gorm:"default:21" json:"daily_rate_divisor"`
+
+	// GeofencePolicy decide what happen when clock-in or clock-out is outside of the assigned office radius,
+	// REJECT refuse the attendance while FLAG accept it & mark it suspicious. approved WFH days are always allowed
+	GeofencePolicy constants.GeofencePolicy `gorm:"type:varchar(20);default:'FLAG'" json:"geofence_policy"`
+
+	// AutoCheckoutGraceMinutes is waited after shift end before attendance without check-out is closed by system
+	AutoCheckoutGraceMinutes int `gorm:"default:120" json:"auto_checkout_grace_minutes"`
+
+	// payslip pdf is encrypted with password derived from employee data by the rule
+	PayslipPasswordEnabled bool                          `gorm:"default:false" json:"payslip_password_enabled"`
+	PayslipPasswordRule    constants.PayslipPasswordRule `gorm:"type:varchar(20);default:'NIK_BIRTH_DATE'" json:"payslip_password_rule"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 func (Company) TableName() string {
@@ -1,6 +1,7 @@
 package company
 
 import (
+	"basekarya-backend/pkg/constants"
 	"context"
 	"fmt"
 	"mime/multipart"
@@ -36,6 +37,23 @@ func (s *service) GetProfile(ctx context.Context) (*CompanyProfileResponse, erro
 		Website:     data.Website,
 		TaxNumber:   data.TaxNumber,
 		LogoURL:     data.LogoURL,
+
+		BankName:          data.BankName,
+		BankAccountNumber: data.BankAccountNumber,
+
+		ProrationBasis:  string(data.ProrationBasis),
+		WorkDaysPerWeek: data.WorkDaysPerWeek,
+
+		AbsenceDeductionEnabled:     data.AbsenceDeductionEnabled,
+		UnpaidLeaveDeductionEnabled: data.UnpaidLeaveDeductionEnabled,
+		DailyRateBasis:              string(data.DailyRateBasis),
+		DailyRateDivisor:            data.DailyRateDivisor,
+
+		GeofencePolicy:           string(data.GeofencePolicy),
+		AutoCheckoutGraceMinutes: data.AutoCheckoutGraceMinutes,
+
+		PayslipPasswordEnabled: data.PayslipPasswordEnabled,
+		PayslipPasswordRule:    string(data.PayslipPasswordRule),
 	}, nil
 }
 
@@ -78,6 +96,54 @@ func (s *service) buildCompanyProfileData(ctx context.Context, curr *Company, up
 		curr.TaxNumber = update.TaxNumber
 	}
 
+	if update.BankName != "" {
+		curr.BankName = update.BankName
+	}
+
+	if update.BankAccountNumber != "" {
+		curr.BankAccountNumber = update.BankAccountNumber
+	}
+
+	if update.ProrationBasis != "" {
+		curr.ProrationBasis = constants.ProrationBasis(update.ProrationBasis)
+	}
+
+	if update.WorkDaysPerWeek != 0 {
+		curr.WorkDaysPerWeek = update.WorkDaysPerWeek
+	}
+
+	if update.AbsenceDeductionEnabled != nil {
+		curr.AbsenceDeductionEnabled = *update.AbsenceDeductionEnabled
+	}
+
+	if update.UnpaidLeaveDeductionEnabled != nil {
+		curr.UnpaidLeaveDeductionEnabled = *update.UnpaidLeaveDeductionEnabled
+	}
+
+	if update.DailyRateBasis != "" {
+		curr.DailyRateBasis = constants.DailyRateBasis(update.DailyRateBasis)
+	}
+
+	if update.DailyRateDivisor != 0 {
+		curr.DailyRateDivisor = update.DailyRateDivisor
+	}
+
+	if update.GeofencePolicy != "" {
+		curr.GeofencePolicy = constants.GeofencePolicy(update.GeofencePolicy)
+	}
+
+	if update.AutoCheckoutGraceMinutes != 0 {
+		curr.AutoCheckoutGraceMinutes = update.AutoCheckoutGraceMinutes
+	}
+
+	if update.PayslipPasswordEnabled != nil {
+		curr.PayslipPasswordEnabled = *update.PayslipPasswordEnabled
+	}
+
+	if update.PayslipPasswordRule != "" {
+		curr.PayslipPasswordRule = constants.PayslipPasswordRule(update.PayslipPasswordRule)
+	}
+
 	if file != nil {
 		fileName := fmt.Sprintf("companies/%d/logo-%d.jpg", curr.ID, time.Now().Unix())
 		fileURL, err := s.storage.UploadFileMultipart(ctx, file, fileName)
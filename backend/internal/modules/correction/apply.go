@@ -0,0 +1,93 @@
+package correction
+
+import (
+	"basekarya-backend/internal/modules/attendance"
+	"basekarya-backend/internal/modules/latepolicy"
+	"basekarya-backend/internal/modules/master"
+	"basekarya-backend/pkg/constants"
+	"errors"
+	"time"
+)
+
+// applyCorrection set the proposed clock on the attendance & recompute its lateness against the shift start of the date,
+// check-out not after check-in is on the next day (overnight shift)
+func applyCorrection(att *attendance.Attendance, correction *AttendanceCorrection, shift *master.Shift, policy *latepolicy.LatePolicy) error {
+	if correction.CheckInTime != nil {
+		checkIn, err := clockOn(correction.Date, *correction.CheckInTime)
+		if err != nil {
+			return errors.New("invalid check-in time")
+		}
+		att.CheckInTime = checkIn
+	}
+
+	if correction.CheckOutTime != nil {
+		checkOut, err := clockOn(correction.Date, *correction.CheckOutTime)
+		if err != nil {
+			return errors.New("invalid check-out time")
+		}
+		if !checkOut.After(att.CheckInTime) {
+			checkOut = checkOut.AddDate(0, 0, 1)
+		}
+		att.CheckOutTime = &checkOut
+		att.IsMissingCheckout = false
+	}
+
+	if att.CheckOutTime != nil && !att.CheckOutTime.After(att.CheckInTime) {
+		return errors.New("check-out time must be after check-in time")
+	}
+
+	shiftStart, err := clockOn(correction.Date, shift.StartTime)
+	if err != nil {
+		return errors.New("invalid shift time configuration")
+	}
+
+	// lateness is measured against the shift of the date, which may differ from the shift kept on the attendance
+	att.ShiftID = shift.ID
+	att.LateDurationMinute = 0
+	if att.CheckInTime.After(shiftStart) {
+		att.LateDurationMinute = int(att.CheckInTime.Sub(shiftStart).Minutes())
+	}
+
+	att.Status = string(constants.AttendanceStatusPresent)
+	if policy.IsLate(att.LateDurationMinute) {
+		att.Status = string(constants.AttendanceStatusLate)
+	}
+
+	return nil
+}
+
+// clockOn combine the date with a clock of AttendanceTimeFormat
+func clockOn(date time.Time, clock string) (time.Time, error) {
+	parsed, err := time.Parse(constants.AttendanceTimeFormat, clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Date(date.Year(), date.Month(), date.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), 0, date.Location()), nil
+}
+
+// truncateRunes cut the text to at most n characters without splitting a multi-byte character
+func truncateRunes(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+
+	return string(runes[:n])
+}
+
+// snapshot copy the current values of the attendance before it is corrected
+func snapshot(att *attendance.Attendance, correctionID, changedBy uint) *AttendanceHistory {
+	return &AttendanceHistory{
+		AttendanceID:       att.ID,
+		CorrectionID:       correctionID,
+		ChangedBy:          changedBy,
+		CheckInTime:        att.CheckInTime,
+		CheckOutTime:       att.CheckOutTime,
+		Status:             att.Status,
+		LateDurationMinute: att.LateDurationMinute,
+		IsSuspicious:       att.IsSuspicious,
+		IsMissingCheckout:  att.IsMissingCheckout,
+		Notes:              att.Notes,
+	}
+}
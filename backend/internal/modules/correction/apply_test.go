@@ -0,0 +1,80 @@
+package correction
+
+import (
+	"basekarya-backend/internal/modules/attendance"
+	"basekarya-backend/internal/modules/latepolicy"
+	"basekarya-backend/internal/modules/master"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func clock(v string) *string { return &v }
+
+func TestApplyCorrection(t *testing.T) {
+	date := time.Date(2026, 10, 14, 0, 0, 0, 0, time.Local)
+	shift := &master.Shift{StartTime: "08:00:00", EndTime: "17:00:00"}
+	policy := &latepolicy.LatePolicy{GraceMinutes: 10}
+
+	t.Run("forgotten check-out keep the check-in", func(t *testing.T) {
+		att := &attendance.Attendance{
+			CheckInTime:        time.Date(2026, 10, 14, 8, 30, 0, 0, time.Local),
+			Status:             "LATE",
+			LateDurationMinute: 30,
+			IsMissingCheckout:  true,
+		}
+		err := applyCorrection(att, &AttendanceCorrection{Date: date, CheckOutTime: clock("18:00:00")}, shift, policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := time.Date(2026, 10, 14, 18, 0, 0, 0, time.Local); att.CheckOutTime == nil || !att.CheckOutTime.Equal(want) {
+			t.Errorf("check-out = %v, want %v", att.CheckOutTime, want)
+		}
+		if att.IsMissingCheckout || att.Status != "LATE" || att.LateDurationMinute != 30 {
+			t.Errorf("got missing=%v status=%s late=%d", att.IsMissingCheckout, att.Status, att.LateDurationMinute)
+		}
+	})
+
+	t.Run("check-in within grace become PRESENT", func(t *testing.T) {
+		att := &attendance.Attendance{Status: "ABSENT"}
+		err := applyCorrection(att, &AttendanceCorrection{Date: date, CheckInTime: clock("08:05:00")}, shift, policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if att.Status != "PRESENT" || att.LateDurationMinute != 5 {
+			t.Errorf("got status=%s late=%d, want PRESENT 5", att.Status, att.LateDurationMinute)
+		}
+	})
+
+	t.Run("rostered shift replace the shift of the attendance", func(t *testing.T) {
+		rostered := &master.Shift{ID: 2, StartTime: "14:00:00", EndTime: "22:00:00"}
+		att := &attendance.Attendance{ShiftID: 1, Status: "ABSENT"}
+		err := applyCorrection(att, &AttendanceCorrection{Date: date, CheckInTime: clock("14:00:00")}, rostered, policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if att.ShiftID != 2 || att.Status != "PRESENT" || att.LateDurationMinute != 0 {
+			t.Errorf("got shift=%d status=%s late=%d, want 2 PRESENT 0", att.ShiftID, att.Status, att.LateDurationMinute)
+		}
+	})
+
+	t.Run("check-in after existing check-out is rejected", func(t *testing.T) {
+		out := time.Date(2026, 10, 14, 12, 0, 0, 0, time.Local)
+		att := &attendance.Attendance{CheckInTime: time.Date(2026, 10, 14, 8, 0, 0, 0, time.Local), CheckOutTime: &out}
+		err := applyCorrection(att, &AttendanceCorrection{Date: date, CheckInTime: clock("13:00:00")}, shift, policy)
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestTruncateRunes(t *testing.T) {
+	if got := truncateRunes("terlambat", 20); got != "terlambat" {
+		t.Errorf("got %q, want unchanged", got)
+	}
+
+	got := truncateRunes("macet—banjir", 6)
+	if got != "macet—" || !utf8.ValidString(got) {
+		t.Errorf("got %q, want %q", got, "macet—")
+	}
+}
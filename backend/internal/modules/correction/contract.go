@@ -0,0 +1,40 @@
+package correction
+
+import (
+	"basekarya-backend/internal/modules/attendance"
+	"basekarya-backend/internal/modules/latepolicy"
+	"basekarya-backend/internal/modules/roster"
+	"basekarya-backend/internal/modules/user"
+	"context"
+	"time"
+)
+
+type NotificationProvider interface {
+	SendNotification(userID uint,
+		Type string,
+		Title string,
+		Message string, relatedID uint) error
+}
+
+type UserProvider interface {
+	FindByID(ctx context.Context, id uint) (*user.User, error)
+	FindAdminID(ctx context.Context) (uint, error)
+}
+
+type AttendanceProvider interface {
+	FindByEmployeeAndDate(ctx context.Context, employeeID uint, date time.Time) (*attendance.Attendance, error)
+	Create(ctx context.Context, attendance *attendance.Attendance) error
+	Update(ctx context.Context, attendance *attendance.Attendance) error
+}
+
+type LatePolicyProvider interface {
+	FindActive(ctx context.Context) (*latepolicy.LatePolicy, error)
+}
+
+type PayrollLockProvider interface {
+	IsPeriodLocked(ctx context.Context, date time.Time) (bool, error)
+}
+
+type RosterProvider interface {
+	FindByEmployeeAndDate(ctx context.Context, employeeID uint, date time.Time) (*roster.ShiftRoster, error)
+}
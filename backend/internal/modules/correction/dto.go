@@ -0,0 +1,59 @@
+package correction
+
+import (
+	"basekarya-backend/pkg/constants"
+	"time"
+)
+
+type CorrectionFilter struct {
+	UserID uint
+	Status string
+	Page   int
+	Limit  int
+}
+
+type CorrectionRequest struct {
+	UserID       uint   `json:"-"`
+	EmployeeID   uint   `json:"-"`
+	Date         string `json:"date" validate:"required,datetime=2006-01-02"`
+	CheckInTime  string `json:"check_in_time" validate:"required_without=CheckOutTime,omitempty,datetime=15:04"`
+	CheckOutTime string `json:"check_out_time" validate:"omitempty,datetime=15:04"`
+	Reason       string `json:"reason" validate:"required,min=5,max=488"`
+}
+
+type ActionRequest struct {
+	ID              uint   `json:"-"`
+	ApproverID      uint   `json:"-"`
+	Action          string `json:"action" validate:"required"`
+	RejectionReason string `json:"rejection_reason" validate:"omitempty"`
+}
+
+type CorrectionListResponse struct {
+	ID           uint                       `json:"id"`
+	EmployeeID   uint                       `json:"employee_id"`
+	EmployeeName string                     `json:"employee_name"`
+	EmployeeNIK  string                     `json:"employee_nik"`
+	Date         string                     `json:"date"`
+	CheckInTime  *string                    `json:"check_in_time"`
+	CheckOutTime *string                    `json:"check_out_time"`
+	Status       constants.CorrectionStatus `json:"status"`
+	CreatedAt    time.Time                  `json:"created_at"`
+}
+
+type CorrectionDetailResponse struct {
+	ID              uint                       `json:"id"`
+	EmployeeID      uint                       `json:"employee_id"`
+	EmployeeName    string                     `json:"employee_name"`
+	EmployeeNIK     string                     `json:"employee_nik"`
+	AttendanceID    *uint                      `json:"attendance_id"`
+	Date            string                     `json:"date"`
+	CheckInTime     *string                    `json:"check_in_time"`
+	CheckOutTime    *string                    `json:"check_out_time"`
+	Reason          string                     `json:"reason"`
+	Status          constants.CorrectionStatus `json:"status"`
+	RejectionReason string                     `json:"rejection_reason"`
+	CreatedAt       time.Time                  `json:"created_at"`
+
+	// Original is the attendance before it was corrected, nil until approved or when there was no attendance
+	Original *AttendanceHistory `json:"original"`
+}
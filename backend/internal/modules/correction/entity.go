@@ -0,0 +1,64 @@
+package correction
+
+import (
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/constants"
+	"database/sql"
+	"time"
+)
+
+// AttendanceCorrection is a request of an employee to fix the clock of a date, e.g. GPS failure or forgotten check-out
+type AttendanceCorrection struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID uint      `gorm:"not null" json:"user_id"`
+	User   user.User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+
+	EmployeeID uint          `gorm:"not null" json:"employee_id"`
+	Employee   user.Employee `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+
+	ApprovedBy *uint      `json:"approved_by"`
+	Approver   *user.User `gorm:"foreignKey:ApprovedBy" json:"approver,omitempty"`
+
+	// AttendanceID is nil when the employee has no attendance on the date, it is filled on approval
+	AttendanceID *uint `json:"attendance_id"`
+
+	Date time.Time `gorm:"type:date;not null" json:"date"`
+
+	// proposed clock, nil keep the current value of the attendance
+	CheckInTime  *string `gorm:"type:time" json:"check_in_time"`
+	CheckOutTime *string `gorm:"type:time" json:"check_out_time"`
+
+	Reason string `gorm:"type:text;not null" json:"reason"`
+
+	Status          constants.CorrectionStatus `gorm:"type:enum('PENDING','APPROVED','REJECTED');default:'PENDING'" json:"status"`
+	RejectionReason sql.NullString             `gorm:"type:text" json:"rejection_reason"`
+}
+
+func (AttendanceCorrection) TableName() string {
+	return "attendance_corrections"
+}
+
+// AttendanceHistory keep original values of an attendance before it was changed by an approved correction
+type AttendanceHistory struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	AttendanceID uint `gorm:"not null;index" json:"attendance_id"`
+	CorrectionID uint `gorm:"not null" json:"correction_id"`
+	ChangedBy    uint `gorm:"not null" json:"changed_by"`
+
+	CheckInTime        time.Time  `gorm:"not null" json:"check_in_time"`
+	CheckOutTime       *time.Time `json:"check_out_time"`
+	Status             string     `gorm:"type:varchar(20);not null" json:"status"`
+	LateDurationMinute int        `gorm:"default:0" json:"late_duration_minute"`
+	IsSuspicious       bool       `gorm:"default:false" json:"is_suspicious"`
+	IsMissingCheckout  bool       `gorm:"default:false" json:"is_missing_checkout"`
+	Notes              string     `gorm:"type:varchar(500)" json:"notes"`
+}
+
+func (AttendanceHistory) TableName() string {
+	return "attendance_histories"
+}
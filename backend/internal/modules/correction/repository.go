@@ -0,0 +1,114 @@
+package correction
+
+import (
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/utils"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, correction *AttendanceCorrection) error
+	FindByID(ctx context.Context, id uint) (*AttendanceCorrection, error)
+	FindAll(ctx context.Context, filter CorrectionFilter) ([]AttendanceCorrection, int64, error)
+	Update(ctx context.Context, correction *AttendanceCorrection) error
+	HasPending(ctx context.Context, employeeID uint, date time.Time) (bool, error)
+	CreateHistory(ctx context.Context, history *AttendanceHistory) error
+	FindHistoryByCorrectionID(ctx context.Context, correctionID uint) (*AttendanceHistory, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db}
+}
+
+func (r *repository) Create(ctx context.Context, correction *AttendanceCorrection) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Create(correction).Error
+}
+
+func (r *repository) FindByID(ctx context.Context, id uint) (*AttendanceCorrection, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var correction AttendanceCorrection
+
+	err := db.
+		Preload("User").
+		Preload("Employee").First(&correction, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &correction, nil
+}
+
+func (r *repository) FindAll(ctx context.Context, filter CorrectionFilter) ([]AttendanceCorrection, int64, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var corrections []AttendanceCorrection
+	var total int64
+
+	query := db.Model(&AttendanceCorrection{}).
+		Preload("User").
+		Preload("Employee")
+
+	if filter.UserID > 0 {
+		query = query.Where("attendance_corrections.user_id = ?", filter.UserID)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("attendance_corrections.status = ?", filter.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	err := query.
+		Limit(filter.Limit).
+		Offset(offset).
+		Order("attendance_corrections.created_at DESC").
+		Find(&corrections).Error
+
+	return corrections, total, err
+}
+
+func (r *repository) Update(ctx context.Context, correction *AttendanceCorrection) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Omit("User", "Employee", "Approver").Save(correction).Error
+}
+
+func (r *repository) HasPending(ctx context.Context, employeeID uint, date time.Time) (bool, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+
+	var count int64
+	err := db.Model(&AttendanceCorrection{}).
+		Where("employee_id = ? AND date = ? AND status = ?", employeeID, date.Format(constants.DefaultTimeFormat), constants.CorrectionStatusPending).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *repository) CreateHistory(ctx context.Context, history *AttendanceHistory) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Create(history).Error
+}
+
+func (r *repository) FindHistoryByCorrectionID(ctx context.Context, correctionID uint) (*AttendanceHistory, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var history AttendanceHistory
+
+	err := db.Where("correction_id = ?", correctionID).First(&history).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &history, nil
+}
@@ -0,0 +1,350 @@
+package correction
+
+import (
+	"basekarya-backend/internal/infrastructure"
+	"basekarya-backend/internal/modules/attendance"
+	"basekarya-backend/internal/modules/roster"
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/response"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	Create(ctx context.Context, req *CorrectionRequest) error
+	GetDetail(ctx context.Context, id uint) (*CorrectionDetailResponse, error)
+	GetList(ctx context.Context, filter CorrectionFilter) ([]CorrectionListResponse, *response.Meta, error)
+	ProcessAction(ctx context.Context, req *ActionRequest) error
+}
+
+type service struct {
+	repo               Repository
+	attendance         AttendanceProvider
+	user               UserProvider
+	latePolicy         LatePolicyProvider
+	payrollLock        PayrollLockProvider
+	notification       NotificationProvider
+	transactionManager infrastructure.TransactionManager
+	roster             RosterProvider
+}
+
+func NewService(repo Repository, attendance AttendanceProvider, user UserProvider, latePolicy LatePolicyProvider, payrollLock PayrollLockProvider, notification NotificationProvider, transactionManager infrastructure.TransactionManager, roster RosterProvider) Service {
+	return &service{repo, attendance, user, latePolicy, payrollLock, notification, transactionManager, roster}
+}
+
+func (s *service) Create(ctx context.Context, req *CorrectionRequest) error {
+	return s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if req.UserID == 0 || req.EmployeeID == 0 {
+			return errors.New("employee data not found")
+		}
+
+		date, err := time.ParseInLocation(constants.DefaultTimeFormat, req.Date, time.Local)
+		if err != nil {
+			return errors.New("invalid date format, use YYYY-MM-DD")
+		}
+		if date.After(time.Now()) {
+			return errors.New("cannot correct attendance of a future date")
+		}
+
+		correction := &AttendanceCorrection{
+			UserID:     req.UserID,
+			EmployeeID: req.EmployeeID,
+			Date:       date,
+			Reason:     strings.TrimSpace(req.Reason),
+			Status:     constants.CorrectionStatusPending,
+		}
+
+		if correction.CheckInTime, err = normalizeClock(req.CheckInTime); err != nil {
+			return fmt.Errorf("invalid check-in time format %s", constants.ShiftHourFormat)
+		}
+		if correction.CheckOutTime, err = normalizeClock(req.CheckOutTime); err != nil {
+			return fmt.Errorf("invalid check-out time format %s", constants.ShiftHourFormat)
+		}
+
+		pending, err := s.repo.HasPending(ctx, req.EmployeeID, date)
+		if err != nil {
+			return err
+		}
+		if pending {
+			return errors.New("there is already a pending correction for this date")
+		}
+
+		att, err := s.attendance.FindByEmployeeAndDate(ctx, req.EmployeeID, date)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if correction.CheckInTime == nil {
+				return errors.New("check-in time is required, there is no attendance on this date")
+			}
+		case err != nil:
+			return err
+		case att.Status == string(constants.AttendanceStatusExcused):
+			return errors.New("attendance on approved leave cannot be corrected")
+		case att.Status == string(constants.AttendanceStatusAbsent) && correction.CheckInTime == nil:
+			return errors.New("check-in time is required to correct an absent attendance")
+		default:
+			correction.AttendanceID = &att.ID
+		}
+
+		if err := s.repo.Create(ctx, correction); err != nil {
+			return err
+		}
+
+		adminID, err := s.user.FindAdminID(ctx)
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			_ = s.notification.SendNotification(
+				adminID,
+				string(constants.NotificationTypeCorrectionApprovalReq),
+				"Pengajuan Koreksi Absensi Baru",
+				fmt.Sprintf("Karyawan mengajukan koreksi absensi tanggal %s", req.Date),
+				correction.ID,
+			)
+		}()
+
+		return nil
+	})
+}
+
+func (s *service) GetDetail(ctx context.Context, id uint) (*CorrectionDetailResponse, error) {
+	detail, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.New("attendance correction not found")
+	}
+
+	rejectionReason := ""
+	if detail.RejectionReason.Valid {
+		rejectionReason = detail.RejectionReason.String
+	}
+
+	resp := &CorrectionDetailResponse{
+		ID:              detail.ID,
+		EmployeeID:      detail.EmployeeID,
+		EmployeeName:    detail.Employee.FullName,
+		EmployeeNIK:     detail.Employee.NIK,
+		AttendanceID:    detail.AttendanceID,
+		Date:            detail.Date.Format(constants.DefaultTimeFormat),
+		CheckInTime:     detail.CheckInTime,
+		CheckOutTime:    detail.CheckOutTime,
+		Reason:          detail.Reason,
+		Status:          detail.Status,
+		RejectionReason: rejectionReason,
+		CreatedAt:       detail.CreatedAt,
+	}
+
+	if detail.Status == constants.CorrectionStatusApproved {
+		original, err := s.repo.FindHistoryByCorrectionID(ctx, detail.ID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		resp.Original = original
+	}
+
+	return resp, nil
+}
+
+func (s *service) GetList(ctx context.Context, filter CorrectionFilter) ([]CorrectionListResponse, *response.Meta, error) {
+	corrections, total, err := s.repo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := []CorrectionListResponse{}
+	for _, c := range corrections {
+		list = append(list, CorrectionListResponse{
+			ID:           c.ID,
+			EmployeeID:   c.EmployeeID,
+			EmployeeName: c.Employee.FullName,
+			EmployeeNIK:  c.Employee.NIK,
+			Date:         c.Date.Format(constants.DefaultTimeFormat),
+			CheckInTime:  c.CheckInTime,
+			CheckOutTime: c.CheckOutTime,
+			Status:       c.Status,
+			CreatedAt:    c.CreatedAt,
+		})
+	}
+
+	meta := response.NewMetaOffset(filter.Page, filter.Limit, total)
+	return list, meta, nil
+}
+
+func (s *service) ProcessAction(ctx context.Context, req *ActionRequest) error {
+	return s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		data, err := s.repo.FindByID(ctx, req.ID)
+		if err != nil {
+			return errors.New("attendance correction not found")
+		}
+
+		if data.Status != constants.CorrectionStatusPending {
+			return fmt.Errorf("cannot process correction with status %s", data.Status)
+		}
+
+		var (
+			notificationType    string
+			notificationTitle   string
+			notificationMessage string
+		)
+		switch constants.CorrectionAction(req.Action) {
+		case constants.CorrectionActionApprove:
+			if err := s.correctAttendance(ctx, data, req.ApproverID); err != nil {
+				return err
+			}
+
+			data.Status = constants.CorrectionStatusApproved
+			data.ApprovedBy = &req.ApproverID
+
+			notificationType = string(constants.NotificationTypeApproved)
+			notificationTitle = "Koreksi Absensi Disetujui"
+			notificationMessage = fmt.Sprintf("Koreksi absensi tanggal %s telah disetujui oleh Admin.", data.Date.Format(constants.DefaultTimeFormat))
+		case constants.CorrectionActionReject:
+			if req.RejectionReason == "" {
+				return errors.New("rejection reason is required")
+			}
+
+			data.Status = constants.CorrectionStatusRejected
+			data.ApprovedBy = &req.ApproverID
+			data.RejectionReason.String = req.RejectionReason
+			data.RejectionReason.Valid = true
+
+			notificationType = string(constants.NotificationTypeRejected)
+			notificationTitle = "Koreksi Absensi Ditolak"
+			notificationMessage = fmt.Sprintf("Koreksi absensi tanggal %s telah ditolak oleh Admin.", data.Date.Format(constants.DefaultTimeFormat))
+		default:
+			return fmt.Errorf("invalid action: %s", req.Action)
+		}
+
+		if err := s.repo.Update(ctx, data); err != nil {
+			return err
+		}
+
+		go func() {
+			_ = s.notification.SendNotification(
+				data.UserID,
+				notificationType,
+				notificationTitle,
+				notificationMessage,
+				data.ID,
+			)
+		}()
+
+		return nil
+	})
+}
+
+// correctAttendance apply the approved correction, the original attendance is kept on history.
+// attendance is created when the employee had none on the date
+func (s *service) correctAttendance(ctx context.Context, data *AttendanceCorrection, approverID uint) error {
+	// late duration of a locked period can no longer change the approved payroll
+	locked, err := s.payrollLock.IsPeriodLocked(ctx, data.Date)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return errors.New("payroll period is locked, correction cannot be applied")
+	}
+
+	u, err := s.user.FindByID(ctx, data.UserID)
+	if err != nil || u.Employee == nil {
+		return errors.New("employee data not found")
+	}
+
+	// the roster entry of the date override the fixed shift of the employee
+	entry, err := s.roster.FindByEmployeeAndDate(ctx, data.EmployeeID, data.Date)
+	if err != nil {
+		return fmt.Errorf("failed to fetch roster: %w", err)
+	}
+
+	shift := roster.ResolveShift(u.Employee, entry)
+	if shift == nil {
+		if entry != nil {
+			return errors.New("employee is not scheduled to work on this date")
+		}
+		return errors.New("employee shift not assigned")
+	}
+
+	policy, err := s.latePolicy.FindActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch late policy: %w", err)
+	}
+
+	note := "[CORRECTED] " + data.Reason
+
+	att, err := s.attendance.FindByEmployeeAndDate(ctx, data.EmployeeID, data.Date)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if data.CheckInTime == nil {
+			return errors.New("check-in time is required, there is no attendance on this date")
+		}
+
+		att = &attendance.Attendance{
+			EmployeeID:     data.EmployeeID,
+			ShiftID:        shift.ID,
+			Date:           data.Date,
+			CheckInAddress: "CORRECTION",
+			Notes:          truncateRunes(note, 500),
+		}
+		if err := applyCorrection(att, data, shift, policy); err != nil {
+			return err
+		}
+		if err := s.attendance.Create(ctx, att); err != nil {
+			return err
+		}
+
+		data.AttendanceID = &att.ID
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if att.Status == string(constants.AttendanceStatusExcused) {
+		return errors.New("attendance on approved leave cannot be corrected")
+	}
+
+	// absent attendance is system generated, it has no real check-in to keep
+	if att.Status == string(constants.AttendanceStatusAbsent) {
+		if data.CheckInTime == nil {
+			return errors.New("check-in time is required to correct an absent attendance")
+		}
+		att.CheckInAddress = "CORRECTION"
+	}
+
+	history := snapshot(att, data.ID, approverID)
+
+	if err := applyCorrection(att, data, shift, policy); err != nil {
+		return err
+	}
+	att.Notes = truncateRunes(strings.TrimSpace(att.Notes+" "+note), 500)
+
+	if err := s.attendance.Update(ctx, att); err != nil {
+		return err
+	}
+
+	if err := s.repo.CreateHistory(ctx, history); err != nil {
+		return err
+	}
+
+	data.AttendanceID = &att.ID
+	return nil
+}
+
+// normalizeClock convert optional ShiftHourFormat clock of the request to AttendanceTimeFormat
+func normalizeClock(clock string) (*string, error) {
+	if clock == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(constants.ShiftHourFormat, clock)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := parsed.Format(constants.AttendanceTimeFormat)
+	return &normalized, nil
+}
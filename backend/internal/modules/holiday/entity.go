@@ -0,0 +1,17 @@
+package holiday
+
+import "time"
+
+// Holiday is a public holiday or company-wide day off
+type Holiday struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Date time.Time `gorm:"type:date;uniqueIndex;not null" json:"date"`
+	Name string    `gorm:"type:varchar(150);not null" json:"name"`
+}
+
+func (Holiday) TableName() string {
+	return "holidays"
+}
@@ -0,0 +1,94 @@
+package holiday
+
+import (
+	"basekarya-backend/pkg/logger"
+	"basekarya-backend/pkg/response"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service}
+}
+
+func (h *Handler) GetAll(ctx echo.Context) error {
+	year, _ := strconv.Atoi(ctx.QueryParam("year"))
+	if year == 0 {
+		year = time.Now().Year()
+	}
+
+	resp, err := h.service.GetAll(ctx.Request().Context(), year)
+	if err != nil {
+		logger.Errorw("get holidays failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Get Holidays Successfully", resp, nil, nil)
+}
+
+func (h *Handler) Create(ctx echo.Context) error {
+	var req HolidayRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	resp, err := h.service.Create(ctx.Request().Context(), &req)
+	if err != nil {
+		logger.Errorw("create holiday failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusCreated, "Holiday created successfully", resp, nil, nil)
+}
+
+func (h *Handler) Update(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "invalid id", nil, err, nil)
+	}
+
+	var req HolidayRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := h.service.Update(ctx.Request().Context(), uint(id), &req); err != nil {
+		logger.Errorw("update holiday failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Holiday updated successfully", nil, nil, nil)
+}
+
+func (h *Handler) Delete(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "invalid id", nil, err, nil)
+	}
+
+	if err := h.service.Delete(ctx.Request().Context(), uint(id)); err != nil {
+		logger.Errorw("delete holiday failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Holiday deleted successfully", nil, nil, nil)
+}
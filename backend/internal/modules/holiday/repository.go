@@ -0,0 +1,72 @@
+package holiday
+
+import (
+	"basekarya-backend/pkg/utils"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindByYear(ctx context.Context, year int) ([]Holiday, error)
+	FindByDateRange(ctx context.Context, start, end time.Time) ([]Holiday, error)
+	FindByID(ctx context.Context, id uint) (*Holiday, error)
+	Create(ctx context.Context, holiday *Holiday) error
+	Update(ctx context.Context, holiday *Holiday) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db}
+}
+
+func (r *repository) FindByYear(ctx context.Context, year int) ([]Holiday, error) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	return r.FindByDateRange(ctx, start, start.AddDate(1, 0, -1))
+}
+
+func (r *repository) FindByDateRange(ctx context.Context, start, end time.Time) ([]Holiday, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var holidays []Holiday
+
+	err := db.Where("date BETWEEN ? AND ?", start.Format("2006-01-02"), end.Format("2006-01-02")).
+		Order("date ASC").
+		Find(&holidays).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return holidays, nil
+}
+
+func (r *repository) FindByID(ctx context.Context, id uint) (*Holiday, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var holiday Holiday
+
+	err := db.First(&holiday, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &holiday, nil
+}
+
+func (r *repository) Create(ctx context.Context, holiday *Holiday) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Create(holiday).Error
+}
+
+func (r *repository) Update(ctx context.Context, holiday *Holiday) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Save(holiday).Error
+}
+
+func (r *repository) Delete(ctx context.Context, id uint) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Delete(&Holiday{}, id).Error
+}
@@ -0,0 +1,90 @@
+package holiday
+
+import (
+	"basekarya-backend/pkg/constants"
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+type Service interface {
+	GetAll(ctx context.Context, year int) ([]HolidayResponse, error)
+	Create(ctx context.Context, req *HolidayRequest) (*HolidayResponse, error)
+	Update(ctx context.Context, id uint, req *HolidayRequest) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo}
+}
+
+func (s *service) GetAll(ctx context.Context, year int) ([]HolidayResponse, error) {
+	holidays, err := s.repo.FindByYear(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := []HolidayResponse{}
+	for _, h := range holidays {
+		resp = append(resp, toHolidayResponse(&h))
+	}
+
+	return resp, nil
+}
+
+func (s *service) Create(ctx context.Context, req *HolidayRequest) (*HolidayResponse, error) {
+	date, err := time.ParseInLocation(constants.DefaultTimeFormat, req.Date, time.Local)
+	if err != nil {
+		return nil, errors.New("invalid date format, use YYYY-MM-DD")
+	}
+
+	holiday := Holiday{
+		Date: date,
+		Name: strings.TrimSpace(req.Name),
+	}
+
+	if err := s.repo.Create(ctx, &holiday); err != nil {
+		return nil, err
+	}
+
+	resp := toHolidayResponse(&holiday)
+	return &resp, nil
+}
+
+func (s *service) Update(ctx context.Context, id uint, req *HolidayRequest) error {
+	holiday, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return errors.New("holiday not found")
+	}
+
+	date, err := time.ParseInLocation(constants.DefaultTimeFormat, req.Date, time.Local)
+	if err != nil {
+		return errors.New("invalid date format, use YYYY-MM-DD")
+	}
+
+	holiday.Date = date
+	holiday.Name = strings.TrimSpace(req.Name)
+
+	return s.repo.Update(ctx, holiday)
+}
+
+func (s *service) Delete(ctx context.Context, id uint) error {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return errors.New("holiday not found")
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+func toHolidayResponse(h *Holiday) HolidayResponse {
+	return HolidayResponse{
+		ID:   h.ID,
+		Date: h.Date.Format(constants.DefaultTimeFormat),
+		Name: h.Name,
+	}
+}
@@ -0,0 +1,21 @@
+package latepolicy
+
+import "basekarya-backend/pkg/money"
+
+type UpdateLatePolicyRequest struct {
+	GraceMinutes int                `json:"grace_minutes" validate:"min=0,max=240"`
+	FlatPenalty  money.Money        `json:"flat_penalty" validate:"min=0"`
+	MonthlyCap   money.Money        `json:"monthly_cap" validate:"min=0"`
+	Tiers        []TierRequest      `json:"tiers" validate:"dive"`
+	Exemptions   []ExemptionRequest `json:"exemptions" validate:"dive"`
+}
+
+type TierRequest struct {
+	FromMinute    int         `json:"from_minute" validate:"required,min=1"`
+	RatePerMinute money.Money `json:"rate_per_minute" validate:"min=0"`
+}
+
+type ExemptionRequest struct {
+	EmployeeID   *uint `json:"employee_id"`
+	DepartmentID *uint `json:"department_id"`
+}
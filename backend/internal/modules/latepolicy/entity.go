@@ -0,0 +1,50 @@
+package latepolicy
+
+import (
+	"basekarya-backend/internal/modules/master"
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/money"
+	"time"
+)
+
+// LatePolicy decide how late check-in is penalized on payroll, there is a single policy for the company
+type LatePolicy struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// GraceMinutes is the tolerance of each check-in, late within it is not marked LATE nor penalized
+	GraceMinutes int `gorm:"default:0" json:"grace_minutes"`
+
+	// FlatPenalty is charged once per late check-in on top of the per minute rate
+	FlatPenalty money.Money `gorm:"type:decimal(15,2);default:0" json:"flat_penalty"`
+
+	// MonthlyCap is the maximum penalty of a month, zero means no cap
+	MonthlyCap money.Money `gorm:"type:decimal(15,2);default:0" json:"monthly_cap"`
+
+	Tiers      []LatePolicyTier      `gorm:"foreignKey:LatePolicyID;constraint:OnDelete:CASCADE" json:"tiers"`
+	Exemptions []LatePolicyExemption `gorm:"foreignKey:LatePolicyID;constraint:OnDelete:CASCADE" json:"exemptions"`
+}
+
+// LatePolicyTier charge RatePerMinute for every late minute from FromMinute until the next tier,
+// minutes are counted after grace period
+type LatePolicyTier struct {
+	ID           uint `gorm:"primaryKey" json:"id"`
+	LatePolicyID uint `gorm:"not null;index" json:"late_policy_id"`
+
+	FromMinute    int         `gorm:"not null" json:"from_minute"`
+	RatePerMinute money.Money `gorm:"type:decimal(15,2);not null" json:"rate_per_minute"`
+}
+
+// LatePolicyExemption exclude an employee or a whole department from late penalty
+type LatePolicyExemption struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt    time.Time `json:"created_at"`
+	LatePolicyID uint      `gorm:"not null;index" json:"late_policy_id"`
+
+	EmployeeID   *uint `json:"employee_id"`
+	DepartmentID *uint `json:"department_id"`
+
+	Employee   *user.Employee     `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	Department *master.Department `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
+}
@@ -0,0 +1,47 @@
+package latepolicy
+
+import (
+	"basekarya-backend/pkg/logger"
+	"basekarya-backend/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service}
+}
+
+func (h *Handler) Get(ctx echo.Context) error {
+	resp, err := h.service.Get(ctx.Request().Context())
+	if err != nil {
+		logger.Errorw("get late policy failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Get Late Policy Successfully", resp, nil, nil)
+}
+
+func (h *Handler) Update(ctx echo.Context) error {
+	var req UpdateLatePolicyRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := h.service.Update(ctx.Request().Context(), &req); err != nil {
+		logger.Errorw("update late policy failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Late policy updated successfully", nil, nil, nil)
+}
@@ -0,0 +1,132 @@
+package latepolicy
+
+import (
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/money"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Penalty is the late penalty of an employee on a period & the rule that produce it
+type Penalty struct {
+	Amount      money.Money
+	Occurrences int
+	Minutes     int
+	Description string
+}
+
+// IsLate return true when late minute of a check-in is beyond the grace period
+func (p *LatePolicy) IsLate(lateMinute int) bool {
+	if p == nil {
+		return lateMinute > 0
+	}
+
+	return lateMinute > p.GraceMinutes
+}
+
+// IsExempt return true when the employee or its department is excluded from late penalty
+func (p *LatePolicy) IsExempt(emp *user.Employee) bool {
+	for _, e := range p.Exemptions {
+		if e.EmployeeID != nil && *e.EmployeeID == emp.ID {
+			return true
+		}
+		if e.DepartmentID != nil && *e.DepartmentID == emp.DepartmentID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Calculate return penalty of the late minutes of every check-in of a period
+func (p *LatePolicy) Calculate(emp *user.Employee, lateMinutes []int) Penalty {
+	var penalty Penalty
+	if p == nil || p.IsExempt(emp) {
+		return penalty
+	}
+
+	for _, minute := range lateMinutes {
+		if !p.IsLate(minute) {
+			continue
+		}
+
+		counted := minute - p.GraceMinutes
+		penalty.Occurrences++
+		penalty.Minutes += counted
+		penalty.Amount += p.minuteCharge(counted) + p.FlatPenalty
+	}
+
+	if penalty.Occurrences == 0 {
+		return penalty
+	}
+
+	capped := p.MonthlyCap > 0 && penalty.Amount > p.MonthlyCap
+	if capped {
+		penalty.Amount = p.MonthlyCap
+	}
+
+	penalty.Description = p.describe(penalty, capped)
+
+	return penalty
+}
+
+// minuteCharge sum rate of each tier the late minutes pass through
+func (p *LatePolicy) minuteCharge(minutes int) money.Money {
+	tiers := p.sortedTiers()
+
+	var charge money.Money
+	for i, tier := range tiers {
+		if minutes < tier.FromMinute {
+			break
+		}
+
+		until := minutes
+		if i+1 < len(tiers) && tiers[i+1].FromMinute-1 < until {
+			until = tiers[i+1].FromMinute - 1
+		}
+
+		charge += tier.RatePerMinute.Mul(int64(until - tier.FromMinute + 1))
+	}
+
+	return charge
+}
+
+func (p *LatePolicy) sortedTiers() []LatePolicyTier {
+	tiers := make([]LatePolicyTier, len(p.Tiers))
+	copy(tiers, p.Tiers)
+
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].FromMinute < tiers[j].FromMinute
+	})
+
+	return tiers
+}
+
+// describe write the applied rule for the payslip line, e.g.
+// "Potongan Terlambat (3x, 45 menit, toleransi 15 menit, Rp 1.000/menit)"
+func (p *LatePolicy) describe(penalty Penalty, capped bool) string {
+	parts := []string{fmt.Sprintf("%dx", penalty.Occurrences), fmt.Sprintf("%d menit", penalty.Minutes)}
+
+	if p.GraceMinutes > 0 {
+		parts = append(parts, fmt.Sprintf("toleransi %d menit", p.GraceMinutes))
+	}
+
+	switch len(p.Tiers) {
+	case 0:
+	case 1:
+		parts = append(parts, fmt.Sprintf("Rp %s/menit", p.Tiers[0].RatePerMinute.Format()))
+	default:
+		parts = append(parts, "tarif bertingkat")
+	}
+
+	if p.FlatPenalty > 0 {
+		parts = append(parts, fmt.Sprintf("Rp %s/kejadian", p.FlatPenalty.Format()))
+	}
+
+	if capped {
+		parts = append(parts, fmt.Sprintf("maks Rp %s", p.MonthlyCap.Format()))
+	}
+
+	return fmt.Sprintf("Potongan Terlambat (%s)", strings.Join(parts, ", "))
+}
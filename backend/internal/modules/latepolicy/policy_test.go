@@ -0,0 +1,45 @@
+package latepolicy
+
+import (
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/money"
+	"testing"
+)
+
+func TestLatePolicy_Calculate(t *testing.T) {
+	departmentID := uint(2)
+	policy := &LatePolicy{
+		GraceMinutes: 10,
+		Tiers: []LatePolicyTier{
+			{FromMinute: 31, RatePerMinute: money.New(2_000)},
+			{FromMinute: 1, RatePerMinute: money.New(1_000)},
+		},
+		Exemptions: []LatePolicyExemption{{DepartmentID: &departmentID}},
+	}
+
+	emp := &user.Employee{ID: 1, DepartmentID: 1}
+
+	// 5 minutes is within grace, 50 minutes count 40 minutes: 30 x 1.000 + 10 x 2.000
+	penalty := policy.Calculate(emp, []int{5, 50})
+	if penalty.Amount != money.New(50_000) || penalty.Occurrences != 1 || penalty.Minutes != 40 {
+		t.Errorf("expected 50000 of 1 occurrence & 40 minutes, got %s of %d occurrence & %d minutes", penalty.Amount, penalty.Occurrences, penalty.Minutes)
+	}
+
+	policy.FlatPenalty = money.New(10_000)
+	policy.MonthlyCap = money.New(100_000)
+
+	// (20.000 + 10.000) + (50.000 + 10.000) + (50.000 + 10.000) capped to 100.000
+	penalty = policy.Calculate(emp, []int{30, 50, 50})
+	if penalty.Amount != money.New(100_000) {
+		t.Errorf("expected capped 100000, got %s", penalty.Amount)
+	}
+
+	expected := "Potongan Terlambat (3x, 100 menit, toleransi 10 menit, tarif bertingkat, Rp 10.000/kejadian, maks Rp 100.000)"
+	if penalty.Description != expected {
+		t.Errorf("expected %q, got %q", expected, penalty.Description)
+	}
+
+	if penalty := policy.Calculate(&user.Employee{ID: 3, DepartmentID: departmentID}, []int{50}); penalty.Amount != 0 {
+		t.Errorf("expected exempt employee has no penalty, got %s", penalty.Amount)
+	}
+}
@@ -0,0 +1,77 @@
+package latepolicy
+
+import (
+	"basekarya-backend/pkg/utils"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindActive(ctx context.Context) (*LatePolicy, error)
+	Update(ctx context.Context, policy *LatePolicy) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db}
+}
+
+func (r *repository) FindActive(ctx context.Context) (*LatePolicy, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var policy LatePolicy
+
+	err := db.
+		Preload("Tiers", func(db *gorm.DB) *gorm.DB {
+			return db.Order("from_minute ASC")
+		}).
+		Preload("Exemptions.Employee").
+		Preload("Exemptions.Department").
+		Order("id ASC").
+		First(&policy).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Update save the policy & replace all of its tiers & exemptions, run inside a transaction
+func (r *repository) Update(ctx context.Context, policy *LatePolicy) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+
+	if err := db.Omit("Tiers", "Exemptions").Save(policy).Error; err != nil {
+		return err
+	}
+
+	if err := db.Where("late_policy_id = ?", policy.ID).Delete(&LatePolicyTier{}).Error; err != nil {
+		return err
+	}
+
+	if err := db.Where("late_policy_id = ?", policy.ID).Delete(&LatePolicyExemption{}).Error; err != nil {
+		return err
+	}
+
+	for i := range policy.Tiers {
+		policy.Tiers[i].LatePolicyID = policy.ID
+	}
+	if len(policy.Tiers) > 0 {
+		if err := db.Create(&policy.Tiers).Error; err != nil {
+			return err
+		}
+	}
+
+	for i := range policy.Exemptions {
+		policy.Exemptions[i].LatePolicyID = policy.ID
+	}
+	if len(policy.Exemptions) > 0 {
+		if err := db.Omit("Employee", "Department").Create(&policy.Exemptions).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package latepolicy
+
+import (
+	"basekarya-backend/internal/infrastructure"
+	"context"
+	"errors"
+	"fmt"
+)
+
+type Service interface {
+	Get(ctx context.Context) (*LatePolicy, error)
+	Update(ctx context.Context, req *UpdateLatePolicyRequest) error
+}
+
+type service struct {
+	repo               Repository
+	transactionManager infrastructure.TransactionManager
+}
+
+func NewService(repo Repository, transactionManager infrastructure.TransactionManager) Service {
+	return &service{repo, transactionManager}
+}
+
+func (s *service) Get(ctx context.Context) (*LatePolicy, error) {
+	return s.repo.FindActive(ctx)
+}
+
+func (s *service) Update(ctx context.Context, req *UpdateLatePolicyRequest) error {
+	policy, err := s.repo.FindActive(ctx)
+	if err != nil {
+		return errors.New("late policy not found")
+	}
+
+	policy.GraceMinutes = req.GraceMinutes
+	policy.FlatPenalty = req.FlatPenalty
+	policy.MonthlyCap = req.MonthlyCap
+
+	policy.Tiers = []LatePolicyTier{}
+	fromMinutes := make(map[int]bool)
+	for _, t := range req.Tiers {
+		if fromMinutes[t.FromMinute] {
+			return fmt.Errorf("duplicate tier from minute %d", t.FromMinute)
+		}
+		fromMinutes[t.FromMinute] = true
+
+		policy.Tiers = append(policy.Tiers, LatePolicyTier{
+			FromMinute:    t.FromMinute,
+			RatePerMinute: t.RatePerMinute,
+		})
+	}
+
+	policy.Exemptions = []LatePolicyExemption{}
+	for _, e := range req.Exemptions {
+		if (e.EmployeeID == nil) == (e.DepartmentID == nil) {
+			return errors.New("exemption must have either employee_id or department_id")
+		}
+
+		policy.Exemptions = append(policy.Exemptions, LatePolicyExemption{
+			EmployeeID:   e.EmployeeID,
+			DepartmentID: e.DepartmentID,
+		})
+	}
+
+	return s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		return s.repo.Update(ctx, policy)
+	})
+}
@@ -23,8 +23,14 @@ type Repository interface {
 	ApproveRequest(ctx context.Context, requestID uint, approverID uint, attendanceRecords []attendance.Attendance, shouldDeduct bool, days int) error
 	RejectRequest(ctx context.Context, requestID uint, approverID uint, reason string) error
 
+	GetBulkUnpaidLeaveDays(ctx context.Context, month, year int) (map[uint]int, error)
+	GetPaidQuota(ctx context.Context, employeeID uint, year int) (int, int, error)
+	IsRemoteWorkApproved(ctx context.Context, employeeID uint, date time.Time) (bool, error)
+	GetEmployeeIDsOnLeave(ctx context.Context, date time.Time) (map[uint]bool, error)
+
 	// For Initial Balance Generation
 	FindAllLeaveTypes(ctx context.Context) ([]master.LeaveType, error)
+	FindLeaveTypeByID(ctx context.Context, id uint) (*master.LeaveType, error)
 	CreateLeaveBalances(ctx context.Context, balances []LeaveBalance) error
 }
 
@@ -173,6 +179,15 @@ func (r *repository) FindAllLeaveTypes(ctx context.Context) ([]master.LeaveType,
 	return leaveTypes, nil
 }
 
+func (r *repository) FindLeaveTypeByID(ctx context.Context, id uint) (*master.LeaveType, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var leaveType master.LeaveType
+	if err := db.First(&leaveType, id).Error; err != nil {
+		return nil, err
+	}
+	return &leaveType, nil
+}
+
 func (r *repository) CreateLeaveBalances(ctx context.Context, balances []LeaveBalance) error {
 	db := utils.GetDBFromContext(ctx, r.db)
 	if len(balances) == 0 {
@@ -180,3 +195,107 @@ func (r *repository) CreateLeaveBalances(ctx context.Context, balances []LeaveBa
 	}
 	return db.Create(&balances).Error
 }
+
+// GetBulkUnpaidLeaveDays return number of approved unpaid leave days falling on the period, keyed by employee id.
+// weekend is not counted, same as the attendance generated on approval
+func (r *repository) GetBulkUnpaidLeaveDays(ctx context.Context, month, year int) (map[uint]int, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	periodStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+	periodEnd := periodStart.AddDate(0, 1, -1)
+
+	var requests []LeaveRequest
+	err := db.Model(&LeaveRequest{}).
+		Joins("JOIN ref_leave_types ON ref_leave_types.id = leave_requests.leave_type_id").
+		Where("ref_leave_types.is_paid = ?", false).
+		Where("leave_requests.status = ?", constants.LeaveStatusApproved).
+		Where("leave_requests.start_date <= ? AND leave_requests.end_date >= ?",
+			periodEnd.Format(constants.DefaultTimeFormat), periodStart.Format(constants.DefaultTimeFormat)).
+		Find(&requests).Error
+	if err != nil {
+		return nil, err
+	}
+
+	dataMap := make(map[uint]int)
+	for _, req := range requests {
+		start := time.Date(req.StartDate.Year(), req.StartDate.Month(), req.StartDate.Day(), 0, 0, 0, 0, time.Local)
+		end := time.Date(req.EndDate.Year(), req.EndDate.Month(), req.EndDate.Day(), 0, 0, 0, 0, time.Local)
+		if start.Before(periodStart) {
+			start = periodStart
+		}
+		if end.After(periodEnd) {
+			end = periodEnd
+		}
+
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+				continue
+			}
+
+			dataMap[req.EmployeeID]++
+		}
+	}
+
+	return dataMap, nil
+}
+
+// GetPaidQuota return total & used days of the employee quota based paid leave of the year, e.g. annual leave
+func (r *repository) GetPaidQuota(ctx context.Context, employeeID uint, year int) (int, int, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+
+	var quota struct {
+		Total int
+		Used  int
+	}
+	err := db.Model(&LeaveBalance{}).
+		Joins("JOIN ref_leave_types ON ref_leave_types.id = leave_balances.leave_type_id").
+		Where("leave_balances.employee_id = ? AND leave_balances.year = ?", employeeID, year).
+		Where("ref_leave_types.is_deducted = ? AND ref_leave_types.is_paid = ?", true, true).
+		Select("COALESCE(SUM(leave_balances.quota_total), 0) AS total, COALESCE(SUM(leave_balances.quota_used), 0) AS used").
+		Scan(&quota).Error
+
+	return quota.Total, quota.Used, err
+}
+
+// IsRemoteWorkApproved return true when the employee has an approved remote work leave covering the date
+func (r *repository) IsRemoteWorkApproved(ctx context.Context, employeeID uint, date time.Time) (bool, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	day := date.Format(constants.DefaultTimeFormat)
+
+	var count int64
+	err := db.Model(&LeaveRequest{}).
+		Joins("JOIN ref_leave_types ON ref_leave_types.id = leave_requests.leave_type_id").
+		Where("ref_leave_types.is_remote_work = ?", true).
+		Where("leave_requests.employee_id = ? AND leave_requests.status = ?", employeeID, constants.LeaveStatusApproved).
+		Where("leave_requests.start_date <= ? AND leave_requests.end_date >= ?", day, day).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// GetEmployeeIDsOnLeave return employees with approved leave covering the date, remote work is not a leave
+func (r *repository) GetEmployeeIDsOnLeave(ctx context.Context, date time.Time) (map[uint]bool, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	day := date.Format(constants.DefaultTimeFormat)
+
+	var employeeIDs []uint
+	err := db.Model(&LeaveRequest{}).
+		Joins("JOIN ref_leave_types ON ref_leave_types.id = leave_requests.leave_type_id").
+		Where("ref_leave_types.is_remote_work = ?", false).
+		Where("leave_requests.status = ?", constants.LeaveStatusApproved).
+		Where("leave_requests.start_date <= ? AND leave_requests.end_date >= ?", day, day).
+		Distinct().
+		Pluck("leave_requests.employee_id", &employeeIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	dataMap := make(map[uint]bool)
+	for _, id := range employeeIDs {
+		dataMap[id] = true
+	}
+
+	return dataMap, nil
+}
@@ -57,14 +57,21 @@ func (s *service) Apply(ctx context.Context, req *ApplyRequest) error {
 		// calculate total days
 		totalDays := int(end.Sub(start).Hours()/24) + 1
 
-		// check balance still available or not
-		balance, err := s.repo.GetBalance(ctx, req.EmployeeID, req.LeaveTypeID, start.Year())
+		leaveType, err := s.repo.FindLeaveTypeByID(ctx, req.LeaveTypeID)
 		if err != nil {
-			return err
+			return errors.New("leave type not found")
 		}
 
-		if balance.QuotaLeft < totalDays {
-			return errors.New("insufficient leave balance")
+		// check balance still available or not, remote work has no quota
+		if !leaveType.IsRemoteWork {
+			balance, err := s.repo.GetBalance(ctx, req.EmployeeID, req.LeaveTypeID, start.Year())
+			if err != nil {
+				return err
+			}
+
+			if balance.QuotaLeft < totalDays {
+				return errors.New("insufficient leave balance")
+			}
 		}
 
 		attachmentUrl := ""
@@ -154,8 +161,9 @@ func (s *service) RequestAction(ctx context.Context, req *LeaveActionRequest) er
 
 			var attendanceRecords []attendance.Attendance
 
+			// remote work is not excused, employee still clock in but from outside of the office radius
 			currentDate := leaveRequest.StartDate
-			for !currentDate.After(leaveRequest.EndDate) {
+			for !leaveRequest.LeaveType.IsRemoteWork && !currentDate.After(leaveRequest.EndDate) {
 
 				if currentDate.Weekday() == time.Saturday || currentDate.Weekday() == time.Sunday {
 					currentDate = currentDate.AddDate(0, 0, 1)
@@ -1,6 +1,9 @@
 package loan
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type NotificationProvider interface {
 	SendNotification(userID uint,
@@ -12,3 +15,7 @@ type NotificationProvider interface {
 type UserProvider interface {
 	FindAdminID(ctx context.Context) (uint, error)
 }
+
+type PayrollLockProvider interface {
+	IsPeriodLocked(ctx context.Context, date time.Time) (bool, error)
+}
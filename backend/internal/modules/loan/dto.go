@@ -2,6 +2,7 @@ package loan
 
 import (
 	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
 	"time"
 )
 
@@ -13,11 +14,11 @@ type LoanFilter struct {
 }
 
 type LoanRequest struct {
-	UserID            uint    `json:"-"`
-	EmployeeID        uint    `json:"-"`
-	TotalAmount       float64 `json:"total_amount" validate:"required"`
-	InstallmentAmount float64 `json:"installment_amount" validate:"required"`
-	Reason            string  `json:"reason" validate:"required"`
+	UserID            uint        `json:"-"`
+	EmployeeID        uint        `json:"-"`
+	TotalAmount       money.Money `json:"total_amount" validate:"required"`
+	InstallmentAmount money.Money `json:"installment_amount" validate:"required"`
+	Reason            string      `json:"reason" validate:"required"`
 }
 
 type ActionRequest struct {
@@ -32,9 +33,9 @@ type LoanListResponse struct {
 	EmployeeID        uint                 `json:"employee_id"`
 	EmployeeName      string               `json:"employee_name"`
 	EmployeeNIK       string               `json:"employee_nik"`
-	TotalAmount       float64              `json:"total_amount"`
-	InstallmentAmount float64              `json:"installment_amount"`
-	RemainingAmount   float64              `json:"remaining_amount"`
+	TotalAmount       money.Money          `json:"total_amount"`
+	InstallmentAmount money.Money          `json:"installment_amount"`
+	RemainingAmount   money.Money          `json:"remaining_amount"`
 	Status            constants.LoanStatus `json:"status"`
 	CreatedAt         time.Time            `json:"created_at"`
 }
@@ -44,9 +45,9 @@ type LoanDetailResponse struct {
 	EmployeeID        uint                 `json:"employee_id"`
 	EmployeeName      string               `json:"employee_name"`
 	EmployeeNIK       string               `json:"employee_nik"`
-	TotalAmount       float64              `json:"total_amount"`
-	InstallmentAmount float64              `json:"installment_amount"`
-	RemainingAmount   float64              `json:"remaining_amount"`
+	TotalAmount       money.Money          `json:"total_amount"`
+	InstallmentAmount money.Money          `json:"installment_amount"`
+	RemainingAmount   money.Money          `json:"remaining_amount"`
 	Reason            string               `json:"reason"`
 	Status            constants.LoanStatus `json:"status"`
 	RejectionReason   string               `json:"rejection_reason"`
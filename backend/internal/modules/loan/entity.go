@@ -3,6 +3,7 @@ package loan
 import (
 	"basekarya-backend/internal/modules/user"
 	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
 	"database/sql"
 	"time"
 )
@@ -21,10 +22,10 @@ type Loan struct {
 	ApprovedBy *uint      `json:"approved_by"`
 	Approver   *user.User `gorm:"foreignKey:ApprovedBy" json:"approver,omitempty"`
 
-	TotalAmount       float64 `gorm:"type:decimal(15,2);not null" json:"total_amount"`
-	InstallmentAmount float64 `gorm:"type:decimal(15,2);not null" json:"installment_amount"`
-	RemainingAmount   float64 `gorm:"type:decimal(15,2);not null" json:"remaining_amount"`
-	Reason            string  `gorm:"type:text" json:"reason"`
+	TotalAmount       money.Money `gorm:"type:decimal(15,2);not null" json:"total_amount"`
+	InstallmentAmount money.Money `gorm:"type:decimal(15,2);not null" json:"installment_amount"`
+	RemainingAmount   money.Money `gorm:"type:decimal(15,2);not null" json:"remaining_amount"`
+	Reason            string      `gorm:"type:text" json:"reason"`
 
 	Status          constants.LoanStatus `gorm:"type:enum('PENDING','APPROVED','REJECTED','PAID_OFF');default:'PENDING'" json:"status"`
 	RejectionReason sql.NullString       `gorm:"type:text" json:"rejection_reason"`
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -25,10 +26,11 @@ type service struct {
 	user               UserProvider
 	transactionManager infrastructure.TransactionManager
 	excel              infrastructure.ExcelProvider
+	payrollLock        PayrollLockProvider
 }
 
-func NewService(repo Repository, notification NotificationProvider, user UserProvider, transactionManager infrastructure.TransactionManager, excel infrastructure.ExcelProvider) Service {
-	return &service{repo, notification, user, transactionManager, excel}
+func NewService(repo Repository, notification NotificationProvider, user UserProvider, transactionManager infrastructure.TransactionManager, excel infrastructure.ExcelProvider, payrollLock PayrollLockProvider) Service {
+	return &service{repo, notification, user, transactionManager, excel, payrollLock}
 }
 
 func (s *service) Create(ctx context.Context, req *LoanRequest) error {
@@ -75,7 +77,7 @@ func (s *service) Create(ctx context.Context, req *LoanRequest) error {
 				adminID,
 				string(constants.NotificationTypeLoanApprovalReq),
 				"Pengajuan Kasbon Baru",
-				fmt.Sprintf("Karyawan mengajukan kasbon dengan total Rp.%2.f", req.TotalAmount),
+				fmt.Sprintf("Karyawan mengajukan kasbon dengan total Rp.%s", req.TotalAmount.Format()),
 				loan.ID,
 			)
 		}()
@@ -162,12 +164,18 @@ func (s *service) ProcessAction(ctx context.Context, req *ActionRequest) error {
 		)
 		switch constants.LoanAction(req.Action) {
 		case constants.LoanActionApprove:
+			// locked period payroll is never recalculated, first installment is deducted on the next open period
+			firstPeriod, err := s.firstDeductionPeriod(ctx, time.Now())
+			if err != nil {
+				return err
+			}
+
 			data.Status = constants.LoanStatusApproved
 			data.ApprovedBy = &req.SuperAdminID
 
 			notificationType = constants.NotificationTypeApproved
 			notificationTitle = "Permintaan Disetujui"
-			notificationMessage = "Kasbon Anda telah disetujui oleh Admin."
+			notificationMessage = fmt.Sprintf("Kasbon Anda telah disetujui oleh Admin. Cicilan pertama dipotong pada payroll %s.", firstPeriod.Format(constants.PayrollTimeFormat))
 		case constants.LoanActionReject:
 			data.Status = constants.LoanStatusRejected
 
@@ -228,9 +236,9 @@ func (s *service) Export(ctx context.Context, filter LoanFilter) ([]byte, error)
 		row := []interface{}{
 			loan.ID,
 			empName,
-			loan.TotalAmount,
-			loan.InstallmentAmount,
-			loan.RemainingAmount,
+			loan.TotalAmount.Float64(),
+			loan.InstallmentAmount.Float64(),
+			loan.RemainingAmount.Float64(),
 			loan.Status,
 			loan.CreatedAt.Format("2006-01-02 15:04:05"),
 		}
@@ -239,3 +247,19 @@ func (s *service) Export(ctx context.Context, filter LoanFilter) ([]byte, error)
 
 	return s.excel.GenerateSimpleExcel("Loans", headers, rows)
 }
+
+// firstDeductionPeriod return the first payroll period from the date which is not locked yet
+func (s *service) firstDeductionPeriod(ctx context.Context, date time.Time) (time.Time, error) {
+	period := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.Local)
+	for {
+		locked, err := s.payrollLock.IsPeriodLocked(ctx, period)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !locked {
+			return period, nil
+		}
+
+		period = period.AddDate(0, 1, 0)
+	}
+}
@@ -10,4 +10,6 @@ type LookupLeaveTypeResponse struct {
 	Name         string `json:"name"`
 	DefaultQuota int    `json:"default_quota"`
 	IsDeducted   bool   `json:"is_deducted"`
+	IsPaid       bool   `json:"is_paid"`
+	IsRemoteWork bool   `json:"is_remote_work"`
 }
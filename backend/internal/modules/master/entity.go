@@ -21,6 +21,8 @@ type LeaveType struct {
 	Name         string    `gorm:"unique;not null" json:"name"`
 	DefaultQuota int       `json:"default_quota"`
 	IsDeducted   bool      `gorm:"default:true" json:"is_deducted"`
+	IsPaid       bool      `json:"is_paid"`
+	IsRemoteWork bool      `json:"is_remote_work"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
@@ -65,6 +65,8 @@ func (s *service) GetAllLeaveTypes() ([]LookupLeaveTypeResponse, error) {
 			Name:         d.Name,
 			DefaultQuota: d.DefaultQuota,
 			IsDeducted:   d.IsDeducted,
+			IsPaid:       d.IsPaid,
+			IsRemoteWork: d.IsRemoteWork,
 		}
 
 		results = append(results, result)
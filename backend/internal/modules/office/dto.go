@@ -0,0 +1,16 @@
+package office
+
+type OfficeLocationRequest struct {
+	Name        string              `json:"name" validate:"required,max=150"`
+	Address     string              `json:"address" validate:"max=500"`
+	Latitude    float64             `json:"latitude" validate:"required,latitude"`
+	Longitude   float64             `json:"longitude" validate:"required,longitude"`
+	RadiusMeter int                 `json:"radius_meter" validate:"required,min=10,max=10000"`
+	IsActive    *bool               `json:"is_active" validate:"required"`
+	Assignments []AssignmentRequest `json:"assignments" validate:"dive"`
+}
+
+type AssignmentRequest struct {
+	EmployeeID   *uint `json:"employee_id"`
+	DepartmentID *uint `json:"department_id"`
+}
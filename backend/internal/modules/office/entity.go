@@ -0,0 +1,45 @@
+package office
+
+import (
+	"basekarya-backend/internal/modules/master"
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/utils"
+	"time"
+)
+
+// OfficeLocation is a workplace, clock-in & clock-out of assigned employees must happen within its radius
+type OfficeLocation struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Name    string `gorm:"type:varchar(150);not null" json:"name"`
+	Address string `gorm:"type:varchar(500)" json:"address"`
+
+	Latitude    float64 `gorm:"type:decimal(10,8);not null" json:"latitude"`
+	Longitude   float64 `gorm:"type:decimal(11,8);not null" json:"longitude"`
+	RadiusMeter int     `gorm:"default:100" json:"radius_meter"`
+
+	IsActive bool `json:"is_active"`
+
+	Assignments []OfficeLocationAssignment `gorm:"foreignKey:OfficeLocationID;constraint:OnDelete:CASCADE" json:"assignments"`
+}
+
+// DistanceMeter return distance of the coordinate from the office
+func (l *OfficeLocation) DistanceMeter(lat, long float64) float64 {
+	return utils.CalculateDistance(l.Latitude, l.Longitude, lat, long)
+}
+
+// OfficeLocationAssignment assign an office location to an employee or a whole department,
+// employee-level assignment override the department-level one
+type OfficeLocationAssignment struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt        time.Time `json:"created_at"`
+	OfficeLocationID uint      `gorm:"not null;index" json:"office_location_id"`
+
+	EmployeeID   *uint `json:"employee_id"`
+	DepartmentID *uint `json:"department_id"`
+
+	Employee   *user.Employee     `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	Department *master.Department `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
+}
@@ -0,0 +1,135 @@
+package office
+
+import (
+	"basekarya-backend/pkg/utils"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindAll(ctx context.Context) ([]OfficeLocation, error)
+	FindByID(ctx context.Context, id uint) (*OfficeLocation, error)
+	FindActiveByEmployee(ctx context.Context, employeeID, departmentID uint) ([]OfficeLocation, error)
+	Create(ctx context.Context, location *OfficeLocation) error
+	Update(ctx context.Context, location *OfficeLocation) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db}
+}
+
+func (r *repository) FindAll(ctx context.Context) ([]OfficeLocation, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var locations []OfficeLocation
+
+	err := db.
+		Preload("Assignments.Employee").
+		Preload("Assignments.Department").
+		Order("name ASC").
+		Find(&locations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+func (r *repository) FindByID(ctx context.Context, id uint) (*OfficeLocation, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var location OfficeLocation
+
+	err := db.
+		Preload("Assignments.Employee").
+		Preload("Assignments.Department").
+		First(&location, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &location, nil
+}
+
+// FindActiveByEmployee return active office locations assigned to the employee,
+// falling back to the department when the employee has no assignment of its own
+func (r *repository) FindActiveByEmployee(ctx context.Context, employeeID, departmentID uint) ([]OfficeLocation, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+
+	for _, assignee := range []struct {
+		column string
+		id     uint
+	}{
+		{"employee_id", employeeID},
+		{"department_id", departmentID},
+	} {
+		if assignee.id == 0 {
+			continue
+		}
+
+		var locations []OfficeLocation
+		err := db.
+			Where("is_active = ?", true).
+			Where("id IN (?)", db.Model(&OfficeLocationAssignment{}).
+				Select("office_location_id").
+				Where(assignee.column+" = ?", assignee.id)).
+			Find(&locations).Error
+		if err != nil {
+			return nil, err
+		}
+
+		if len(locations) > 0 {
+			return locations, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Create save the location & its assignments, run inside a transaction
+func (r *repository) Create(ctx context.Context, location *OfficeLocation) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+
+	if err := db.Omit("Assignments").Create(location).Error; err != nil {
+		return err
+	}
+
+	return r.createAssignments(db, location)
+}
+
+// Update save the location & replace all of its assignments, run inside a transaction
+func (r *repository) Update(ctx context.Context, location *OfficeLocation) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+
+	if err := db.Omit("Assignments").Save(location).Error; err != nil {
+		return err
+	}
+
+	if err := db.Where("office_location_id = ?", location.ID).Delete(&OfficeLocationAssignment{}).Error; err != nil {
+		return err
+	}
+
+	return r.createAssignments(db, location)
+}
+
+func (r *repository) createAssignments(db *gorm.DB, location *OfficeLocation) error {
+	for i := range location.Assignments {
+		location.Assignments[i].OfficeLocationID = location.ID
+	}
+	if len(location.Assignments) > 0 {
+		if err := db.Omit("Employee", "Department").Create(&location.Assignments).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, id uint) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Delete(&OfficeLocation{}, id).Error
+}
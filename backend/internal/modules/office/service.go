@@ -0,0 +1,90 @@
+package office
+
+import (
+	"basekarya-backend/internal/infrastructure"
+	"context"
+	"errors"
+	"strings"
+)
+
+type Service interface {
+	GetAll(ctx context.Context) ([]OfficeLocation, error)
+	Create(ctx context.Context, req *OfficeLocationRequest) (*OfficeLocation, error)
+	Update(ctx context.Context, id uint, req *OfficeLocationRequest) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type service struct {
+	repo               Repository
+	transactionManager infrastructure.TransactionManager
+}
+
+func NewService(repo Repository, transactionManager infrastructure.TransactionManager) Service {
+	return &service{repo, transactionManager}
+}
+
+func (s *service) GetAll(ctx context.Context) ([]OfficeLocation, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *service) Create(ctx context.Context, req *OfficeLocationRequest) (*OfficeLocation, error) {
+	location := OfficeLocation{}
+	if err := fillLocation(&location, req); err != nil {
+		return nil, err
+	}
+
+	err := s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		return s.repo.Create(ctx, &location)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &location, nil
+}
+
+func (s *service) Update(ctx context.Context, id uint, req *OfficeLocationRequest) error {
+	location, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return errors.New("office location not found")
+	}
+
+	if err := fillLocation(location, req); err != nil {
+		return err
+	}
+
+	return s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		return s.repo.Update(ctx, location)
+	})
+}
+
+func (s *service) Delete(ctx context.Context, id uint) error {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return errors.New("office location not found")
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+func fillLocation(location *OfficeLocation, req *OfficeLocationRequest) error {
+	location.Name = strings.TrimSpace(req.Name)
+	location.Address = strings.TrimSpace(req.Address)
+	location.Latitude = req.Latitude
+	location.Longitude = req.Longitude
+	location.RadiusMeter = req.RadiusMeter
+	location.IsActive = *req.IsActive
+
+	location.Assignments = []OfficeLocationAssignment{}
+	for _, a := range req.Assignments {
+		if (a.EmployeeID == nil) == (a.DepartmentID == nil) {
+			return errors.New("assignment must have either employee_id or department_id")
+		}
+
+		location.Assignments = append(location.Assignments, OfficeLocationAssignment{
+			EmployeeID:   a.EmployeeID,
+			DepartmentID: a.DepartmentID,
+		})
+	}
+
+	return nil
+}
@@ -12,8 +12,9 @@ type Repository interface {
 	Create(ctx context.Context, overtime *Overtime) error
 	FindByID(ctx context.Context, id uint) (*Overtime, error)
 	FindAll(ctx context.Context, filter OvertimeFilter) ([]Overtime, int64, error)
-	GetBulkActiveOvertimesByEmployeeIds(ctx context.Context, month, year int, ids []uint) (map[uint]int, error)
+	GetBulkActiveOvertimesByEmployeeIds(ctx context.Context, month, year int, ids []uint) (map[uint][]Overtime, error)
 	UpdateBulkStatusByEmployeeId(ctx context.Context, employeeID uint, periodMonth, periodYear int, status constants.OvertimeStatus) error
+	RevertBulkPaidStatusByEmployeeId(ctx context.Context, employeeID uint, periodMonth, periodYear int) error
 	Update(ctx context.Context, overtime *Overtime) error
 }
 
@@ -82,29 +83,24 @@ func (r *repository) Update(ctx context.Context, overtime *Overtime) error {
 	return db.Save(overtime).Error
 }
 
-func (r *repository) GetBulkActiveOvertimesByEmployeeIds(ctx context.Context, month, year int, ids []uint) (map[uint]int, error) {
+// GetBulkActiveOvertimesByEmployeeIds return approved overtimes of a period keyed by employee id, ordered by date
+func (r *repository) GetBulkActiveOvertimesByEmployeeIds(ctx context.Context, month, year int, ids []uint) (map[uint][]Overtime, error) {
 	db := utils.GetDBFromContext(ctx, r.db)
-	type Result struct {
-		EmployeeID      uint
-		DurationMinutes int
-	}
-
-	var results []Result
+	var overtimes []Overtime
 
-	err := db.Model(&Overtime{}).
-		Select("employee_id, SUM(duration_minutes) as duration_minutes").
+	err := db.
 		Where("status = ?", string(constants.OvertimeStatusApproved)).
 		Where("MONTH(date) = ? AND YEAR(date) = ?", month, year).
 		Where("employee_id IN ?", ids).
-		Group("employee_id").
-		Scan(&results).Error
+		Order("date ASC, start_time ASC").
+		Find(&overtimes).Error
 	if err != nil {
 		return nil, err
 	}
 
-	dataMap := make(map[uint]int)
-	for _, res := range results {
-		dataMap[res.EmployeeID] = res.DurationMinutes
+	dataMap := make(map[uint][]Overtime)
+	for _, ot := range overtimes {
+		dataMap[ot.EmployeeID] = append(dataMap[ot.EmployeeID], ot)
 	}
 
 	return dataMap, nil
@@ -118,3 +114,12 @@ func (r *repository) UpdateBulkStatusByEmployeeId(ctx context.Context, employeeI
 		Where("MONTH(date) = ? AND YEAR(date) = ?", periodMonth, periodYear).
 		Update("status", string(status)).Error
 }
+
+func (r *repository) RevertBulkPaidStatusByEmployeeId(ctx context.Context, employeeID uint, periodMonth, periodYear int) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Model(&Overtime{}).
+		Where("employee_id = ?", employeeID).
+		Where("status = ?", string(constants.OvertimeStatusPaid)).
+		Where("MONTH(date) = ? AND YEAR(date) = ?", periodMonth, periodYear).
+		Update("status", string(constants.OvertimeStatusApproved)).Error
+}
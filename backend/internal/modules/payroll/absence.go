@@ -0,0 +1,52 @@
+package payroll
+
+import (
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"fmt"
+)
+
+// dailyRateAmount is days of base salary valued at daily rate, e.g. salary cut of unpaid days or unused leave payout
+type dailyRateAmount struct {
+	Days      int
+	Divisor   int
+	DailyRate money.Money
+	Amount    money.Money
+}
+
+// Label return payslip line of the amount, e.g. "Potongan Tidak Hadir (2 hari x Rp 227.273)"
+func (d dailyRateAmount) Label(title string) string {
+	return fmt.Sprintf("%s (%d hari x Rp %s)", title, d.Days, d.DailyRate.Format())
+}
+
+// periodWorkingDays return number of working days of the period from company work week, holiday excluded
+func (in *calculationInput) periodWorkingDays() int {
+	return in.countWorkingDays(in.PeriodDate, in.PeriodDate.AddDate(0, 1, -1))
+}
+
+// dailyRateDivisor return number of days a monthly base salary is divided into
+func (in *calculationInput) dailyRateDivisor() int {
+	if in.DailyRateBasis == constants.DailyRateBasisFixedDivisor && in.DailyRateDivisor > 0 {
+		return in.DailyRateDivisor
+	}
+
+	return in.periodWorkingDays()
+}
+
+// calculateAbsenceDeduction deduct days from base salary at daily rate, amount never exceed maxAmount
+func (in *calculationInput) calculateAbsenceDeduction(baseSalary money.Money, days int, maxAmount money.Money) dailyRateAmount {
+	d := dailyRateAmount{Days: days, Divisor: in.dailyRateDivisor()}
+	if days <= 0 || d.Divisor <= 0 || maxAmount <= 0 {
+		return d
+	}
+
+	d.DailyRate = baseSalary.MulRatio(1, int64(d.Divisor)).Round()
+	d.Amount = money.Min(baseSalary.MulRatio(int64(days), int64(d.Divisor)).Round(), maxAmount)
+
+	return d
+}
+
+// calculateDailyPay value days of base salary at daily rate without any cap, e.g. unused leave of a leaving employee
+func (in *calculationInput) calculateDailyPay(baseSalary money.Money, days int) dailyRateAmount {
+	return in.calculateAbsenceDeduction(baseSalary, days, baseSalary.Mul(int64(days)))
+}
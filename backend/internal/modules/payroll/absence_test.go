@@ -0,0 +1,69 @@
+package payroll
+
+import (
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"testing"
+	"time"
+)
+
+func TestCalculateAbsenceDeduction(t *testing.T) {
+	period := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name      string
+		in        calculationInput
+		base      money.Money
+		days      int
+		maxAmount money.Money
+		expected  money.Money
+		label     string
+	}{
+		{
+			name:      "working days of period",
+			in:        calculationInput{PeriodDate: period, WorkDaysPerWeek: 5},
+			base:      money.New(4_400_000),
+			days:      2,
+			maxAmount: money.New(4_400_000),
+			expected:  money.New(400_000),
+			label:     "Potongan Tidak Hadir (2 hari x Rp 200.000)",
+		},
+		{
+			name:      "holiday excluded from working days",
+			in:        calculationInput{PeriodDate: period, WorkDaysPerWeek: 5, Holidays: map[string]string{"2026-10-02": "Libur"}},
+			base:      money.New(4_200_000),
+			days:      1,
+			maxAmount: money.New(4_200_000),
+			expected:  money.New(200_000),
+			label:     "Potongan Tidak Hadir (1 hari x Rp 200.000)",
+		},
+		{
+			name:      "fixed divisor",
+			in:        calculationInput{PeriodDate: period, DailyRateBasis: constants.DailyRateBasisFixedDivisor, DailyRateDivisor: 25},
+			base:      money.New(5_000_000),
+			days:      3,
+			maxAmount: money.New(5_000_000),
+			expected:  money.New(600_000),
+			label:     "Potongan Tidak Hadir (3 hari x Rp 200.000)",
+		},
+		{
+			name:      "capped to max amount",
+			in:        calculationInput{PeriodDate: period, WorkDaysPerWeek: 5},
+			base:      money.New(4_400_000),
+			days:      10,
+			maxAmount: money.New(1_000_000),
+			expected:  money.New(1_000_000),
+			label:     "Potongan Tidak Hadir (10 hari x Rp 200.000)",
+		},
+	}
+
+	for _, tt := range tests {
+		d := tt.in.calculateAbsenceDeduction(tt.base, tt.days, tt.maxAmount)
+		if d.Amount != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.expected, d.Amount)
+		}
+		if label := d.Label("Potongan Tidak Hadir"); label != tt.label {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.label, label)
+		}
+	}
+}
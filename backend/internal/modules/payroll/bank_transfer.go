@@ -0,0 +1,217 @@
+package payroll
+
+import (
+	"basekarya-backend/pkg/money"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bankTransferRow is a single salary transfer to an employee account
+type bankTransferRow struct {
+	PayrollID     uint
+	EmployeeNIK   string
+	EmployeeName  string
+	AccountNumber string
+	AccountHolder string
+	Amount        money.Money
+}
+
+// bankTransferHeader is the source account & period of a bulk transfer file
+type bankTransferHeader struct {
+	CompanyName   string
+	AccountNumber string
+	PeriodDate    time.Time
+	TransferDate  time.Time
+}
+
+// BankTransferFormatter produce bulk transfer upload file of a bank
+type BankTransferFormatter interface {
+	Code() string
+	ValidateAccountNumber(accountNumber string) error
+	Format(header bankTransferHeader, rows []bankTransferRow) ([]byte, error)
+}
+
+var bankTransferFormatters = map[string]BankTransferFormatter{}
+
+// RegisterBankTransferFormatter add or replace formatter of a bank, keyed by its code
+func RegisterBankTransferFormatter(formatter BankTransferFormatter) {
+	bankTransferFormatters[formatter.Code()] = formatter
+}
+
+func init() {
+	RegisterBankTransferFormatter(bcaFormatter{})
+	RegisterBankTransferFormatter(mandiriFormatter{})
+	RegisterBankTransferFormatter(bniFormatter{})
+	RegisterBankTransferFormatter(briFormatter{})
+}
+
+// bank name is free text on employee data, map common full names to bank code
+var bankNameAliases = map[string]string{
+	"CENTRAL ASIA":      "BCA",
+	"NEGARA INDONESIA":  "BNI",
+	"RAKYAT INDONESIA":  "BRI",
+	"MANDIRI (PERSERO)": "MANDIRI",
+}
+
+// resolveBankCode normalize employee bank name into registered bank code,
+// e.g. "PT. Bank Central Asia Tbk" become "BCA"
+func resolveBankCode(bankName string) string {
+	var words []string
+	for _, word := range strings.Fields(strings.ToUpper(strings.ReplaceAll(bankName, ".", ""))) {
+		if word == "PT" || word == "BANK" || word == "TBK" {
+			continue
+		}
+		words = append(words, word)
+	}
+
+	name := strings.Join(words, " ")
+	if code, ok := bankNameAliases[name]; ok {
+		return code
+	}
+
+	return name
+}
+
+var digitsOnly = regexp.MustCompile(`^[0-9]+$`)
+
+func validateAccountLength(bank, accountNumber string, length int) error {
+	if !digitsOnly.MatchString(accountNumber) {
+		return fmt.Errorf("%s account number must be numeric", bank)
+	}
+
+	if len(accountNumber) != length {
+		return fmt.Errorf("%s account number must be %d digits", bank, length)
+	}
+
+	return nil
+}
+
+func transferRemark(periodDate time.Time) string {
+	return fmt.Sprintf("Gaji %s", periodDate.Format("Jan 2006"))
+}
+
+// BankTransferIssue is a payroll that cannot be included in the transfer file
+type BankTransferIssue struct {
+	PayrollID    uint   `json:"payroll_id"`
+	EmployeeNIK  string `json:"employee_nik"`
+	EmployeeName string `json:"employee_name"`
+	Reason       string `json:"reason"`
+}
+
+// BankTransferValidationError is returned when any payroll has missing or invalid bank data
+type BankTransferValidationError struct {
+	Issues []BankTransferIssue
+}
+
+func (e *BankTransferValidationError) Error() string {
+	return fmt.Sprintf("%d payroll have invalid bank data", len(e.Issues))
+}
+
+func totalTransferAmount(rows []bankTransferRow) money.Money {
+	var total money.Money
+	for _, row := range rows {
+		total += row.Amount
+	}
+
+	return total
+}
+
+func writeCSV(records [][]string) ([]byte, error) {
+	var buffer bytes.Buffer
+	w := csv.NewWriter(&buffer)
+
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// bcaFormatter write BCA bulk transfer with a header & detail record
+type bcaFormatter struct{}
+
+func (bcaFormatter) Code() string { return "BCA" }
+
+func (bcaFormatter) ValidateAccountNumber(accountNumber string) error {
+	return validateAccountLength("BCA", accountNumber, 10)
+}
+
+func (bcaFormatter) Format(header bankTransferHeader, rows []bankTransferRow) ([]byte, error) {
+	records := [][]string{
+		{"H", header.AccountNumber, header.TransferDate.Format("20060102"), fmt.Sprintf("%d", len(rows)), totalTransferAmount(rows).String()},
+	}
+
+	for _, row := range rows {
+		records = append(records, []string{"D", row.AccountNumber, row.AccountHolder, row.Amount.String(), transferRemark(header.PeriodDate)})
+	}
+
+	return writeCSV(records)
+}
+
+// mandiriFormatter write Mandiri bulk transfer, first line is the debit account summary
+type mandiriFormatter struct{}
+
+func (mandiriFormatter) Code() string { return "MANDIRI" }
+
+func (mandiriFormatter) ValidateAccountNumber(accountNumber string) error {
+	return validateAccountLength("Mandiri", accountNumber, 13)
+}
+
+func (mandiriFormatter) Format(header bankTransferHeader, rows []bankTransferRow) ([]byte, error) {
+	records := [][]string{
+		{"P", header.TransferDate.Format("20060102"), header.AccountNumber, fmt.Sprintf("%d", len(rows)), strconv.FormatInt(totalTransferAmount(rows).Whole(), 10)},
+	}
+
+	for _, row := range rows {
+		records = append(records, []string{row.AccountNumber, row.AccountHolder, "IDR", strconv.FormatInt(row.Amount.Whole(), 10), transferRemark(header.PeriodDate), row.EmployeeNIK})
+	}
+
+	return writeCSV(records)
+}
+
+// bniFormatter write BNI bulk transfer as a plain table
+type bniFormatter struct{}
+
+func (bniFormatter) Code() string { return "BNI" }
+
+func (bniFormatter) ValidateAccountNumber(accountNumber string) error {
+	return validateAccountLength("BNI", accountNumber, 10)
+}
+
+func (bniFormatter) Format(header bankTransferHeader, rows []bankTransferRow) ([]byte, error) {
+	records := [][]string{
+		{"No", "Rekening Tujuan", "Nama Penerima", "Nominal", "Keterangan"},
+	}
+
+	for i, row := range rows {
+		records = append(records, []string{fmt.Sprintf("%d", i+1), row.AccountNumber, row.AccountHolder, strconv.FormatInt(row.Amount.Whole(), 10), transferRemark(header.PeriodDate)})
+	}
+
+	return writeCSV(records)
+}
+
+// briFormatter write BRI bulk transfer as a plain table
+type briFormatter struct{}
+
+func (briFormatter) Code() string { return "BRI" }
+
+func (briFormatter) ValidateAccountNumber(accountNumber string) error {
+	return validateAccountLength("BRI", accountNumber, 15)
+}
+
+func (briFormatter) Format(header bankTransferHeader, rows []bankTransferRow) ([]byte, error) {
+	records := [][]string{
+		{"NO REKENING", "NAMA", "NOMINAL", "KETERANGAN"},
+	}
+
+	for _, row := range rows {
+		records = append(records, []string{row.AccountNumber, row.AccountHolder, strconv.FormatInt(row.Amount.Whole(), 10), transferRemark(header.PeriodDate)})
+	}
+
+	return writeCSV(records)
+}
@@ -0,0 +1,43 @@
+package payroll
+
+import "testing"
+
+func TestResolveBankCode(t *testing.T) {
+	tests := map[string]string{
+		"BCA":                          "BCA",
+		"bank bca":                     "BCA",
+		"PT. Bank Central Asia Tbk":    "BCA",
+		"Bank Mandiri (Persero)":       "MANDIRI",
+		"Bank Negara Indonesia":        "BNI",
+		"PT Bank Rakyat Indonesia Tbk": "BRI",
+		"Bank Jago":                    "JAGO",
+	}
+
+	for name, expected := range tests {
+		if code := resolveBankCode(name); code != expected {
+			t.Errorf("%s: expected %s, got %s", name, expected, code)
+		}
+	}
+}
+
+func TestBankTransferFormatter_ValidateAccountNumber(t *testing.T) {
+	tests := []struct {
+		bank    string
+		account string
+		valid   bool
+	}{
+		{"BCA", "1234567890", true},
+		{"BCA", "123456789", false},
+		{"BCA", "12345-6789", false},
+		{"MANDIRI", "1234567890123", true},
+		{"BRI", "123456789012345", true},
+		{"BNI", "123456789012345", false},
+	}
+
+	for _, tt := range tests {
+		err := bankTransferFormatters[tt.bank].ValidateAccountNumber(tt.account)
+		if (err == nil) != tt.valid {
+			t.Errorf("%s %s: expected valid %v, got error %v", tt.bank, tt.account, tt.valid, err)
+		}
+	}
+}
@@ -0,0 +1,363 @@
+package payroll
+
+import (
+	"basekarya-backend/internal/modules/bpjs"
+	"basekarya-backend/internal/modules/latepolicy"
+	"basekarya-backend/internal/modules/loan"
+	"basekarya-backend/internal/modules/overtime"
+	"basekarya-backend/internal/modules/salarycomponent"
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// calculationInput hold all bulk data of a period, fetched once and looked up per employee
+type calculationInput struct {
+	PeriodDate   time.Time
+	LateMap      map[uint][]int
+	LatePolicy   *latepolicy.LatePolicy
+	ReimburseMap map[uint]money.Money
+	LoanMap      map[uint]loan.Loan
+	OvertimeMap  map[uint][]overtime.Overtime
+	Assignments  []salarycomponent.SalaryComponentAssignment
+	BPJSRates    []bpjs.ContributionRate
+
+	ProrationBasis constants.ProrationBasis
+
+	// Holidays is holiday name keyed by date, used with WorkDaysPerWeek to decide overtime rate of a day
+	Holidays        map[string]string
+	WorkDaysPerWeek int
+
+	// AbsentMap & UnpaidLeaveMap is unpaid days keyed by employee id, nil when the deduction is disabled
+	AbsentMap        map[uint]int
+	UnpaidLeaveMap   map[uint]int
+	DailyRateBasis   constants.DailyRateBasis
+	DailyRateDivisor int
+
+	// TaxYearToDateMap only filled on december for the annual PPh 21 true-up
+	TaxYearToDateMap map[uint]taxYearToDate
+}
+
+// resolveComponents return active salary component assignments that apply to the employee.
+// employee-level assignment override department-level assignment of the same component
+func (in *calculationInput) resolveComponents(emp *user.Employee) []salarycomponent.SalaryComponentAssignment {
+	resolved := make(map[uint]salarycomponent.SalaryComponentAssignment)
+
+	for _, a := range in.Assignments {
+		if a.DepartmentID != nil && *a.DepartmentID == emp.DepartmentID {
+			if _, exists := resolved[a.SalaryComponentID]; !exists {
+				resolved[a.SalaryComponentID] = a
+			}
+		}
+	}
+
+	for _, a := range in.Assignments {
+		if a.EmployeeID != nil && *a.EmployeeID == emp.ID {
+			resolved[a.SalaryComponentID] = a
+		}
+	}
+
+	result := make([]salarycomponent.SalaryComponentAssignment, 0, len(resolved))
+	for _, a := range resolved {
+		result = append(result, a)
+	}
+
+	// keep payslip lines in a stable order
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].SalaryComponentID < result[j].SalaryComponentID
+	})
+
+	return result
+}
+
+// calculatePayroll build a draft payroll with its detail lines for a single employee
+func calculatePayroll(emp *user.Employee, in *calculationInput) Payroll {
+	payroll := buildPayroll(emp, in)
+	payroll.finalize(emp, in)
+
+	return payroll
+}
+
+// buildPayroll build the earning & deduction lines of a period before BPJS & PPh 21 is applied
+func buildPayroll(emp *user.Employee, in *calculationInput) Payroll {
+	baseSalary := emp.BaseSalary
+	prorate := in.calculateProration(emp)
+	proratedBaseSalary := prorate.Apply(baseSalary)
+	latePenalty := in.LatePolicy.Calculate(emp, in.LateMap[emp.ID])
+	reimburseAmount := in.ReimburseMap[emp.UserID]
+	loanData := in.LoanMap[emp.ID]
+	// last installment only deduct what is left of the loan
+	loanAmount := money.Min(loanData.InstallmentAmount, loanData.RemainingAmount)
+	overtimeDays := calculateOvertimeDays(baseSalary, in.OvertimeMap[emp.ID], in)
+
+	baseSalaryTitle := "Base Salary"
+	if !prorate.IsFull() {
+		baseSalaryTitle = fmt.Sprintf("Base Salary Prorata (%s x Rp %s)", prorate.Label(), baseSalary.Format())
+	}
+
+	details := []PayrollDetail{
+		{
+			Title:     baseSalaryTitle,
+			Type:      constants.DetailTypeAllowance,
+			Amount:    proratedBaseSalary,
+			IsTaxable: true,
+		},
+	}
+
+	// check if reimburse amount not zero
+	if reimburseAmount > 0 {
+		details = append(details, PayrollDetail{
+			Title:  "Reimbursement",
+			Type:   constants.DetailTypeAllowance,
+			Amount: reimburseAmount,
+		})
+	}
+
+	// overtime is paid per day since the rate depend on the day type
+	for _, day := range overtimeDays {
+		if day.Amount <= 0 {
+			continue
+		}
+
+		details = append(details, PayrollDetail{
+			Title:     day.Label(),
+			Type:      constants.DetailTypeAllowance,
+			Amount:    day.Amount,
+			IsTaxable: true,
+		})
+	}
+
+	for _, a := range in.resolveComponents(emp) {
+		component := a.SalaryComponent
+		if component == nil {
+			continue
+		}
+
+		title := component.Name
+		amount := a.ResolveAmount().Round()
+		if component.CalculationType == constants.SalaryComponentCalculationPercentage {
+			amount = proratedBaseSalary.Percent(a.ResolvePercentage()).Round()
+		} else if component.Type == constants.DetailTypeAllowance && !prorate.IsFull() {
+			// fixed allowance follow base salary proration
+			amount = prorate.Apply(amount)
+			title = fmt.Sprintf("%s (Prorata %s)", component.Name, prorate.Label())
+		}
+
+		if amount <= 0 {
+			continue
+		}
+
+		details = append(details, PayrollDetail{
+			Title:     title,
+			Type:      component.Type,
+			Amount:    amount,
+			IsTaxable: component.IsTaxable,
+		})
+	}
+
+	// check if late penalty amount not zero, title describe the late policy rule applied
+	if latePenalty.Amount > 0 {
+		details = append(details, PayrollDetail{
+			Title:  latePenalty.Description,
+			Type:   constants.DetailTypeDeduction,
+			Amount: latePenalty.Amount,
+		})
+	}
+
+	// absent & unpaid leave days are cut at daily rate, together never more than the prorated base salary
+	remainingBaseSalary := proratedBaseSalary
+	for _, unpaid := range []struct {
+		title string
+		days  int
+	}{
+		{"Potongan Tidak Hadir", in.AbsentMap[emp.ID]},
+		{"Potongan Cuti Tidak Dibayar", in.UnpaidLeaveMap[emp.ID]},
+	} {
+		deduction := in.calculateAbsenceDeduction(baseSalary, unpaid.days, remainingBaseSalary)
+		if deduction.Amount <= 0 {
+			continue
+		}
+
+		remainingBaseSalary -= deduction.Amount
+		details = append(details, PayrollDetail{
+			Title:     deduction.Label(unpaid.title),
+			Type:      constants.DetailTypeDeduction,
+			Amount:    deduction.Amount,
+			IsTaxable: true,
+		})
+	}
+
+	// check if loan amount not zero
+	if loanAmount > 0 {
+		details = append(details, PayrollDetail{
+			Title:  "Potongan Kasbon",
+			Type:   constants.DetailTypeDeduction,
+			Amount: loanAmount,
+		})
+	}
+
+	payroll := Payroll{
+		EmployeeID: emp.ID,
+		Type:       constants.PayrollTypeRegular,
+		PeriodDate: in.PeriodDate,
+		BaseSalary: proratedBaseSalary,
+		Status:     constants.PayrollStatusDraft,
+		Details:    details,
+	}
+	if loanAmount > 0 {
+		payroll.LoanID = &loanData.ID
+		payroll.LoanDeduction = loanAmount
+	}
+
+	return payroll
+}
+
+// finalize apply BPJS contribution & PPh 21 on top of the built lines then sum the totals
+func (p *Payroll) finalize(emp *user.Employee, in *calculationInput) {
+	p.applyContributions(in.BPJSRates)
+	p.applyTax(emp, in)
+	p.recalculateTotals()
+}
+
+// applyContributions calculate BPJS contribution from base salary, employee share become deduction line
+func (p *Payroll) applyContributions(rates []bpjs.ContributionRate) {
+	for _, rate := range rates {
+		employeeAmount, employerAmount := rate.Calculate(p.BaseSalary)
+		if employeeAmount == 0 && employerAmount == 0 {
+			continue
+		}
+
+		p.Contributions = append(p.Contributions, PayrollContribution{
+			Program:        rate.Program,
+			BaseAmount:     rate.ContributionBase(p.BaseSalary),
+			EmployeeRate:   rate.EmployeeRate,
+			EmployerRate:   rate.EmployerRate,
+			EmployeeAmount: employeeAmount,
+			EmployerAmount: employerAmount,
+		})
+
+		if employeeAmount > 0 {
+			p.Details = append(p.Details, PayrollDetail{
+				Title:  fmt.Sprintf("BPJS %s (%s%%)", bpjsProgramLabel(rate.Program), strconv.FormatFloat(rate.EmployeeRate, 'f', -1, 64)),
+				Type:   constants.DetailTypeDeduction,
+				Amount: employeeAmount,
+			})
+		}
+	}
+}
+
+// pensionContribution return employee share of JHT & JP of this payroll
+func (p *Payroll) pensionContribution() money.Money {
+	var total money.Money
+	for _, c := range p.Contributions {
+		if c.Program == constants.BPJSProgramJHT || c.Program == constants.BPJSProgramJP {
+			total += c.EmployeeAmount
+		}
+	}
+
+	return total
+}
+
+func bpjsProgramLabel(program constants.BPJSProgram) string {
+	if program == constants.BPJSProgramKesehatan {
+		return "Kesehatan"
+	}
+
+	return string(program)
+}
+
+// applyTax calculate PPh 21 from taxable detail lines & append it as a detail line
+func (p *Payroll) applyTax(emp *user.Employee, in *calculationInput) {
+	profile := taxProfile{
+		MaritalStatus: emp.MaritalStatus,
+		Dependents:    emp.Dependents,
+		HasNPWP:       strings.TrimSpace(emp.NPWP) != "",
+	}
+
+	var taxableIncome money.Money
+	for _, d := range p.Details {
+		if !d.IsTaxable {
+			continue
+		}
+
+		if d.Type == constants.DetailTypeAllowance {
+			taxableIncome += d.Amount
+		} else {
+			taxableIncome -= d.Amount
+		}
+	}
+
+	// employer paid premium of BPJS Kesehatan, JKK & JKM is a taxable benefit
+	for _, c := range p.Contributions {
+		switch c.Program {
+		case constants.BPJSProgramKesehatan, constants.BPJSProgramJKK, constants.BPJSProgramJKM:
+			taxableIncome += c.EmployerAmount
+		}
+	}
+
+	if taxableIncome < 0 {
+		taxableIncome = 0
+	}
+
+	p.TaxableIncome = taxableIncome
+	p.PTKPStatus = profile.PTKPCode()
+
+	// leaving employee settle the tax of the year on the final settlement, same as december true-up
+	if in.PeriodDate.Month() == time.December || p.IsFinalSettlement() {
+		taxAmount := calculateDecemberTax(profile, taxableIncome, p.pensionContribution(), in.TaxYearToDateMap[emp.ID])
+		p.TaxAmount = taxAmount
+
+		if taxAmount > 0 {
+			p.Details = append(p.Details, PayrollDetail{
+				Title:  fmt.Sprintf("PPh 21 Penyesuaian Tahunan (%s)", profile.PTKPCode()),
+				Type:   constants.DetailTypeDeduction,
+				Amount: taxAmount,
+			})
+		} else if taxAmount < 0 {
+			p.Details = append(p.Details, PayrollDetail{
+				Title:  "Pengembalian Kelebihan PPh 21",
+				Type:   constants.DetailTypeAllowance,
+				Amount: -taxAmount,
+			})
+		}
+
+		return
+	}
+
+	taxAmount, rate := calculateMonthlyTax(profile, taxableIncome)
+	p.TaxAmount = taxAmount
+
+	if taxAmount > 0 {
+		p.Details = append(p.Details, PayrollDetail{
+			Title:  fmt.Sprintf("PPh 21 (TER %s %s%%)", profile.terCategory(), strconv.FormatFloat(rate, 'f', -1, 64)),
+			Type:   constants.DetailTypeDeduction,
+			Amount: taxAmount,
+		})
+	}
+}
+
+// recalculateTotals sum detail lines into total allowance, total deduction & net salary
+func (p *Payroll) recalculateTotals() {
+	p.TotalAllowance = 0
+	p.TotalDeduction = 0
+
+	for _, d := range p.Details {
+		if d.Type == constants.DetailTypeAllowance {
+			p.TotalAllowance += d.Amount
+		} else {
+			p.TotalDeduction += d.Amount
+		}
+	}
+
+	p.NetSalary = p.TotalAllowance - p.TotalDeduction
+}
+
+// isPayable return false when employee not yet joined or already left on the period
+func isPayable(emp *user.Employee, in *calculationInput) bool {
+	return in.calculateProration(emp).WorkedDays > 0
+}
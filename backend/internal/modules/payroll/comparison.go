@@ -0,0 +1,158 @@
+package payroll
+
+import (
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"math"
+	"sort"
+	"strings"
+)
+
+func newVarianceAmount(previous, current money.Money) VarianceAmount {
+	v := VarianceAmount{
+		Previous: previous,
+		Current:  current,
+		Delta:    current - previous,
+	}
+
+	if previous != 0 {
+		percent := math.Round(v.Delta.Float64()/previous.Float64()*10000) / 100
+		v.DeltaPercent = &percent
+	}
+
+	return v
+}
+
+func (v VarianceAmount) exceeds(threshold float64) bool {
+	return v.DeltaPercent != nil && math.Abs(*v.DeltaPercent) > threshold
+}
+
+// comparisonTitle drop the variable part in brackets of a detail title so lines of both periods can be matched,
+// e.g. "Uang Lembur (3 jam 10 menit)" become "Uang Lembur"
+func comparisonTitle(title string) string {
+	if i := strings.Index(title, " ("); i > 0 {
+		title = title[:i]
+	}
+
+	return strings.TrimSpace(title)
+}
+
+// comparePayrolls match payrolls of two periods per employee, employee only on the current period is a new joiner
+// & employee only on the previous period is a leaver
+func comparePayrolls(previous, current []Payroll, threshold float64) []EmployeeVariance {
+	// THR is a one-off payment, comparing it with monthly salary only add noise
+	previous, current = regularPayrolls(previous), regularPayrolls(current)
+
+	previousMap := make(map[uint]*Payroll)
+	for i := range previous {
+		previousMap[previous[i].EmployeeID] = &previous[i]
+	}
+
+	currentMap := make(map[uint]*Payroll)
+	for i := range current {
+		currentMap[current[i].EmployeeID] = &current[i]
+	}
+
+	var result []EmployeeVariance
+	for i := range current {
+		result = append(result, compareEmployee(previousMap[current[i].EmployeeID], &current[i], threshold))
+	}
+
+	for i := range previous {
+		if _, ok := currentMap[previous[i].EmployeeID]; !ok {
+			result = append(result, compareEmployee(&previous[i], nil, threshold))
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].EmployeeNIK < result[j].EmployeeNIK
+	})
+
+	return result
+}
+
+func regularPayrolls(payrolls []Payroll) []Payroll {
+	result := make([]Payroll, 0, len(payrolls))
+	for _, p := range payrolls {
+		if !p.IsTHR() && !p.IsFinalSettlement() {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
+
+type detailKey struct {
+	Type  constants.PayrollDetailType
+	Title string
+}
+
+func compareEmployee(previous, current *Payroll, threshold float64) EmployeeVariance {
+	var employeeID uint
+	var emp *user.Employee
+	var previousBase, currentBase, previousNet, currentNet money.Money
+	var keys []detailKey
+	previousDetails := make(map[detailKey]money.Money)
+	currentDetails := make(map[detailKey]money.Money)
+
+	collect := func(details []PayrollDetail, amounts map[detailKey]money.Money) {
+		for _, d := range details {
+			key := detailKey{Type: d.Type, Title: comparisonTitle(d.Title)}
+			if _, seen := previousDetails[key]; !seen {
+				if _, seen := currentDetails[key]; !seen {
+					keys = append(keys, key)
+				}
+			}
+			amounts[key] += d.Amount
+		}
+	}
+
+	if previous != nil {
+		employeeID = previous.EmployeeID
+		emp = previous.Employee
+		previousBase = previous.BaseSalary
+		previousNet = previous.NetSalary
+		collect(previous.Details, previousDetails)
+	}
+
+	if current != nil {
+		employeeID = current.EmployeeID
+		emp = current.Employee
+		currentBase = current.BaseSalary
+		currentNet = current.NetSalary
+		collect(current.Details, currentDetails)
+	}
+
+	variance := EmployeeVariance{
+		EmployeeID: employeeID,
+		Flags:      []constants.PayrollVarianceFlag{},
+		BaseSalary: newVarianceAmount(previousBase, currentBase),
+		NetSalary:  newVarianceAmount(previousNet, currentNet),
+		Details:    []DetailVariance{},
+	}
+
+	if emp != nil {
+		variance.EmployeeNIK = emp.NIK
+		variance.EmployeeName = emp.FullName
+	}
+
+	switch {
+	case previous == nil:
+		variance.Flags = append(variance.Flags, constants.PayrollVarianceNewJoiner)
+	case current == nil:
+		variance.Flags = append(variance.Flags, constants.PayrollVarianceLeaver)
+	case variance.BaseSalary.exceeds(threshold) || variance.NetSalary.exceeds(threshold):
+		variance.Flags = append(variance.Flags, constants.PayrollVarianceAboveThreshold)
+	}
+
+	for _, key := range keys {
+		variance.Details = append(variance.Details, DetailVariance{
+			Title:          key.Title,
+			Type:           key.Type,
+			VarianceAmount: newVarianceAmount(previousDetails[key], currentDetails[key]),
+		})
+	}
+
+	return variance
+}
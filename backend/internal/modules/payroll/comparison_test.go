@@ -0,0 +1,71 @@
+package payroll
+
+import (
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"testing"
+)
+
+func TestComparisonTitle(t *testing.T) {
+	tests := map[string]string{
+		"Uang Lembur (3 jam 10 menit)": "Uang Lembur",
+		"PPh 21 (TER A 2%)":            "PPh 21",
+		"Potongan Kasbon":              "Potongan Kasbon",
+	}
+
+	for title, expected := range tests {
+		if got := comparisonTitle(title); got != expected {
+			t.Errorf("%s: expected %s, got %s", title, expected, got)
+		}
+	}
+}
+
+func TestComparePayrolls(t *testing.T) {
+	payroll := func(id uint, nik string, base, net int64) Payroll {
+		return Payroll{
+			EmployeeID: id,
+			Employee:   &user.Employee{ID: id, NIK: nik},
+			BaseSalary: money.New(base),
+			NetSalary:  money.New(net),
+			Details: []PayrollDetail{
+				{Title: "Uang Lembur (1 jam 0 menit)", Type: constants.DetailTypeAllowance, Amount: money.New(net - base)},
+			},
+		}
+	}
+
+	previous := []Payroll{
+		payroll(1, "001", 10_000_000, 10_000_000),
+		payroll(2, "002", 8_000_000, 8_000_000),
+		payroll(3, "003", 5_000_000, 5_000_000),
+	}
+	current := []Payroll{
+		payroll(1, "001", 10_000_000, 10_500_000),
+		payroll(2, "002", 8_000_000, 9_000_000),
+		payroll(4, "004", 6_000_000, 6_000_000),
+	}
+
+	result := comparePayrolls(previous, current, 10)
+	if len(result) != 4 {
+		t.Fatalf("expected 4 employees, got %d", len(result))
+	}
+
+	expected := map[string][]constants.PayrollVarianceFlag{
+		"001": {},
+		"002": {constants.PayrollVarianceAboveThreshold},
+		"003": {constants.PayrollVarianceLeaver},
+		"004": {constants.PayrollVarianceNewJoiner},
+	}
+
+	for _, e := range result {
+		flags := expected[e.EmployeeNIK]
+		if len(e.Flags) != len(flags) || (len(flags) > 0 && e.Flags[0] != flags[0]) {
+			t.Errorf("%s: expected flags %v, got %v", e.EmployeeNIK, flags, e.Flags)
+		}
+	}
+
+	overtime := result[0].Details[0]
+	if overtime.Title != "Uang Lembur" || overtime.Delta != money.New(500_000) || overtime.DeltaPercent != nil {
+		t.Errorf("unexpected overtime variance %+v", overtime)
+	}
+}
@@ -1,23 +1,34 @@
 package payroll
 
 import (
+	"basekarya-backend/internal/modules/bpjs"
 	"basekarya-backend/internal/modules/company"
+	"basekarya-backend/internal/modules/holiday"
+	"basekarya-backend/internal/modules/latepolicy"
 	"basekarya-backend/internal/modules/loan"
+	"basekarya-backend/internal/modules/overtime"
+	"basekarya-backend/internal/modules/salarycomponent"
 	"basekarya-backend/internal/modules/user"
 	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
 	"context"
+	"time"
 )
 
 type UserProvider interface {
 	FindAllEmployeeActive(ctx context.Context) ([]user.Employee, error)
+	FindEmployeeByID(ctx context.Context, id uint) (*user.Employee, error)
+	UpdateEmployee(ctx context.Context, emp *user.Employee) error
+	UpdateUser(ctx context.Context, user *user.User) error
 }
 
 type AttendanceProvider interface {
-	GetBulkLateDuration(ctx context.Context, month, year int) (map[uint]int, error)
+	GetBulkLateOccurrences(ctx context.Context, month, year int) (map[uint][]int, error)
+	GetBulkAbsentDays(ctx context.Context, month, year int) (map[uint]int, error)
 }
 
 type ReimbursementProvider interface {
-	GetBulkApprovedAmount(ctx context.Context, month, year int) (map[uint]float64, error)
+	GetBulkApprovedAmount(ctx context.Context, month, year int) (map[uint]money.Money, error)
 }
 
 type CompanyProvider interface {
@@ -37,10 +48,41 @@ type EmailProvider interface {
 
 type LoanProvider interface {
 	GetBulkActiveLoansByEmployeeIds(ctx context.Context, ids []uint) (map[uint]loan.Loan, error)
+	FindByID(ctx context.Context, id uint) (*loan.Loan, error)
 	Update(ctx context.Context, loan *loan.Loan) error
 }
 
 type OvertimeProvider interface {
-	GetBulkActiveOvertimesByEmployeeIds(ctx context.Context, month, year int, ids []uint) (map[uint]int, error)
+	GetBulkActiveOvertimesByEmployeeIds(ctx context.Context, month, year int, ids []uint) (map[uint][]overtime.Overtime, error)
 	UpdateBulkStatusByEmployeeId(ctx context.Context, employeeID uint, periodMonth, periodYear int, status constants.OvertimeStatus) error
+	RevertBulkPaidStatusByEmployeeId(ctx context.Context, employeeID uint, periodMonth, periodYear int) error
+}
+
+type SalaryComponentProvider interface {
+	FindAllActiveAssignments(ctx context.Context) ([]salarycomponent.SalaryComponentAssignment, error)
+}
+
+type BPJSProvider interface {
+	FindAllActive(ctx context.Context) ([]bpjs.ContributionRate, error)
+}
+
+type WebsocketProvider interface {
+	SendToUser(userID uint, payload []byte)
+}
+
+type PayslipSender interface {
+	BlastPayslipEmail(ctx context.Context, id uint) error
+}
+
+type LatePolicyProvider interface {
+	FindActive(ctx context.Context) (*latepolicy.LatePolicy, error)
+}
+
+type HolidayProvider interface {
+	FindByDateRange(ctx context.Context, start, end time.Time) ([]holiday.Holiday, error)
+}
+
+type LeaveProvider interface {
+	GetBulkUnpaidLeaveDays(ctx context.Context, month, year int) (map[uint]int, error)
+	GetPaidQuota(ctx context.Context, employeeID uint, year int) (int, int, error)
 }
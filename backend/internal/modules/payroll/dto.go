@@ -2,6 +2,7 @@ package payroll
 
 import (
 	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
 	"time"
 )
 
@@ -10,46 +11,108 @@ type GenerateRequest struct {
 	Year  int `json:"year" validate:"required,min=2024"`
 }
 
+// GenerateTHRRequest generate THR of the period, tenure is counted up to CutoffDate (usually H-7 of the religious holiday)
+type GenerateTHRRequest struct {
+	Month      int    `json:"month" validate:"required,min=1,max=12"`
+	Year       int    `json:"year" validate:"required,min=2024"`
+	CutoffDate string `json:"cutoff_date" validate:"required,datetime=2006-01-02"`
+}
+
+type SimulateRequest struct {
+	Month int `json:"month" validate:"required,min=1,max=12"`
+	Year  int `json:"year" validate:"required,min=2024"`
+}
+
+type RegenerateRequest struct {
+	Month   int  `json:"month" validate:"required,min=1,max=12"`
+	Year    int  `json:"year" validate:"required,min=2024"`
+	ActorID uint `json:"-"`
+}
+
+type VoidRequest struct {
+	ID      uint   `json:"-"`
+	ActorID uint   `json:"-"`
+	Reason  string `json:"reason" validate:"required,min=5"`
+}
+
 type GenerateResponse struct {
-	SuccessCount int `json:"success_count"`
-	Month        int `json:"month"`
-	Year         int `json:"year"`
+	PayrollRunID uint `json:"payroll_run_id"`
+	SuccessCount int  `json:"success_count"`
+	Month        int  `json:"month"`
+	Year         int  `json:"year"`
 }
 
 type PayrollFilter struct {
-	Page    int    `json:"page"`
-	Limit   int    `json:"limit"`
-	Month   int    `json:"month"`
-	Year    int    `json:"year"`
-	Keyword string `json:"keyword"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	Month      int    `json:"month"`
+	Year       int    `json:"year"`
+	Keyword    string `json:"keyword"`
+	EmployeeID uint   `json:"employee_id"`
+	Status     string `json:"status"`
+	Type       string `json:"type"`
 }
 
 type PayrollListResponse struct {
-	ID           uint      `json:"id"`
-	EmployeeName string    `json:"employee_name"`
-	EmployeeNIK  string    `json:"employee_nik"`
-	PeriodDate   string    `json:"period_date"`
-	NetSalary    float64   `json:"net_salary"`
-	Status       string    `json:"status"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID           uint        `json:"id"`
+	EmployeeName string      `json:"employee_name"`
+	EmployeeNIK  string      `json:"employee_nik"`
+	PeriodDate   string      `json:"period_date"`
+	Type         string      `json:"type"`
+	NetSalary    money.Money `json:"net_salary"`
+	Status       string      `json:"status"`
+	CreatedAt    time.Time   `json:"created_at"`
 }
 
 type PayrollDetailResponse struct {
-	ID                        uint      `json:"id"`
-	EmployeeID                uint      `json:"employee_id"`
-	EmployeeName              string    `json:"employee_name"`
-	EmployeeNIK               string    `json:"employee_nik"`
-	EmployeeBankNumber        string    `json:"employee_bank_number"`
-	EmployeeBankName          string    `json:"employee_bank_name"`
-	EmployeeBankAccountHolder string    `json:"employee_bank_account_holder"`
-	PeriodDate                string    `json:"period_date"`
-	BaseSalary                float64   `json:"base_salary"`
-	TotalAllowance            float64   `json:"total_allowance"`
-	TotalDeduction            float64   `json:"total_deduction"`
-	NetSalary                 float64   `json:"net_salary"`
-	Status                    string    `json:"status"`
-	CreatedAt                 time.Time `json:"created_at"`
-	Details                   []Detail  `json:"details"`
+	ID                        uint        `json:"id"`
+	EmployeeID                uint        `json:"employee_id"`
+	EmployeeName              string      `json:"employee_name"`
+	EmployeeNIK               string      `json:"employee_nik"`
+	EmployeeBankNumber        string      `json:"employee_bank_number"`
+	EmployeeBankName          string      `json:"employee_bank_name"`
+	EmployeeBankAccountHolder string      `json:"employee_bank_account_holder"`
+	PeriodDate                string      `json:"period_date"`
+	Type                      string      `json:"type"`
+	BaseSalary                money.Money `json:"base_salary"`
+	TotalAllowance            money.Money `json:"total_allowance"`
+	TotalDeduction            money.Money `json:"total_deduction"`
+	NetSalary                 money.Money `json:"net_salary"`
+	TaxableIncome             money.Money `json:"taxable_income"`
+	TaxAmount                 money.Money `json:"tax_amount"`
+	PTKPStatus                string      `json:"ptkp_status"`
+	Status                    string      `json:"status"`
+	CreatedAt                 time.Time   `json:"created_at"`
+	Details                   []Detail    `json:"details"`
+
+	Contributions []Contribution `json:"contributions"`
+}
+
+type SimulationResponse struct {
+	Month          int                `json:"month"`
+	Year           int                `json:"year"`
+	TotalEmployee  int                `json:"total_employee"`
+	TotalNetSalary money.Money        `json:"total_net_salary"`
+	TotalWarning   int                `json:"total_warning"`
+	Employees      []SimulatedPayroll `json:"employees"`
+}
+
+// SimulatedPayroll is a calculated payroll that is not saved, AlreadyGenerated mean the employee already has a draft
+type SimulatedPayroll struct {
+	EmployeeID       uint                                 `json:"employee_id"`
+	EmployeeName     string                               `json:"employee_name"`
+	EmployeeNIK      string                               `json:"employee_nik"`
+	BaseSalary       money.Money                          `json:"base_salary"`
+	TotalAllowance   money.Money                          `json:"total_allowance"`
+	TotalDeduction   money.Money                          `json:"total_deduction"`
+	NetSalary        money.Money                          `json:"net_salary"`
+	TaxableIncome    money.Money                          `json:"taxable_income"`
+	TaxAmount        money.Money                          `json:"tax_amount"`
+	PTKPStatus       string                               `json:"ptkp_status"`
+	AlreadyGenerated bool                                 `json:"already_generated"`
+	Warnings         []constants.PayrollSimulationWarning `json:"warnings"`
+	Details          []Detail                             `json:"details"`
+	Contributions    []Contribution                       `json:"contributions"`
 }
 
 type Detail struct {
@@ -60,5 +123,212 @@ type Detail struct {
 
 	Type constants.PayrollDetailType `json:"type"`
 
-	Amount float64 `json:"amount"`
+	Amount money.Money `json:"amount"`
+
+	IsTaxable bool `json:"is_taxable"`
+}
+
+// taxYearToDate is the accumulated taxable income & withheld tax of an employee before the current period
+type taxYearToDate struct {
+	EmployeeID    uint
+	TaxableIncome money.Money
+	TaxAmount     money.Money
+
+	// PensionContribution is employee share of JHT & JP, deductible on annual PPh 21
+	PensionContribution money.Money
+}
+
+type Contribution struct {
+	Program        constants.BPJSProgram `json:"program"`
+	BaseAmount     money.Money           `json:"base_amount"`
+	EmployeeRate   float64               `json:"employee_rate"`
+	EmployerRate   float64               `json:"employer_rate"`
+	EmployeeAmount money.Money           `json:"employee_amount"`
+	EmployerAmount money.Money           `json:"employer_amount"`
+}
+
+type PayrollRunFilter struct {
+	Page  int
+	Limit int
+	Year  int
+}
+
+type PayrollRunActionRequest struct {
+	ID           uint   `json:"-"`
+	SuperAdminID uint   `json:"-"`
+	Action       string `json:"action" validate:"required,oneof=REVIEW APPROVE PAY CLOSE"`
+}
+
+type PayrollRunResponse struct {
+	ID             uint                  `json:"id"`
+	PeriodDate     string                `json:"period_date"`
+	Type           string                `json:"type"`
+	Status         string                `json:"status"`
+	TotalEmployee  int                   `json:"total_employee"`
+	TotalNetSalary money.Money           `json:"total_net_salary"`
+	ReviewedAt     *time.Time            `json:"reviewed_at"`
+	ApprovedAt     *time.Time            `json:"approved_at"`
+	PaidAt         *time.Time            `json:"paid_at"`
+	ClosedAt       *time.Time            `json:"closed_at"`
+	CreatedAt      time.Time             `json:"created_at"`
+	Payrolls       []PayrollListResponse `json:"payrolls,omitempty"`
+}
+
+type SendPayslipEmailRequest struct {
+	Month   int  `json:"month" validate:"required,min=1,max=12"`
+	Year    int  `json:"year" validate:"required,min=2024"`
+	ActorID uint `json:"-"`
+}
+
+// FinalSettlementRequest end the employment of an employee on EndDate & settle its last salary, leave & severance
+type FinalSettlementRequest struct {
+	EmployeeID uint   `json:"employee_id" validate:"required"`
+	EndDate    string `json:"end_date" validate:"required,datetime=2006-01-02"`
+	Reason     string `json:"reason" validate:"required,oneof=RESIGNATION EFFICIENCY RETIREMENT DEATH MISCONDUCT CONTRACT_END"`
+	Notes      string `json:"notes"`
+	ActorID    uint   `json:"-"`
+}
+
+type FinalSettlementResponse struct {
+	ID              uint        `json:"id"`
+	PayrollID       uint        `json:"payroll_id"`
+	EmployeeID      uint        `json:"employee_id"`
+	EmployeeName    string      `json:"employee_name"`
+	EmployeeNIK     string      `json:"employee_nik"`
+	EndDate         string      `json:"end_date"`
+	Reason          string      `json:"reason"`
+	TenureMonths    int         `json:"tenure_months"`
+	UnusedLeaveDays int         `json:"unused_leave_days"`
+	OutstandingLoan money.Money `json:"outstanding_loan"`
+	SeverancePay    money.Money `json:"severance_pay"`
+	ServicePay      money.Money `json:"service_pay"`
+	NetSalary       money.Money `json:"net_salary"`
+	Details         []Detail    `json:"details"`
+}
+
+type UpdateSeveranceRuleRequest struct {
+	ID                   uint     `json:"-"`
+	SeveranceMultiplier  *float64 `json:"severance_multiplier" validate:"required,min=0,max=10"`
+	ServicePayMultiplier *float64 `json:"service_pay_multiplier" validate:"required,min=0,max=10"`
+}
+
+type SendTaxFormEmailRequest struct {
+	Year       int  `json:"year" validate:"required,min=2024"`
+	EmployeeID uint `json:"employee_id"`
+}
+
+type SendTaxFormEmailResponse struct {
+	Year        int                  `json:"year"`
+	TotalCount  int                  `json:"total_count"`
+	SentCount   int                  `json:"sent_count"`
+	FailedCount int                  `json:"failed_count"`
+	Results     []TaxFormEmailResult `json:"results"`
+}
+
+type TaxFormEmailResult struct {
+	EmployeeID   uint   `json:"employee_id"`
+	EmployeeName string `json:"employee_name"`
+	EmployeeNIK  string `json:"employee_nik"`
+	Email        string `json:"email"`
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error_message"`
+}
+
+type PayslipEmailJobResponse struct {
+	ID          uint                           `json:"id"`
+	PeriodDate  string                         `json:"period_date"`
+	Status      string                         `json:"status"`
+	TotalCount  int                            `json:"total_count"`
+	SentCount   int                            `json:"sent_count"`
+	FailedCount int                            `json:"failed_count"`
+	CreatedAt   time.Time                      `json:"created_at"`
+	FinishedAt  *time.Time                     `json:"finished_at"`
+	Deliveries  []PayslipEmailDeliveryResponse `json:"deliveries,omitempty"`
+}
+
+type PayslipEmailDeliveryResponse struct {
+	ID           uint       `json:"id"`
+	PayrollID    uint       `json:"payroll_id"`
+	EmployeeName string     `json:"employee_name"`
+	EmployeeNIK  string     `json:"employee_nik"`
+	Email        string     `json:"email"`
+	Status       string     `json:"status"`
+	Attempts     int        `json:"attempts"`
+	ErrorMessage string     `json:"error_message"`
+	SentAt       *time.Time `json:"sent_at"`
+}
+
+// PayslipEmailProgressMessage is pushed over websocket to the admin who started the job
+type PayslipEmailProgressMessage struct {
+	Type        string `json:"type"`
+	JobID       uint   `json:"job_id"`
+	Status      string `json:"status"`
+	TotalCount  int    `json:"total_count"`
+	SentCount   int    `json:"sent_count"`
+	FailedCount int    `json:"failed_count"`
+}
+
+type UpdateJournalAccountRequest struct {
+	ID   uint   `json:"-"`
+	Code string `json:"code" validate:"required,max=30"`
+	Name string `json:"name" validate:"required,max=150"`
+}
+
+type JournalAccountMappingRequest struct {
+	DetailType  string `json:"detail_type" validate:"required,oneof=ALLOWANCE DEDUCTION"`
+	TitlePrefix string `json:"title_prefix" validate:"max=150"`
+	AccountKey  string `json:"account_key" validate:"required"`
+}
+
+type ReplaceJournalMappingsRequest struct {
+	Mappings []JournalAccountMappingRequest `json:"mappings" validate:"required,min=1,dive"`
+}
+
+type JournalSettingResponse struct {
+	Accounts []JournalAccount        `json:"accounts"`
+	Mappings []JournalAccountMapping `json:"mappings"`
+}
+
+type PayrollComparisonRequest struct {
+	BaseMonth int
+	BaseYear  int
+	Month     int
+	Year      int
+
+	// Threshold is change in percent of base or net salary that get flagged
+	Threshold float64
+}
+
+type PayrollComparisonResponse struct {
+	BasePeriod         string             `json:"base_period"`
+	Period             string             `json:"period"`
+	Threshold          float64            `json:"threshold"`
+	TotalBaseNetSalary money.Money        `json:"total_base_net_salary"`
+	TotalNetSalary     money.Money        `json:"total_net_salary"`
+	TotalDelta         money.Money        `json:"total_delta"`
+	Employees          []EmployeeVariance `json:"employees"`
+}
+
+type EmployeeVariance struct {
+	EmployeeID   uint                            `json:"employee_id"`
+	EmployeeNIK  string                          `json:"employee_nik"`
+	EmployeeName string                          `json:"employee_name"`
+	Flags        []constants.PayrollVarianceFlag `json:"flags"`
+	BaseSalary   VarianceAmount                  `json:"base_salary"`
+	NetSalary    VarianceAmount                  `json:"net_salary"`
+	Details      []DetailVariance                `json:"details"`
+}
+
+type DetailVariance struct {
+	Title string                      `json:"title"`
+	Type  constants.PayrollDetailType `json:"type"`
+	VarianceAmount
+}
+
+// VarianceAmount is an amount on both periods, DeltaPercent is nil when the base amount is zero
+type VarianceAmount struct {
+	Previous     money.Money `json:"previous"`
+	Current      money.Money `json:"current"`
+	Delta        money.Money `json:"delta"`
+	DeltaPercent *float64    `json:"delta_percent"`
 }
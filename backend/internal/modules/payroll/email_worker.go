@@ -0,0 +1,189 @@
+package payroll
+
+import (
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+var errEmailDelivery = errors.New("failed to send email")
+
+const (
+	payslipEmailMaxAttempts  = 3
+	payslipEmailRetryBackoff = 5 * time.Second
+)
+
+// PayslipEmailWorker send payslip email of a job in background with a bounded worker pool
+type PayslipEmailWorker interface {
+	Start(workerCount int)
+	Enqueue(job *PayslipEmailJob)
+	Stop()
+}
+
+type payslipEmailTask struct {
+	JobID    uint
+	Delivery PayslipEmailDelivery
+}
+
+type payslipEmailWorker struct {
+	repo   Repository
+	sender PayslipSender
+	ws     WebsocketProvider
+	queue  chan payslipEmailTask
+	wg     *sync.WaitGroup
+	quit   chan bool
+}
+
+func NewPayslipEmailWorker(repo Repository, sender PayslipSender, ws WebsocketProvider, bufferSize int) PayslipEmailWorker {
+	return &payslipEmailWorker{
+		repo:   repo,
+		sender: sender,
+		ws:     ws,
+		queue:  make(chan payslipEmailTask, bufferSize),
+		wg:     &sync.WaitGroup{},
+		quit:   make(chan bool),
+	}
+}
+
+// Start also resume job left unfinished by the previous shutdown
+func (w *payslipEmailWorker) Start(workerCount int) {
+	for i := 0; i < workerCount; i++ {
+		w.wg.Add(1)
+		go w.runWorker(i)
+	}
+
+	jobs, err := w.repo.FindUnfinishedEmailJobs(context.Background())
+	if err != nil {
+		logger.Errorw("Failed to resume payslip email jobs", "error", err)
+		return
+	}
+
+	for i := range jobs {
+		w.Enqueue(&jobs[i])
+	}
+}
+
+// Enqueue push pending deliveries of the job, queue may be full on big period so it never block the caller
+func (w *payslipEmailWorker) Enqueue(job *PayslipEmailJob) {
+	go func() {
+		for _, delivery := range job.Deliveries {
+			if delivery.Status != constants.PayslipEmailDeliveryStatusPending {
+				continue
+			}
+
+			select {
+			case w.queue <- payslipEmailTask{JobID: job.ID, Delivery: delivery}:
+			case <-w.quit:
+				return
+			}
+		}
+	}()
+}
+
+// Stop leave unprocessed deliveries as PENDING, they are resumed on next Start
+func (w *payslipEmailWorker) Stop() {
+	logger.Info("Stopping Payslip Email Workers...")
+	close(w.quit)
+	w.wg.Wait()
+	logger.Info("All Payslip Email Workers stopped.")
+}
+
+func (w *payslipEmailWorker) runWorker(id int) {
+	defer w.wg.Done()
+	logger.Infof("Payslip Email Worker #%d Started", id)
+
+	for {
+		select {
+		case task := <-w.queue:
+			w.processTask(task)
+		case <-w.quit:
+			return
+		}
+	}
+}
+
+func (w *payslipEmailWorker) processTask(task payslipEmailTask) {
+	ctx := context.Background()
+	delivery := task.Delivery
+
+	if err := w.repo.StartEmailJob(ctx, task.JobID); err != nil {
+		logger.Errorw("Failed to start payslip email job", "error", err, "id", task.JobID)
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		delivery.Attempts++
+		err = w.sender.BlastPayslipEmail(ctx, delivery.PayrollID)
+
+		// only smtp failure is worth retrying, invalid payroll or employee data will fail again
+		if err == nil || !errors.Is(err, errEmailDelivery) || attempt == payslipEmailMaxAttempts {
+			break
+		}
+
+		select {
+		case <-time.After(payslipEmailRetryBackoff * time.Duration(attempt)):
+		case <-w.quit:
+			return
+		}
+	}
+
+	if err != nil {
+		delivery.Status = constants.PayslipEmailDeliveryStatusFailed
+		delivery.ErrorMessage = err.Error()
+	} else {
+		now := time.Now()
+		delivery.Status = constants.PayslipEmailDeliveryStatusSent
+		delivery.ErrorMessage = ""
+		delivery.SentAt = &now
+	}
+
+	if err := w.repo.UpdateEmailDelivery(ctx, &delivery); err != nil {
+		logger.Errorw("Failed to update payslip email delivery", "error", err, "id", delivery.ID)
+		return
+	}
+
+	w.updateProgress(ctx, task.JobID)
+}
+
+// updateProgress recount the job, close it when every delivery is done & push progress to the admin
+func (w *payslipEmailWorker) updateProgress(ctx context.Context, jobID uint) {
+	if err := w.repo.RefreshEmailJobCounts(ctx, jobID); err != nil {
+		logger.Errorw("Failed to refresh payslip email job", "error", err, "id", jobID)
+		return
+	}
+
+	job, err := w.repo.FindEmailJobByID(ctx, jobID)
+	if err != nil {
+		logger.Errorw("Failed to find payslip email job", "error", err, "id", jobID)
+		return
+	}
+
+	if job.Status != constants.PayslipEmailJobStatusCompleted && job.SentCount+job.FailedCount >= job.TotalCount {
+		now := time.Now()
+		job.Status = constants.PayslipEmailJobStatusCompleted
+		job.FinishedAt = &now
+
+		if err := w.repo.UpdateEmailJob(ctx, job); err != nil {
+			logger.Errorw("Failed to complete payslip email job", "error", err, "id", jobID)
+		}
+	}
+
+	payload, err := json.Marshal(&PayslipEmailProgressMessage{
+		Type:        string(constants.NotificationTypePayslipEmailProgress),
+		JobID:       job.ID,
+		Status:      string(job.Status),
+		TotalCount:  job.TotalCount,
+		SentCount:   job.SentCount,
+		FailedCount: job.FailedCount,
+	})
+	if err != nil {
+		logger.Errorw("Failed to marshal payslip email progress", "error", err, "id", jobID)
+		return
+	}
+
+	w.ws.SendToUser(job.CreatedBy, payload)
+}
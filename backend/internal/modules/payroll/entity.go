@@ -3,6 +3,7 @@ package payroll
 import (
 	"basekarya-backend/internal/modules/user"
 	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
 	"time"
 
 	"gorm.io/gorm"
@@ -16,18 +17,109 @@ type Payroll struct {
 
 	EmployeeID uint           `json:"employee_id"`
 	Employee   *user.Employee `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
-	PeriodDate time.Time      `gorm:"type:date;not null;index" json:"period_date"`
 
-	BaseSalary     float64 `gorm:"type:decimal(15,2)" json:"base_salary"`
-	TotalAllowance float64 `gorm:"type:decimal(15,2)" json:"total_allowance"`
-	TotalDeduction float64 `gorm:"type:decimal(15,2)" json:"total_deduction"`
-	NetSalary      float64 `gorm:"type:decimal(15,2)" json:"net_salary"`
+	PayrollRunID *uint       `gorm:"index" json:"payroll_run_id"`
+	PayrollRun   *PayrollRun `gorm:"foreignKey:PayrollRunID" json:"payroll_run,omitempty"`
+
+	// Type separate the monthly salary from THR & final settlement, an employee has at most one payroll of each type per period
+	Type constants.PayrollType `gorm:"type:varchar(20);default:'REGULAR'" json:"type"`
+
+	// LoanID is the loan which installment deducted on this payroll, LoanDeduction is the deducted installment
+	LoanID        *uint       `json:"loan_id"`
+	LoanDeduction money.Money `gorm:"type:decimal(15,2)" json:"loan_deduction"`
+
+	PeriodDate time.Time `gorm:"type:date;not null;index" json:"period_date"`
+
+	BaseSalary     money.Money `gorm:"type:decimal(15,2)" json:"base_salary"`
+	TotalAllowance money.Money `gorm:"type:decimal(15,2)" json:"total_allowance"`
+	TotalDeduction money.Money `gorm:"type:decimal(15,2)" json:"total_deduction"`
+	NetSalary      money.Money `gorm:"type:decimal(15,2)" json:"net_salary"`
+
+	// TaxableIncome is the monthly gross used as PPh 21 base, TaxAmount is the withheld PPh 21
+	TaxableIncome money.Money `gorm:"type:decimal(15,2)" json:"taxable_income"`
+	TaxAmount     money.Money `gorm:"type:decimal(15,2)" json:"tax_amount"`
+
+	// PTKPStatus is the PTKP code the PPh 21 is calculated with, kept since the employee status can change later
+	PTKPStatus string `gorm:"type:varchar(10)" json:"ptkp_status"`
 
 	Status constants.PayrollStatus `gorm:"type:varchar(20);default:'DRAFT'" json:"status"`
 
 	Notes string `gorm:"type:text" json:"notes"`
 
 	Details []PayrollDetail `gorm:"foreignKey:PayrollID;constraint:OnDelete:CASCADE" json:"details,omitempty"`
+
+	Contributions []PayrollContribution `gorm:"foreignKey:PayrollID;constraint:OnDelete:CASCADE" json:"contributions,omitempty"`
+}
+
+// IsTHR return true when the payroll is a religious holiday allowance instead of monthly salary
+func (p *Payroll) IsTHR() bool {
+	return p.Type == constants.PayrollTypeTHR
+}
+
+// IsFinalSettlement return true when the payroll is the last payment of a leaving employee
+func (p *Payroll) IsFinalSettlement() bool {
+	return p.Type == constants.PayrollTypeFinalSettlement
+}
+
+// payslipPeriod return period part of payslip file name, e.g. Jan2026, THR-Mar2026 or FINAL-Jun2026
+func (p *Payroll) payslipPeriod() string {
+	if p.IsTHR() {
+		return "THR-" + p.PeriodDate.Format("Jan2006")
+	}
+	if p.IsFinalSettlement() {
+		return "FINAL-" + p.PeriodDate.Format("Jan2006")
+	}
+
+	return p.PeriodDate.Format("Jan2006")
+}
+
+// periodLabel return period as written on payslip & its email, e.g. "March 2026" or "THR March 2026"
+func (p *Payroll) periodLabel(layout string) string {
+	period := p.PeriodDate.Format(layout)
+	if p.IsTHR() {
+		return "THR " + period
+	}
+	if p.IsFinalSettlement() {
+		return "Final Settlement " + period
+	}
+
+	return period
+}
+
+// PayrollRun group all payrolls of a period & type & drive their approval lifecycle,
+// once a regular run approved the period is locked for any changes
+type PayrollRun struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	PeriodDate time.Time                  `gorm:"type:date;uniqueIndex:uq_payroll_runs_period_type;not null" json:"period_date"`
+	Type       constants.PayrollType      `gorm:"type:varchar(20);uniqueIndex:uq_payroll_runs_period_type;default:'REGULAR'" json:"type"`
+	Status     constants.PayrollRunStatus `gorm:"type:varchar(20);default:'DRAFT'" json:"status"`
+
+	TotalEmployee  int         `json:"total_employee"`
+	TotalNetSalary money.Money `gorm:"type:decimal(15,2)" json:"total_net_salary"`
+
+	ReviewedBy *uint      `json:"reviewed_by"`
+	ReviewedAt *time.Time `json:"reviewed_at"`
+	ApprovedBy *uint      `json:"approved_by"`
+	ApprovedAt *time.Time `json:"approved_at"`
+	PaidBy     *uint      `json:"paid_by"`
+	PaidAt     *time.Time `json:"paid_at"`
+	ClosedBy   *uint      `json:"closed_by"`
+	ClosedAt   *time.Time `json:"closed_at"`
+
+	Payrolls []Payroll `gorm:"foreignKey:PayrollRunID" json:"payrolls,omitempty"`
+}
+
+// IsLocked return true when period of this run can no longer be changed
+func (r *PayrollRun) IsLocked() bool {
+	switch r.Status {
+	case constants.PayrollRunStatusApproved, constants.PayrollRunStatusPaid, constants.PayrollRunStatusClosed:
+		return true
+	}
+
+	return false
 }
 
 type PayrollDetail struct {
@@ -38,5 +130,141 @@ type PayrollDetail struct {
 
 	Type constants.PayrollDetailType `gorm:"type:varchar(20);not null" json:"type"`
 
-	Amount float64 `gorm:"type:decimal(15,2);not null" json:"amount"`
+	Amount money.Money `gorm:"type:decimal(15,2);not null" json:"amount"`
+
+	IsTaxable bool `gorm:"default:false" json:"is_taxable"`
+}
+
+// PayrollContribution is a BPJS contribution snapshot, employee share is deducted from salary
+// while employer share is a company cost outside of net salary
+type PayrollContribution struct {
+	ID        uint `gorm:"primaryKey" json:"id"`
+	PayrollID uint `json:"payroll_id"`
+
+	Program constants.BPJSProgram `gorm:"type:varchar(20);not null" json:"program"`
+
+	BaseAmount     money.Money `gorm:"type:decimal(15,2)" json:"base_amount"`
+	EmployeeRate   float64     `gorm:"type:decimal(5,2)" json:"employee_rate"`
+	EmployerRate   float64     `gorm:"type:decimal(5,2)" json:"employer_rate"`
+	EmployeeAmount money.Money `gorm:"type:decimal(15,2)" json:"employee_amount"`
+	EmployerAmount money.Money `gorm:"type:decimal(15,2)" json:"employer_amount"`
+}
+
+// PayrollAudit record every regenerate & void of a payroll
+type PayrollAudit struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	PayrollID uint                         `gorm:"not null;index" json:"payroll_id"`
+	ActorID   uint                         `gorm:"not null" json:"actor_id"`
+	Action    constants.PayrollAuditAction `gorm:"type:varchar(20);not null" json:"action"`
+	Reason    string                       `gorm:"type:text" json:"reason"`
+
+	PreviousStatus    constants.PayrollStatus `gorm:"type:varchar(20)" json:"previous_status"`
+	PreviousNetSalary money.Money             `gorm:"type:decimal(15,2)" json:"previous_net_salary"`
+	NewNetSalary      money.Money             `gorm:"type:decimal(15,2)" json:"new_net_salary"`
+}
+
+// PayslipEmailJob is a background blast of every paid payslip of a period
+type PayslipEmailJob struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	PeriodDate time.Time                       `gorm:"type:date;not null" json:"period_date"`
+	Status     constants.PayslipEmailJobStatus `gorm:"type:varchar(20);default:'QUEUED'" json:"status"`
+
+	TotalCount  int `json:"total_count"`
+	SentCount   int `json:"sent_count"`
+	FailedCount int `json:"failed_count"`
+
+	CreatedBy  uint       `gorm:"not null" json:"created_by"`
+	FinishedAt *time.Time `json:"finished_at"`
+
+	Deliveries []PayslipEmailDelivery `gorm:"foreignKey:JobID" json:"deliveries,omitempty"`
+}
+
+// PayslipEmailDelivery is the delivery status of one employee payslip inside a job
+type PayslipEmailDelivery struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	JobID      uint   `gorm:"not null;index" json:"job_id"`
+	PayrollID  uint   `gorm:"not null" json:"payroll_id"`
+	EmployeeID uint   `gorm:"not null" json:"employee_id"`
+	Email      string `gorm:"type:varchar(255)" json:"email"`
+
+	Status       constants.PayslipEmailDeliveryStatus `gorm:"type:varchar(20);default:'PENDING'" json:"status"`
+	Attempts     int                                  `json:"attempts"`
+	ErrorMessage string                               `gorm:"type:text" json:"error_message"`
+	SentAt       *time.Time                           `json:"sent_at"`
+
+	Employee *user.Employee `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+}
+
+// JournalAccount is the chart of accounts entry used for a role on payroll journal
+type JournalAccount struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	AccountKey constants.JournalAccountKey `gorm:"type:varchar(30);uniqueIndex;not null" json:"account_key"`
+	Code       string                      `gorm:"type:varchar(30);not null" json:"code"`
+	Name       string                      `gorm:"type:varchar(150);not null" json:"name"`
+}
+
+// JournalAccountMapping route payroll detail lines of a type into a journal account by title prefix,
+// the longest matching prefix win & an empty prefix is the fallback of the type
+type JournalAccountMapping struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	DetailType  constants.PayrollDetailType `gorm:"type:varchar(20);not null" json:"detail_type"`
+	TitlePrefix string                      `gorm:"type:varchar(150);default:''" json:"title_prefix"`
+	AccountKey  constants.JournalAccountKey `gorm:"type:varchar(30);not null" json:"account_key"`
+}
+
+// SeveranceRule is the multiplier of severance pay & service pay of a termination reason
+type SeveranceRule struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Reason constants.TerminationReason `gorm:"type:varchar(30);uniqueIndex;not null" json:"reason"`
+
+	// multiplier of the statutory month count, e.g. 0.5 or 2
+	SeveranceMultiplier  float64 `gorm:"type:decimal(4,2);default:0" json:"severance_multiplier"`
+	ServicePayMultiplier float64 `gorm:"type:decimal(4,2);default:0" json:"service_pay_multiplier"`
+}
+
+// FinalSettlement record the termination of an employee & the payroll that settle it
+type FinalSettlement struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	PayrollID uint     `gorm:"not null;uniqueIndex" json:"payroll_id"`
+	Payroll   *Payroll `gorm:"foreignKey:PayrollID" json:"payroll,omitempty"`
+
+	EmployeeID uint           `gorm:"not null;index" json:"employee_id"`
+	Employee   *user.Employee `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+
+	EndDate time.Time                   `gorm:"type:date;not null" json:"end_date"`
+	Reason  constants.TerminationReason `gorm:"type:varchar(30);not null" json:"reason"`
+
+	TenureMonths    int `json:"tenure_months"`
+	UnusedLeaveDays int `json:"unused_leave_days"`
+
+	OutstandingLoan money.Money `gorm:"type:decimal(15,2)" json:"outstanding_loan"`
+	SeverancePay    money.Money `gorm:"type:decimal(15,2)" json:"severance_pay"`
+	ServicePay      money.Money `gorm:"type:decimal(15,2)" json:"service_pay"`
+
+	// PreviousEndDate & PreviousUserActive is the employee state before the settlement, restored when its payroll is void
+	PreviousEndDate    *time.Time `gorm:"type:date" json:"previous_end_date"`
+	PreviousUserActive bool       `json:"previous_user_active"`
+
+	Notes     string `gorm:"type:text" json:"notes"`
+	CreatedBy uint   `gorm:"not null" json:"created_by"`
 }
@@ -2,22 +2,28 @@ package payroll
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"basekarya-backend/pkg/constants"
 	"basekarya-backend/pkg/logger"
 	"basekarya-backend/pkg/response"
+	"basekarya-backend/pkg/utils"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 type Handler struct {
-	service Service
+	service     Service
+	emailWorker PayslipEmailWorker
 }
 
-func NewHandler(service Service) *Handler {
-	return &Handler{service}
+func NewHandler(service Service, emailWorker PayslipEmailWorker) *Handler {
+	return &Handler{service, emailWorker}
 }
 
 func (h *Handler) Generate(ctx echo.Context) error {
@@ -36,6 +42,46 @@ func (h *Handler) Generate(ctx echo.Context) error {
 	return response.NewResponses[any](ctx, http.StatusOK, "Generate All Payroll Employees Successfully", resp, nil, nil)
 }
 
+func (h *Handler) GenerateTHR(ctx echo.Context) error {
+	var req GenerateTHRRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	resp, err := h.service.GenerateTHR(ctx.Request().Context(), &req)
+	if err != nil {
+		logger.Errorw("Generate THR failed: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Generate THR Successfully", resp, nil, nil)
+}
+
+func (h *Handler) Simulate(ctx echo.Context) error {
+	var req SimulateRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	resp, err := h.service.Simulate(ctx.Request().Context(), &req)
+	if err != nil {
+		logger.Errorw("Simulate payroll failed: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Simulate Payroll Successfully", resp, nil, nil)
+}
+
 func (h *Handler) GetList(ctx echo.Context) error {
 	filter := h.parseFilter(ctx)
 
@@ -78,7 +124,80 @@ func (h *Handler) DownloadPayslipPDF(ctx echo.Context) error {
 		return response.NewResponses[any](ctx, http.StatusInternalServerError, "Failed to fetch payroll detail", nil, err, nil)
 	}
 
-	filename := fmt.Sprintf("Payslip-%s-%s.pdf", data.Employee.NIK, data.PeriodDate.Format("Jan2006"))
+	filename := fmt.Sprintf("Payslip-%s-%s.pdf", data.Employee.NIK, data.payslipPeriod())
+	ctx.Response().Header().Set("Content-Type", "application/pdf")
+	ctx.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+
+	_, err = pdf.WriteTo(ctx.Response().Writer)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (h *Handler) GetMyList(ctx echo.Context) error {
+	userContext, err := utils.GetUserContext(ctx)
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	if userContext.EmployeeID == nil {
+		return response.NewResponses[any](ctx, http.StatusForbidden, "Employee data not found", nil, nil, nil)
+	}
+
+	page, _ := strconv.Atoi(ctx.QueryParam("page"))
+	limit, _ := strconv.Atoi(ctx.QueryParam("limit"))
+	year, _ := strconv.Atoi(ctx.QueryParam("year"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	filter := PayrollFilter{
+		Page:  page,
+		Limit: limit,
+		Year:  year,
+	}
+
+	data, meta, err := h.service.GetMyList(ctx.Request().Context(), *userContext.EmployeeID, &filter)
+	if err != nil {
+		logger.Errorw("Failed to fetch my payroll list: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, "Failed to fetch payroll list", nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Fetch My Payroll List Success", data, nil, meta)
+}
+
+func (h *Handler) DownloadMyPayslipPDF(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "invalid id", nil, err, nil)
+	}
+
+	userContext, err := utils.GetUserContext(ctx)
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	if userContext.EmployeeID == nil {
+		return response.NewResponses[any](ctx, http.StatusForbidden, "Employee data not found", nil, nil, nil)
+	}
+
+	pdf, data, err := h.service.GenerateMyPayslipPDF(ctx.Request().Context(), uint(id), *userContext.EmployeeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.NewResponses[any](ctx, http.StatusNotFound, "Payslip not found", nil, err, nil)
+		}
+
+		logger.Errorw("Failed to generate my payslip: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, "Failed to generate payslip", nil, err, nil)
+	}
+
+	filename := fmt.Sprintf("Payslip-%s-%s.pdf", data.Employee.NIK, data.payslipPeriod())
 	ctx.Response().Header().Set("Content-Type", "application/pdf")
 	ctx.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
 
@@ -124,6 +243,534 @@ func (h *Handler) BlastPayslipEmail(ctx echo.Context) error {
 	return response.NewResponses[any](ctx, http.StatusOK, "Blast Payslip Email Success", nil, nil, nil)
 }
 
+func (h *Handler) SendAllPayslipEmail(ctx echo.Context) error {
+	userContext, err := utils.GetUserContext(ctx)
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	var req SendPayslipEmailRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	req.ActorID = userContext.UserID
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	job, err := h.service.CreatePayslipEmailJob(ctx.Request().Context(), &req)
+	if err != nil {
+		logger.Errorw("Failed to create payslip email job: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	h.emailWorker.Enqueue(job)
+
+	return response.NewResponses[any](ctx, http.StatusAccepted, "Payslip Email Job Queued", toPayslipEmailJobResponse(job), nil, nil)
+}
+
+func (h *Handler) GetPayslipEmailJobs(ctx echo.Context) error {
+	filter := h.parseFilter(ctx)
+
+	data, err := h.service.GetPayslipEmailJobs(ctx.Request().Context(), filter.Month, filter.Year)
+	if err != nil {
+		logger.Errorw("Failed to fetch payslip email jobs: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, "Failed to fetch payslip email jobs", nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Fetch Payslip Email Jobs Success", data, nil, nil)
+}
+
+func (h *Handler) GetPayslipEmailJobDetail(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "invalid id", nil, err, nil)
+	}
+
+	data, err := h.service.GetPayslipEmailJobDetail(ctx.Request().Context(), uint(id))
+	if err != nil {
+		logger.Errorw("Failed to fetch payslip email job detail: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, "Failed to fetch payslip email job detail", nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Fetch Payslip Email Job Detail Success", data, nil, nil)
+}
+
+func (h *Handler) RetryPayslipEmailJob(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "invalid id", nil, err, nil)
+	}
+
+	job, err := h.service.RetryPayslipEmailJob(ctx.Request().Context(), uint(id))
+	if err != nil {
+		logger.Errorw("Failed to retry payslip email job: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	h.emailWorker.Enqueue(job)
+
+	return response.NewResponses[any](ctx, http.StatusAccepted, "Payslip Email Job Queued", toPayslipEmailJobResponse(job), nil, nil)
+}
+
+func (h *Handler) ExportBPJSRecap(ctx echo.Context) error {
+	filter := h.parseFilter(ctx)
+
+	excelFile, err := h.service.ExportBPJSRecap(ctx.Request().Context(), filter.Month, filter.Year)
+	if err != nil {
+		logger.Errorw("Failed to export bpjs recap: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, "Failed to export bpjs recap", nil, err, nil)
+	}
+
+	filename := fmt.Sprintf("BPJS-Recap-%d-%02d.xlsx", filter.Year, filter.Month)
+	ctx.Response().Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	ctx.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	return ctx.Blob(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", excelFile)
+}
+
+func (h *Handler) DownloadTaxForm(ctx echo.Context) error {
+	employeeID, err := strconv.Atoi(ctx.Param("employeeId"))
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "invalid employee id", nil, err, nil)
+	}
+
+	return h.writeTaxFormPDF(ctx, uint(employeeID))
+}
+
+func (h *Handler) DownloadMyTaxForm(ctx echo.Context) error {
+	userContext, err := utils.GetUserContext(ctx)
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	if userContext.EmployeeID == nil {
+		return response.NewResponses[any](ctx, http.StatusForbidden, "Employee data not found", nil, nil, nil)
+	}
+
+	return h.writeTaxFormPDF(ctx, *userContext.EmployeeID)
+}
+
+func (h *Handler) writeTaxFormPDF(ctx echo.Context, employeeID uint) error {
+	filter := h.parseFilter(ctx)
+
+	pdfBytes, form, err := h.service.GenerateTaxFormPDF(ctx.Request().Context(), employeeID, filter.Year)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.NewResponses[any](ctx, http.StatusNotFound, "No payroll found on this year", nil, err, nil)
+		}
+
+		logger.Errorw("Failed to generate 1721-A1: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, "Failed to generate 1721-A1", nil, err, nil)
+	}
+
+	ctx.Response().Header().Set("Content-Type", "application/pdf")
+	ctx.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", form.FileName()))
+	return ctx.Blob(http.StatusOK, "application/pdf", pdfBytes)
+}
+
+func (h *Handler) ExportTaxForms(ctx echo.Context) error {
+	filter := h.parseFilter(ctx)
+
+	zipFile, err := h.service.ExportTaxForms(ctx.Request().Context(), filter.Year)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.NewResponses[any](ctx, http.StatusNotFound, "No payroll found on this year", nil, err, nil)
+		}
+
+		logger.Errorw("Failed to export 1721-A1: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	filename := fmt.Sprintf("1721-A1-%d.zip", filter.Year)
+	ctx.Response().Header().Set("Content-Type", "application/zip")
+	ctx.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	return ctx.Blob(http.StatusOK, "application/zip", zipFile)
+}
+
+func (h *Handler) SendTaxFormEmails(ctx echo.Context) error {
+	var req SendTaxFormEmailRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	resp, err := h.service.SendTaxFormEmails(ctx.Request().Context(), &req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.NewResponses[any](ctx, http.StatusNotFound, "No payroll found on this year", nil, err, nil)
+		}
+
+		logger.Errorw("Failed to send 1721-A1 emails: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Send 1721-A1 Emails Success", resp, nil, nil)
+}
+
+func (h *Handler) ExportBankTransfer(ctx echo.Context) error {
+	filter := h.parseFilter(ctx)
+
+	payrollType := constants.PayrollTypeRegular
+	if filter.Type != "" {
+		payrollType = constants.PayrollType(filter.Type)
+	}
+
+	switch payrollType {
+	case constants.PayrollTypeRegular, constants.PayrollTypeTHR, constants.PayrollTypeFinalSettlement:
+	default:
+		err := fmt.Errorf("invalid payroll type: %s", payrollType)
+		return response.NewResponses[any](ctx, http.StatusBadRequest, err.Error(), nil, err, nil)
+	}
+
+	file, bankCode, err := h.service.ExportBankTransfer(ctx.Request().Context(), filter.Month, filter.Year, ctx.QueryParam("bank"), payrollType)
+	if err != nil {
+		logger.Errorw("Failed to export bank transfer: %w", err)
+
+		var validationErr *BankTransferValidationError
+		if errors.As(err, &validationErr) {
+			return response.NewResponses[any](ctx, http.StatusUnprocessableEntity, err.Error(), validationErr.Issues, err, nil)
+		}
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	filename := fmt.Sprintf("Bank-Transfer-%s-%d-%02d.csv", bankCode, filter.Year, filter.Month)
+	if payrollType != constants.PayrollTypeRegular {
+		filename = fmt.Sprintf("Bank-Transfer-%s-%s-%d-%02d.csv", payrollType, bankCode, filter.Year, filter.Month)
+	}
+	ctx.Response().Header().Set("Content-Type", "text/csv")
+	ctx.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	return ctx.Blob(http.StatusOK, "text/csv", file)
+}
+
+func (h *Handler) ComparePeriods(ctx echo.Context) error {
+	req, err := h.parseComparisonRequest(ctx)
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, err.Error(), nil, err, nil)
+	}
+
+	resp, err := h.service.ComparePeriods(ctx.Request().Context(), req)
+	if err != nil {
+		logger.Errorw("Failed to compare payroll periods: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Compare Payroll Periods Success", resp, nil, nil)
+}
+
+func (h *Handler) ExportComparison(ctx echo.Context) error {
+	req, err := h.parseComparisonRequest(ctx)
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, err.Error(), nil, err, nil)
+	}
+
+	excelFile, err := h.service.ExportComparison(ctx.Request().Context(), req)
+	if err != nil {
+		logger.Errorw("Failed to export payroll comparison: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, "Failed to export payroll comparison", nil, err, nil)
+	}
+
+	filename := fmt.Sprintf("Payroll-Comparison-%d-%02d-vs-%d-%02d.xlsx", req.Year, req.Month, req.BaseYear, req.BaseMonth)
+	ctx.Response().Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	ctx.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	return ctx.Blob(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", excelFile)
+}
+
+// parseComparisonRequest read compared period from month & year, base period default to the month before
+func (h *Handler) parseComparisonRequest(ctx echo.Context) (*PayrollComparisonRequest, error) {
+	filter := h.parseFilter(ctx)
+	base := time.Date(filter.Year, time.Month(filter.Month), 1, 0, 0, 0, 0, time.Local).AddDate(0, -1, 0)
+
+	req := &PayrollComparisonRequest{
+		BaseMonth: int(base.Month()),
+		BaseYear:  base.Year(),
+		Month:     filter.Month,
+		Year:      filter.Year,
+		Threshold: constants.PayrollVarianceThresholdPercent,
+	}
+
+	if m := ctx.QueryParam("base_month"); m != "" {
+		fmt.Sscanf(m, "%d", &req.BaseMonth)
+	}
+	if y := ctx.QueryParam("base_year"); y != "" {
+		fmt.Sscanf(y, "%d", &req.BaseYear)
+	}
+	if t := ctx.QueryParam("threshold"); t != "" {
+		threshold, err := strconv.ParseFloat(t, 64)
+		if err != nil || threshold < 0 {
+			return nil, errors.New("threshold must be a positive number")
+		}
+		req.Threshold = threshold
+	}
+
+	if req.Month < 1 || req.Month > 12 || req.BaseMonth < 1 || req.BaseMonth > 12 {
+		return nil, errors.New("month must be between 1 and 12")
+	}
+
+	if req.Month == req.BaseMonth && req.Year == req.BaseYear {
+		return nil, errors.New("base period must be different from compared period")
+	}
+
+	return req, nil
+}
+
+func (h *Handler) ExportJournal(ctx echo.Context) error {
+	filter := h.parseFilter(ctx)
+
+	format := constants.JournalExportFormat(strings.ToLower(ctx.QueryParam("format")))
+	if format == "" {
+		format = constants.JournalExportFormatCSV
+	}
+	if format != constants.JournalExportFormatCSV && format != constants.JournalExportFormatJSON {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "format must be csv or json", nil, nil, nil)
+	}
+
+	file, err := h.service.ExportJournal(ctx.Request().Context(), filter.Month, filter.Year, format)
+	if err != nil {
+		logger.Errorw("Failed to export payroll journal: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	contentType := "text/csv"
+	if format == constants.JournalExportFormatJSON {
+		contentType = "application/json"
+	}
+
+	filename := fmt.Sprintf("Payroll-Journal-%d-%02d.%s", filter.Year, filter.Month, format)
+	ctx.Response().Header().Set("Content-Type", contentType)
+	ctx.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	return ctx.Blob(http.StatusOK, contentType, file)
+}
+
+func (h *Handler) GetJournalSetting(ctx echo.Context) error {
+	resp, err := h.service.GetJournalSetting(ctx.Request().Context())
+	if err != nil {
+		logger.Errorw("Failed to fetch journal setting: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Fetch Journal Setting Success", resp, nil, nil)
+}
+
+func (h *Handler) UpdateJournalAccount(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "invalid id", nil, err, nil)
+	}
+
+	var req UpdateJournalAccountRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	req.ID = uint(id)
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := h.service.UpdateJournalAccount(ctx.Request().Context(), &req); err != nil {
+		logger.Errorw("Failed to update journal account: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Journal account updated successfully", nil, nil, nil)
+}
+
+func (h *Handler) ReplaceJournalMappings(ctx echo.Context) error {
+	var req ReplaceJournalMappingsRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := h.service.ReplaceJournalMappings(ctx.Request().Context(), &req); err != nil {
+		logger.Errorw("Failed to update journal mappings: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Journal mappings updated successfully", nil, nil, nil)
+}
+
+func (h *Handler) GetRuns(ctx echo.Context) error {
+	page, _ := strconv.Atoi(ctx.QueryParam("page"))
+	limit, _ := strconv.Atoi(ctx.QueryParam("limit"))
+	year, _ := strconv.Atoi(ctx.QueryParam("year"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	filter := PayrollRunFilter{
+		Page:  page,
+		Limit: limit,
+		Year:  year,
+	}
+
+	data, meta, err := h.service.GetRuns(ctx.Request().Context(), &filter)
+	if err != nil {
+		logger.Errorw("Failed to fetch payroll run list: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, "Failed to fetch payroll run list", nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Fetch Payroll Run List Success", data, nil, meta)
+}
+
+func (h *Handler) GetRunDetail(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "invalid id", nil, err, nil)
+	}
+
+	data, err := h.service.GetRunDetail(ctx.Request().Context(), uint(id))
+	if err != nil {
+		logger.Errorw("Failed to fetch detail payroll run: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, "Failed to fetch payroll run detail", nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Fetch Payroll Run Detail Success", data, nil, nil)
+}
+
+func (h *Handler) ProcessRunAction(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "invalid id", nil, err, nil)
+	}
+
+	userContext, err := utils.GetUserContext(ctx)
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	var req PayrollRunActionRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	req.ID = uint(id)
+	req.SuperAdminID = userContext.UserID
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	err = h.service.ProcessRunAction(ctx.Request().Context(), &req)
+	if err != nil {
+		logger.Errorw("Process payroll run action failed: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Process Payroll Run Action Success", nil, nil, nil)
+}
+
+func (h *Handler) Regenerate(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "invalid id", nil, err, nil)
+	}
+
+	userContext, err := utils.GetUserContext(ctx)
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	err = h.service.Regenerate(ctx.Request().Context(), uint(id), userContext.UserID)
+	if err != nil {
+		logger.Errorw("Failed to regenerate payroll: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Regenerate Payroll Success", nil, nil, nil)
+}
+
+func (h *Handler) RegeneratePeriod(ctx echo.Context) error {
+	userContext, err := utils.GetUserContext(ctx)
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	var req RegenerateRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	req.ActorID = userContext.UserID
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	resp, err := h.service.RegeneratePeriod(ctx.Request().Context(), &req)
+	if err != nil {
+		logger.Errorw("Failed to regenerate payroll period: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Regenerate Payroll Period Success", resp, nil, nil)
+}
+
+func (h *Handler) Void(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "invalid id", nil, err, nil)
+	}
+
+	userContext, err := utils.GetUserContext(ctx)
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	var req VoidRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	req.ID = uint(id)
+	req.ActorID = userContext.UserID
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	err = h.service.Void(ctx.Request().Context(), &req)
+	if err != nil {
+		logger.Errorw("Failed to void payroll: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Void Payroll Success", nil, nil, nil)
+}
+
 func (h *Handler) parseFilter(ctx echo.Context) *PayrollFilter {
 	month := int(time.Now().Month())
 	year := time.Now().Year()
@@ -153,5 +800,93 @@ func (h *Handler) parseFilter(ctx echo.Context) *PayrollFilter {
 		Month:   month,
 		Year:    year,
 		Keyword: search,
+		Type:    ctx.QueryParam("type"),
+	}
+}
+
+func (h *Handler) CreateFinalSettlement(ctx echo.Context) error {
+	userContext, err := utils.GetUserContext(ctx)
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	var req FinalSettlementRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
 	}
+
+	req.ActorID = userContext.UserID
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	resp, err := h.service.CreateFinalSettlement(ctx.Request().Context(), &req)
+	if err != nil {
+		logger.Errorw("Failed to create final settlement: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusCreated, "Final Settlement Created Successfully", resp, nil, nil)
+}
+
+func (h *Handler) DownloadExitDocument(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "invalid id", nil, err, nil)
+	}
+
+	pdfBytes, settlement, err := h.service.GenerateExitDocumentPDF(ctx.Request().Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.NewResponses[any](ctx, http.StatusNotFound, "Final settlement not found", nil, err, nil)
+		}
+
+		logger.Errorw("Failed to generate exit document: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	filename := fmt.Sprintf("Exit-Document-%s-%s.pdf", settlement.Employee.NIK, settlement.EndDate.Format("20060102"))
+	ctx.Response().Header().Set("Content-Type", "application/pdf")
+	ctx.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	return ctx.Blob(http.StatusOK, "application/pdf", pdfBytes)
+}
+
+func (h *Handler) GetSeveranceRules(ctx echo.Context) error {
+	resp, err := h.service.GetSeveranceRules(ctx.Request().Context())
+	if err != nil {
+		logger.Errorw("Failed to get severance rules: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Get Severance Rules Success", resp, nil, nil)
+}
+
+func (h *Handler) UpdateSeveranceRule(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "invalid id", nil, err, nil)
+	}
+
+	var req UpdateSeveranceRuleRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	req.ID = uint(id)
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := h.service.UpdateSeveranceRule(ctx.Request().Context(), &req); err != nil {
+		logger.Errorw("Failed to update severance rule: %w", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Severance rule updated successfully", nil, nil, nil)
 }
@@ -0,0 +1,192 @@
+package payroll
+
+import (
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Journal is a balanced double-entry journal of a payroll period, also the generic JSON export format
+type Journal struct {
+	Date        string        `json:"date"`
+	Reference   string        `json:"reference"`
+	Description string        `json:"description"`
+	Currency    string        `json:"currency"`
+	Lines       []JournalLine `json:"lines"`
+	TotalDebit  money.Money   `json:"total_debit"`
+	TotalCredit money.Money   `json:"total_credit"`
+}
+
+type JournalLine struct {
+	AccountCode string      `json:"account_code"`
+	AccountName string      `json:"account_name"`
+	Description string      `json:"description"`
+	Debit       money.Money `json:"debit"`
+	Credit      money.Money `json:"credit"`
+}
+
+// journalBuilder accumulate debit & credit of every payroll per journal account
+type journalBuilder struct {
+	accounts map[constants.JournalAccountKey]JournalAccount
+	mappings []JournalAccountMapping
+	debit    map[constants.JournalAccountKey]money.Money
+	credit   map[constants.JournalAccountKey]money.Money
+}
+
+func newJournalBuilder(accounts []JournalAccount, mappings []JournalAccountMapping) *journalBuilder {
+	b := &journalBuilder{
+		accounts: make(map[constants.JournalAccountKey]JournalAccount),
+		mappings: mappings,
+		debit:    make(map[constants.JournalAccountKey]money.Money),
+		credit:   make(map[constants.JournalAccountKey]money.Money),
+	}
+
+	for _, a := range accounts {
+		b.accounts[a.AccountKey] = a
+	}
+
+	return b
+}
+
+// resolveAccount return the account of a detail line, picking the longest title prefix that match
+func (b *journalBuilder) resolveAccount(detail PayrollDetail) (constants.JournalAccountKey, error) {
+	var key constants.JournalAccountKey
+	matchedLength := -1
+	title := strings.ToLower(detail.Title)
+
+	for _, m := range b.mappings {
+		if m.DetailType != detail.Type {
+			continue
+		}
+
+		prefix := strings.ToLower(strings.TrimSpace(m.TitlePrefix))
+		if strings.HasPrefix(title, prefix) && len(prefix) > matchedLength {
+			key = m.AccountKey
+			matchedLength = len(prefix)
+		}
+	}
+
+	if matchedLength < 0 {
+		return "", fmt.Errorf("no journal account mapped for %s %q", strings.ToLower(string(detail.Type)), detail.Title)
+	}
+
+	return key, nil
+}
+
+// addPayroll post allowance as debit, deduction & net salary as credit,
+// employer share of BPJS is posted as expense against BPJS payable
+func (b *journalBuilder) addPayroll(p *Payroll) error {
+	for _, d := range p.Details {
+		key, err := b.resolveAccount(d)
+		if err != nil {
+			return err
+		}
+
+		if d.Type == constants.DetailTypeAllowance {
+			b.debit[key] += d.Amount
+		} else {
+			b.credit[key] += d.Amount
+		}
+	}
+
+	b.credit[constants.JournalAccountSalaryPayable] += p.NetSalary
+
+	for _, c := range p.Contributions {
+		b.debit[constants.JournalAccountBPJSExpense] += c.EmployerAmount
+		b.credit[constants.JournalAccountBPJSPayable] += c.EmployerAmount
+	}
+
+	return nil
+}
+
+// build net out debit & credit of each account into one line, debit lines come first
+func (b *journalBuilder) build(periodDate time.Time) (*Journal, error) {
+	journal := &Journal{
+		// journal is posted on the last day of the period
+		Date:        periodDate.AddDate(0, 1, -1).Format(time.DateOnly),
+		Reference:   fmt.Sprintf("PAYROLL/%s", periodDate.Format("2006/01")),
+		Description: fmt.Sprintf("Gaji %s", periodDate.Format("January 2006")),
+		Currency:    "IDR",
+	}
+
+	keys := make(map[constants.JournalAccountKey]bool)
+	for key := range b.debit {
+		keys[key] = true
+	}
+	for key := range b.credit {
+		keys[key] = true
+	}
+
+	for key := range keys {
+		balance := b.debit[key] - b.credit[key]
+		if balance == 0 {
+			continue
+		}
+
+		account, ok := b.accounts[key]
+		if !ok {
+			return nil, fmt.Errorf("journal account %s is not configured", key)
+		}
+
+		line := JournalLine{
+			AccountCode: account.Code,
+			AccountName: account.Name,
+			Description: journal.Description,
+		}
+		if balance > 0 {
+			line.Debit = balance
+		} else {
+			line.Credit = -balance
+		}
+
+		journal.Lines = append(journal.Lines, line)
+		journal.TotalDebit += line.Debit
+		journal.TotalCredit += line.Credit
+	}
+
+	if len(journal.Lines) == 0 {
+		return nil, errors.New("no payroll to journal on this period")
+	}
+
+	if journal.TotalDebit != journal.TotalCredit {
+		return nil, fmt.Errorf("journal is not balanced, debit %s credit %s", journal.TotalDebit, journal.TotalCredit)
+	}
+
+	sort.Slice(journal.Lines, func(i, j int) bool {
+		left, right := journal.Lines[i], journal.Lines[j]
+		if (left.Debit > 0) != (right.Debit > 0) {
+			return left.Debit > 0
+		}
+
+		return left.AccountCode < right.AccountCode
+	})
+
+	return journal, nil
+}
+
+// CSV write one row per journal line, amounts in plain decimal so it import cleanly
+func (j *Journal) CSV() ([]byte, error) {
+	records := [][]string{{"Date", "Reference", "Account Code", "Account Name", "Description", "Debit", "Credit"}}
+	for _, line := range j.Lines {
+		records = append(records, []string{
+			j.Date,
+			j.Reference,
+			line.AccountCode,
+			line.AccountName,
+			line.Description,
+			line.Debit.String(),
+			line.Credit.String(),
+		})
+	}
+
+	return writeCSV(records)
+}
+
+func (j *Journal) JSON() ([]byte, error) {
+	return json.MarshalIndent(j, "", "  ")
+}
@@ -0,0 +1,112 @@
+package payroll
+
+import (
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"testing"
+	"time"
+)
+
+func testJournalBuilder() *journalBuilder {
+	keys := []constants.JournalAccountKey{
+		constants.JournalAccountSalaryExpense,
+		constants.JournalAccountAllowanceExpense,
+		constants.JournalAccountBPJSExpense,
+		constants.JournalAccountTaxPayable,
+		constants.JournalAccountBPJSPayable,
+		constants.JournalAccountLoanReceivable,
+		constants.JournalAccountSalaryPayable,
+	}
+
+	var accounts []JournalAccount
+	for _, key := range keys {
+		accounts = append(accounts, JournalAccount{AccountKey: key, Code: string(key), Name: string(key)})
+	}
+
+	mappings := []JournalAccountMapping{
+		{DetailType: constants.DetailTypeAllowance, AccountKey: constants.JournalAccountAllowanceExpense},
+		{DetailType: constants.DetailTypeAllowance, TitlePrefix: "Base Salary", AccountKey: constants.JournalAccountSalaryExpense},
+		{DetailType: constants.DetailTypeDeduction, AccountKey: constants.JournalAccountSalaryExpense},
+		{DetailType: constants.DetailTypeDeduction, TitlePrefix: "PPh 21", AccountKey: constants.JournalAccountTaxPayable},
+		{DetailType: constants.DetailTypeDeduction, TitlePrefix: "BPJS", AccountKey: constants.JournalAccountBPJSPayable},
+		{DetailType: constants.DetailTypeDeduction, TitlePrefix: "Potongan Kasbon", AccountKey: constants.JournalAccountLoanReceivable},
+	}
+
+	return newJournalBuilder(accounts, mappings)
+}
+
+func TestJournalBuilder_ResolveAccount(t *testing.T) {
+	b := testJournalBuilder()
+
+	tests := []struct {
+		detail   PayrollDetail
+		expected constants.JournalAccountKey
+	}{
+		{PayrollDetail{Title: "Base Salary Prorata (11/22 hari x Rp 10.000.000)", Type: constants.DetailTypeAllowance}, constants.JournalAccountSalaryExpense},
+		{PayrollDetail{Title: "Tunjangan Transport", Type: constants.DetailTypeAllowance}, constants.JournalAccountAllowanceExpense},
+		{PayrollDetail{Title: "pph 21 (TER A 2%)", Type: constants.DetailTypeDeduction}, constants.JournalAccountTaxPayable},
+		{PayrollDetail{Title: "Potongan Terlambat (10 menit)", Type: constants.DetailTypeDeduction}, constants.JournalAccountSalaryExpense},
+	}
+
+	for _, tt := range tests {
+		key, err := b.resolveAccount(tt.detail)
+		if err != nil || key != tt.expected {
+			t.Errorf("%s: expected %s, got %s (%v)", tt.detail.Title, tt.expected, key, err)
+		}
+	}
+}
+
+func TestJournalBuilder_Build(t *testing.T) {
+	b := testJournalBuilder()
+
+	payroll := Payroll{
+		Details: []PayrollDetail{
+			{Title: "Base Salary", Type: constants.DetailTypeAllowance, Amount: money.New(10_000_000)},
+			{Title: "PPh 21 (TER A 2%)", Type: constants.DetailTypeDeduction, Amount: money.New(200_000)},
+			{Title: "BPJS Kesehatan (1%)", Type: constants.DetailTypeDeduction, Amount: money.New(100_000)},
+			{Title: "Potongan Kasbon", Type: constants.DetailTypeDeduction, Amount: money.New(500_000)},
+			{Title: "Potongan Terlambat (50 menit)", Type: constants.DetailTypeDeduction, Amount: money.New(50_000)},
+		},
+		Contributions: []PayrollContribution{
+			{Program: constants.BPJSProgramKesehatan, EmployerAmount: money.New(400_000)},
+		},
+		NetSalary: money.New(9_150_000),
+	}
+
+	if err := b.addPayroll(&payroll); err != nil {
+		t.Fatal(err)
+	}
+
+	journal, err := b.build(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if journal.Date != "2025-01-31" {
+		t.Errorf("expected journal date 2025-01-31, got %s", journal.Date)
+	}
+
+	if journal.TotalDebit != money.New(10_350_000) || journal.TotalCredit != money.New(10_350_000) {
+		t.Errorf("expected balanced 10350000, got debit %s credit %s", journal.TotalDebit, journal.TotalCredit)
+	}
+
+	expected := map[string][2]money.Money{
+		"SALARY_EXPENSE":  {money.New(9_950_000), 0},
+		"BPJS_EXPENSE":    {money.New(400_000), 0},
+		"TAX_PAYABLE":     {0, money.New(200_000)},
+		"BPJS_PAYABLE":    {0, money.New(500_000)},
+		"LOAN_RECEIVABLE": {0, money.New(500_000)},
+		"SALARY_PAYABLE":  {0, money.New(9_150_000)},
+	}
+
+	if len(journal.Lines) != len(expected) {
+		t.Fatalf("expected %d lines, got %d", len(expected), len(journal.Lines))
+	}
+
+	for _, line := range journal.Lines {
+		amounts := expected[line.AccountCode]
+		if line.Debit != amounts[0] || line.Credit != amounts[1] {
+			t.Errorf("%s: expected debit %s credit %s, got debit %s credit %s", line.AccountCode, amounts[0], amounts[1], line.Debit, line.Credit)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package payroll
+
+import (
+	"basekarya-backend/internal/modules/overtime"
+	"basekarya-backend/pkg/money"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type overtimeDayType string
+
+const (
+	overtimeWorkday overtimeDayType = "WORKDAY"
+	overtimeRestDay overtimeDayType = "REST_DAY"
+	overtimeHoliday overtimeDayType = "HOLIDAY"
+)
+
+// hourly wage is 1/173 of monthly wage, PP 35/2021
+const overtimeHoursPerMonth = 173
+
+// overtimeTier pay Hours of overtime with the multiplier, zero hours means the rest of the day.
+// multiplier is kept in half so 1.5x stay an integer
+type overtimeTier struct {
+	Hours          int
+	HalfMultiplier int64
+}
+
+var (
+	// workday: 1.5x first hour, 2x for the next hours
+	workdayOvertimeTiers = []overtimeTier{{1, 3}, {0, 4}}
+
+	// rest day & public holiday: 2x for normal working hours, 3x for the next hour, 4x after
+	fiveDayRestOvertimeTiers = []overtimeTier{{8, 4}, {1, 6}, {0, 8}}
+	sixDayRestOvertimeTiers  = []overtimeTier{{7, 4}, {1, 6}, {0, 8}}
+
+	// 6 day work week with public holiday on the shortest working day (saturday)
+	sixDayShortHolidayOvertimeTiers = []overtimeTier{{5, 4}, {1, 6}, {0, 8}}
+)
+
+// overtimeDay is total approved overtime of an employee on a single date
+type overtimeDay struct {
+	Date        time.Time
+	Minutes     int
+	DayType     overtimeDayType
+	HolidayName string
+	Amount      money.Money
+}
+
+// Label return payslip line of the day, e.g. "Uang Lembur (05 Jan, hari kerja, 2 jam 30 menit)"
+func (d overtimeDay) Label() string {
+	dayLabel := "hari kerja"
+	switch d.DayType {
+	case overtimeRestDay:
+		dayLabel = "hari istirahat"
+	case overtimeHoliday:
+		dayLabel = "libur " + d.HolidayName
+	}
+
+	return fmt.Sprintf("Uang Lembur (%s, %s, %d jam %d menit)", d.Date.Format("02 Jan"), dayLabel, d.Minutes/60, d.Minutes%60)
+}
+
+// overtimeDayType return the day type of a date from holiday calendar & company work week
+func (in *calculationInput) overtimeDayType(date time.Time) overtimeDayType {
+	if _, ok := in.Holidays[date.Format("2006-01-02")]; ok {
+		return overtimeHoliday
+	}
+
+	if date.Weekday() == time.Sunday || (date.Weekday() == time.Saturday && in.WorkDaysPerWeek != 6) {
+		return overtimeRestDay
+	}
+
+	return overtimeWorkday
+}
+
+func (in *calculationInput) overtimeTiers(day overtimeDay) []overtimeTier {
+	if day.DayType == overtimeWorkday {
+		return workdayOvertimeTiers
+	}
+
+	if in.WorkDaysPerWeek != 6 {
+		return fiveDayRestOvertimeTiers
+	}
+
+	if day.DayType == overtimeHoliday && day.Date.Weekday() == time.Saturday {
+		return sixDayShortHolidayOvertimeTiers
+	}
+
+	return sixDayRestOvertimeTiers
+}
+
+// calculateOvertimeDays group overtime records per date & calculate pay of each day, rounded to rupiah
+func calculateOvertimeDays(baseSalary money.Money, overtimes []overtime.Overtime, in *calculationInput) []overtimeDay {
+	dayMap := make(map[string]*overtimeDay)
+	for _, ot := range overtimes {
+		date, err := parseOvertimeDate(ot.Date)
+		if err != nil || ot.DurationMinutes <= 0 {
+			continue
+		}
+
+		key := date.Format("2006-01-02")
+		day, ok := dayMap[key]
+		if !ok {
+			day = &overtimeDay{
+				Date:        date,
+				DayType:     in.overtimeDayType(date),
+				HolidayName: in.Holidays[key],
+			}
+			dayMap[key] = day
+		}
+
+		day.Minutes += ot.DurationMinutes
+	}
+
+	days := make([]overtimeDay, 0, len(dayMap))
+	for _, day := range dayMap {
+		day.Amount = calculateOvertimePay(baseSalary, day.Minutes, in.overtimeTiers(*day))
+		days = append(days, *day)
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Date.Before(days[j].Date)
+	})
+
+	return days
+}
+
+// calculateOvertimePay spread overtime minutes of a day over its tiers as one exact ratio of base salary
+func calculateOvertimePay(baseSalary money.Money, minutes int, tiers []overtimeTier) money.Money {
+	if minutes <= 0 {
+		return 0
+	}
+
+	var halfMultiplierMinutes int64
+	remaining := minutes
+	for _, tier := range tiers {
+		tierMinutes := remaining
+		if tier.Hours > 0 {
+			tierMinutes = min(remaining, tier.Hours*60)
+		}
+
+		halfMultiplierMinutes += int64(tierMinutes) * tier.HalfMultiplier
+		remaining -= tierMinutes
+
+		if remaining == 0 {
+			break
+		}
+	}
+
+	return baseSalary.MulRatio(halfMultiplierMinutes, overtimeHoursPerMonth*60*2).Round()
+}
+
+// parseOvertimeDate accept date only or full timestamp as scanned from DATE column
+func parseOvertimeDate(value string) (time.Time, error) {
+	if len(value) > len(time.DateOnly) {
+		value = value[:len(time.DateOnly)]
+	}
+
+	return time.ParseInLocation(time.DateOnly, value, time.Local)
+}
@@ -0,0 +1,54 @@
+package payroll
+
+import (
+	"basekarya-backend/internal/modules/overtime"
+	"basekarya-backend/pkg/money"
+	"testing"
+)
+
+func TestCalculateOvertimeDays(t *testing.T) {
+	// hourly wage is 17.300.000 / 173 = 100.000
+	baseSalary := money.New(17_300_000)
+
+	tests := []struct {
+		name            string
+		workDaysPerWeek int
+		date            string
+		minutes         int
+		dayType         overtimeDayType
+		expected        money.Money
+	}{
+		// 1 x 1.5 + 1.5 x 2
+		{"workday", 5, "2025-01-06", 150, overtimeWorkday, money.New(450_000)},
+		// 8 x 2 + 1 x 3 + 1 x 4
+		{"saturday on 5 day week", 5, "2025-01-04", 600, overtimeRestDay, money.New(2_300_000)},
+		// 1 x 1.5 + 1 x 2
+		{"saturday on 6 day week", 6, "2025-01-04T00:00:00+07:00", 120, overtimeWorkday, money.New(350_000)},
+		// 7 x 2 + 1 x 3 + 1 x 4
+		{"sunday on 6 day week", 6, "2025-01-05", 540, overtimeRestDay, money.New(2_100_000)},
+		// 5 x 2 + 1 x 3 + 1 x 4
+		{"holiday on saturday of 6 day week", 6, "2025-01-25", 420, overtimeHoliday, money.New(1_700_000)},
+	}
+
+	for _, tt := range tests {
+		in := &calculationInput{
+			Holidays:        map[string]string{"2025-01-25": "Tahun Baru Imlek"},
+			WorkDaysPerWeek: tt.workDaysPerWeek,
+		}
+
+		// split into two records on the same day to make sure rate is applied per day
+		overtimes := []overtime.Overtime{
+			{Date: tt.date, DurationMinutes: tt.minutes / 2},
+			{Date: tt.date, DurationMinutes: tt.minutes - tt.minutes/2},
+		}
+
+		days := calculateOvertimeDays(baseSalary, overtimes, in)
+		if len(days) != 1 {
+			t.Fatalf("%s: expected 1 day, got %d", tt.name, len(days))
+		}
+
+		if days[0].DayType != tt.dayType || days[0].Amount != tt.expected {
+			t.Errorf("%s: expected %s %s, got %s %s", tt.name, tt.dayType, tt.expected, days[0].DayType, days[0].Amount)
+		}
+	}
+}
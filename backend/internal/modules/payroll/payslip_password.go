@@ -0,0 +1,71 @@
+package payroll
+
+import (
+	"basekarya-backend/internal/modules/company"
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/constants"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/signintech/gopdf"
+)
+
+// payslipPasswordDateFormat is the birth date part of payslip password, e.g. 17081990
+const payslipPasswordDateFormat = "02012006"
+
+// payslipPassword derive payslip pdf password of the employee from company rule
+func payslipPassword(emp *user.Employee, rule constants.PayslipPasswordRule) (string, error) {
+	if rule == constants.PayslipPasswordRuleNIK {
+		if emp.NIK == "" {
+			return "", errors.New("employee nik is required for payslip password")
+		}
+
+		return emp.NIK, nil
+	}
+
+	if emp.BirthDate == nil {
+		return "", errors.New("employee birth date is required for payslip password")
+	}
+
+	birthDate := emp.BirthDate.Format(payslipPasswordDateFormat)
+	if rule == constants.PayslipPasswordRuleBirthDate {
+		return birthDate, nil
+	}
+
+	return emp.NIK + birthDate, nil
+}
+
+// payslipPasswordHint explain the password rule to employee without revealing it
+func payslipPasswordHint(rule constants.PayslipPasswordRule) string {
+	switch rule {
+	case constants.PayslipPasswordRuleNIK:
+		return "NIK Anda"
+	case constants.PayslipPasswordRuleBirthDate:
+		return "tanggal lahir Anda (DDMMYYYY)"
+	default:
+		return "NIK Anda diikuti tanggal lahir (DDMMYYYY)"
+	}
+}
+
+// payslipPDFConfig build gopdf config, protected with employee password when company enable it
+func payslipPDFConfig(comp *company.Company, emp *user.Employee) (gopdf.Config, error) {
+	config := gopdf.Config{PageSize: *gopdf.PageSizeA4}
+	if !comp.PayslipPasswordEnabled {
+		return config, nil
+	}
+
+	password, err := payslipPassword(emp, comp.PayslipPasswordRule)
+	if err != nil {
+		return config, err
+	}
+
+	// owner password is random since nobody should lift the restriction
+	config.Protection = gopdf.PDFProtectionConfig{
+		UseProtection: true,
+		Permissions:   gopdf.PermissionsPrint,
+		UserPass:      []byte(password),
+		OwnerPass:     []byte(uuid.NewString()),
+	}
+
+	return config, nil
+}
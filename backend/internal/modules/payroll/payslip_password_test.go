@@ -0,0 +1,33 @@
+package payroll
+
+import (
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/constants"
+	"testing"
+	"time"
+)
+
+func TestPayslipPassword(t *testing.T) {
+	birthDate := time.Date(1990, time.August, 17, 0, 0, 0, 0, time.Local)
+	emp := user.Employee{NIK: "EMP001", BirthDate: &birthDate}
+
+	tests := map[constants.PayslipPasswordRule]string{
+		constants.PayslipPasswordRuleNIKBirthDate: "EMP00117081990",
+		constants.PayslipPasswordRuleBirthDate:    "17081990",
+		constants.PayslipPasswordRuleNIK:          "EMP001",
+	}
+
+	for rule, expected := range tests {
+		password, err := payslipPassword(&emp, rule)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", rule, err)
+		}
+		if password != expected {
+			t.Errorf("%s: expected %s, got %s", rule, expected, password)
+		}
+	}
+
+	if _, err := payslipPassword(&user.Employee{NIK: "EMP002"}, constants.PayslipPasswordRuleNIKBirthDate); err == nil {
+		t.Errorf("expected error when birth date is empty")
+	}
+}
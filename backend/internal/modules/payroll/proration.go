@@ -0,0 +1,123 @@
+package payroll
+
+import (
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"fmt"
+	"time"
+)
+
+// proration hold days worked by an employee against total days of a period
+type proration struct {
+	Basis      constants.ProrationBasis
+	WorkedDays int
+	TotalDays  int
+}
+
+// calculateProration compare employee join & end date with the period,
+// working days follow the same company work week & holiday calendar as the absence deduction
+func (in *calculationInput) calculateProration(emp *user.Employee) proration {
+	basis := in.ProrationBasis
+	periodStart := time.Date(in.PeriodDate.Year(), in.PeriodDate.Month(), 1, 0, 0, 0, 0, time.Local)
+	periodEnd := periodStart.AddDate(0, 1, -1)
+
+	start, end := periodStart, periodEnd
+	if emp.JoinDate != nil {
+		joinDate := truncateDate(*emp.JoinDate)
+		if joinDate.After(start) {
+			start = joinDate
+		}
+	}
+	if emp.EndDate != nil {
+		endDate := truncateDate(*emp.EndDate)
+		if endDate.Before(end) {
+			end = endDate
+		}
+	}
+
+	p := proration{
+		Basis:     basis,
+		TotalDays: in.countDays(periodStart, periodEnd),
+	}
+
+	if !end.Before(start) {
+		p.WorkedDays = in.countDays(start, end)
+	}
+
+	return p
+}
+
+// IsFull return true when employee worked the whole period
+func (p proration) IsFull() bool {
+	return p.WorkedDays >= p.TotalDays
+}
+
+// Apply prorate the amount, rounded to rupiah
+func (p proration) Apply(amount money.Money) money.Money {
+	if p.IsFull() {
+		return amount
+	}
+
+	if p.TotalDays == 0 {
+		return 0
+	}
+
+	return amount.MulRatio(int64(p.WorkedDays), int64(p.TotalDays)).Round()
+}
+
+// Label return readable proration formula, e.g. 12/22 hari kerja
+func (p proration) Label() string {
+	unit := "hari kerja"
+	if p.Basis == constants.ProrationBasisCalendarDays {
+		unit = "hari kalender"
+	}
+
+	return fmt.Sprintf("%d/%d %s", p.WorkedDays, p.TotalDays, unit)
+}
+
+// countDays return number of days between start & end inclusive, by the proration basis of the company
+func (in *calculationInput) countDays(start, end time.Time) int {
+	if in.ProrationBasis != constants.ProrationBasisCalendarDays {
+		return in.countWorkingDays(start, end)
+	}
+
+	days := 0
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		days++
+	}
+
+	return days
+}
+
+// countWorkingDays return number of working days between start & end inclusive from company work week, holiday excluded
+func (in *calculationInput) countWorkingDays(start, end time.Time) int {
+	days := 0
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if in.overtimeDayType(d) == overtimeWorkday {
+			days++
+		}
+	}
+
+	return days
+}
+
+func truncateDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// monthsBetween return full months from start until end, zero when start is after end
+func monthsBetween(start, end time.Time) int {
+	start = truncateDate(start)
+	end = truncateDate(end)
+	if start.After(end) {
+		return 0
+	}
+
+	months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+	if end.Day() < start.Day() {
+		months--
+	}
+
+	return months
+}
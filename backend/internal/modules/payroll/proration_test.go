@@ -0,0 +1,68 @@
+package payroll
+
+import (
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"testing"
+	"time"
+)
+
+func TestCalculateProration(t *testing.T) {
+	period := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.Local)
+	joinDate := time.Date(2026, time.October, 15, 0, 0, 0, 0, time.Local)
+	endDate := time.Date(2026, time.September, 30, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		emp      user.Employee
+		basis    constants.ProrationBasis
+		expected string
+		full     bool
+	}{
+		{"full month", user.Employee{}, constants.ProrationBasisWorkingDays, "22/22 hari kerja", true},
+		{"joined mid month working days", user.Employee{JoinDate: &joinDate}, constants.ProrationBasisWorkingDays, "12/22 hari kerja", false},
+		{"joined mid month calendar days", user.Employee{JoinDate: &joinDate}, constants.ProrationBasisCalendarDays, "17/31 hari kalender", false},
+		{"left before period", user.Employee{EndDate: &endDate}, constants.ProrationBasisWorkingDays, "0/22 hari kerja", false},
+	}
+
+	for _, tt := range tests {
+		in := &calculationInput{PeriodDate: period, ProrationBasis: tt.basis, WorkDaysPerWeek: 5}
+		p := in.calculateProration(&tt.emp)
+		if p.Label() != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.expected, p.Label())
+		}
+		if p.IsFull() != tt.full {
+			t.Errorf("%s: expected full %v, got %v", tt.name, tt.full, p.IsFull())
+		}
+	}
+}
+
+func TestProration_Apply(t *testing.T) {
+	p := proration{Basis: constants.ProrationBasisWorkingDays, WorkedDays: 11, TotalDays: 22}
+
+	if got := p.Apply(money.New(10_000_000)); got != money.New(5_000_000) {
+		t.Errorf("expected 5000000, got %s", got)
+	}
+}
+
+func TestCalculateProration_WorkWeekAndHolidays(t *testing.T) {
+	period := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.Local)
+	joinDate := time.Date(2026, time.October, 15, 0, 0, 0, 0, time.Local)
+	emp := user.Employee{JoinDate: &joinDate}
+
+	in := &calculationInput{
+		PeriodDate:      period,
+		ProrationBasis:  constants.ProrationBasisWorkingDays,
+		WorkDaysPerWeek: 6,
+		Holidays:        map[string]string{"2026-10-20": "Libur"},
+	}
+
+	p := in.calculateProration(&emp)
+	if p.Label() != "14/26 hari kerja" {
+		t.Errorf("expected 14/26 hari kerja, got %s", p.Label())
+	}
+	if p.TotalDays != in.periodWorkingDays() {
+		t.Errorf("proration total %d differ from absence working days %d", p.TotalDays, in.periodWorkingDays())
+	}
+}
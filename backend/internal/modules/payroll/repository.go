@@ -1,20 +1,60 @@
 package payroll
 
 import (
-	"context"
 	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
 	"basekarya-backend/pkg/utils"
+	"context"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
-	CreateBulk(payroll *[]Payroll) error
+	CreateBulk(ctx context.Context, payroll *[]Payroll) error
 	FindAll(filter *PayrollFilter) ([]Payroll, int64, error)
-	FindByID(id uint) (*Payroll, error)
-	GetExistingEmployeeID(month, year int) (map[uint]bool, error)
+	FindByID(ctx context.Context, id uint) (*Payroll, error)
+	FindByIDForUpdate(ctx context.Context, id uint) (*Payroll, error)
+	GetExistingEmployeeID(month, year int, payrollType constants.PayrollType) (map[uint]bool, error)
 	UpdateStatus(ctx context.Context, id uint, status constants.PayrollStatus) error
+	GetBulkTaxYearToDate(ctx context.Context, month, year int) (map[uint]taxYearToDate, error)
+	GetBulkTaxableIncome(ctx context.Context, month, year int, payrollType constants.PayrollType) (map[uint]money.Money, error)
+	FindAllWithContributions(ctx context.Context, month, year int, payrollTypes ...constants.PayrollType) ([]Payroll, error)
+	FindAllWithDetails(ctx context.Context, month, year int) ([]Payroll, error)
+	FindAllByYear(ctx context.Context, year int, employeeID uint) ([]Payroll, error)
+	CreateRun(ctx context.Context, run *PayrollRun) error
+	UpdateRun(ctx context.Context, run *PayrollRun) error
+	FindRunByID(ctx context.Context, id uint) (*PayrollRun, error)
+	FindRunByPeriod(ctx context.Context, month, year int, payrollType constants.PayrollType) (*PayrollRun, error)
+	FindAllRuns(ctx context.Context, filter *PayrollRunFilter) ([]PayrollRun, int64, error)
+	UpdateRunSummary(ctx context.Context, runID uint) error
+	IsPeriodLocked(ctx context.Context, date time.Time) (bool, error)
+	FindAllByPeriodAndStatus(ctx context.Context, month, year int, status constants.PayrollStatus) ([]Payroll, error)
+	ReplaceCalculation(ctx context.Context, payroll *Payroll) error
+	CreateAudit(ctx context.Context, audit *PayrollAudit) error
+	CreateEmailJob(ctx context.Context, job *PayslipEmailJob) error
+	UpdateEmailJob(ctx context.Context, job *PayslipEmailJob) error
+	StartEmailJob(ctx context.Context, id uint) error
+	FindEmailJobByID(ctx context.Context, id uint) (*PayslipEmailJob, error)
+	FindEmailJobsByPeriod(ctx context.Context, month, year int) ([]PayslipEmailJob, error)
+	HasActiveEmailJob(ctx context.Context, month, year int) (bool, error)
+	FindUnfinishedEmailJobs(ctx context.Context) ([]PayslipEmailJob, error)
+	RefreshEmailJobCounts(ctx context.Context, jobID uint) error
+	UpdateEmailDelivery(ctx context.Context, delivery *PayslipEmailDelivery) error
+	ResetFailedEmailDeliveries(ctx context.Context, jobID uint) error
+	FindJournalAccounts(ctx context.Context) ([]JournalAccount, error)
+	FindJournalAccountByID(ctx context.Context, id uint) (*JournalAccount, error)
+	UpdateJournalAccount(ctx context.Context, account *JournalAccount) error
+	FindJournalMappings(ctx context.Context) ([]JournalAccountMapping, error)
+	ReplaceJournalMappings(ctx context.Context, mappings []JournalAccountMapping) error
+	FindSeveranceRules(ctx context.Context) ([]SeveranceRule, error)
+	FindSeveranceRuleByID(ctx context.Context, id uint) (*SeveranceRule, error)
+	FindSeveranceRuleByReason(ctx context.Context, reason constants.TerminationReason) (*SeveranceRule, error)
+	UpdateSeveranceRule(ctx context.Context, rule *SeveranceRule) error
+	CreateFinalSettlement(ctx context.Context, settlement *FinalSettlement) error
+	FindFinalSettlementByPayrollID(ctx context.Context, payrollID uint) (*FinalSettlement, error)
+	HasActiveFinalSettlement(ctx context.Context, employeeID uint) (bool, error)
 }
 
 type repository struct {
@@ -25,8 +65,9 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db}
 }
 
-func (r *repository) CreateBulk(payroll *[]Payroll) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r *repository) CreateBulk(ctx context.Context, payroll *[]Payroll) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.CreateInBatches(payroll, 100).Error; err != nil {
 			return err
 		}
@@ -47,6 +88,22 @@ func (r *repository) FindAll(filter *PayrollFilter) ([]Payroll, int64, error) {
 		startDate := time.Date(filter.Year, time.Month(filter.Month), 1, 0, 0, 0, 0, time.Local)
 		endDate := startDate.AddDate(0, 1, -1)
 		query = query.Where("period_date BETWEEN ? AND ? ", startDate, endDate)
+	} else if filter.Year > 0 {
+		startDate := time.Date(filter.Year, time.January, 1, 0, 0, 0, 0, time.Local)
+		endDate := startDate.AddDate(1, 0, -1)
+		query = query.Where("period_date BETWEEN ? AND ? ", startDate, endDate)
+	}
+
+	if filter.EmployeeID > 0 {
+		query = query.Where("payrolls.employee_id = ?", filter.EmployeeID)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("payrolls.status = ?", filter.Status)
+	}
+
+	if filter.Type != "" {
+		query = query.Where("payrolls.type = ?", filter.Type)
 	}
 
 	if filter.Keyword != "" {
@@ -68,11 +125,24 @@ func (r *repository) FindAll(filter *PayrollFilter) ([]Payroll, int64, error) {
 	return payrolls, total, err
 }
 
-func (r *repository) FindByID(id uint) (*Payroll, error) {
+func (r *repository) FindByID(ctx context.Context, id uint) (*Payroll, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return r.findByID(db, id)
+}
+
+// FindByIDForUpdate lock the payroll row until the transaction of ctx end, so its status can be checked & changed safely
+func (r *repository) FindByIDForUpdate(ctx context.Context, id uint) (*Payroll, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return r.findByID(db.Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (r *repository) findByID(db *gorm.DB, id uint) (*Payroll, error) {
 	var payroll Payroll
-	err := r.db.
+	err := db.
 		Preload("Employee").
+		Preload("PayrollRun").
 		Preload("Details").
+		Preload("Contributions").
 		First(&payroll, id).Error
 	if err != nil {
 		return nil, err
@@ -81,7 +151,9 @@ func (r *repository) FindByID(id uint) (*Payroll, error) {
 	return &payroll, nil
 }
 
-func (r *repository) GetExistingEmployeeID(month, year int) (map[uint]bool, error) {
+// GetExistingEmployeeID return employee who already has a payroll of the type on the period,
+// so regular payroll & THR of the same month never block each other
+func (r *repository) GetExistingEmployeeID(month, year int, payrollType constants.PayrollType) (map[uint]bool, error) {
 	var existingID []uint
 
 	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
@@ -89,6 +161,8 @@ func (r *repository) GetExistingEmployeeID(month, year int) (map[uint]bool, erro
 
 	err := r.db.Model(&Payroll{}).
 		Where("period_date BETWEEN ? AND ?", startDate, endDate).
+		Where("type = ?", payrollType).
+		Where("status != ?", constants.PayrollStatusVoid).
 		Pluck("employee_id", &existingID).Error
 	if err != nil {
 		return nil, err
@@ -108,3 +182,584 @@ func (r *repository) UpdateStatus(ctx context.Context, id uint, status constants
 		Where("id = ?", id).
 		Update("status", status).Error
 }
+
+func (r *repository) GetBulkTaxYearToDate(ctx context.Context, month, year int) (map[uint]taxYearToDate, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var results []taxYearToDate
+
+	startDate := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	endDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+
+	// THR paid on the current month already withheld its own tax, so it count as year to date too
+	err := db.Model(&Payroll{}).
+		Select("employee_id, SUM(taxable_income) AS taxable_income, SUM(tax_amount) AS tax_amount").
+		Where("(period_date >= ? AND period_date < ?) OR (period_date = ? AND type = ?)", startDate, endDate, endDate, constants.PayrollTypeTHR).
+		Where("status != ?", constants.PayrollStatusVoid).
+		Group("employee_id").
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var pensions []taxYearToDate
+	err = db.Table("payroll_contributions").
+		Select("payrolls.employee_id, SUM(payroll_contributions.employee_amount) AS pension_contribution").
+		Joins("JOIN payrolls ON payrolls.id = payroll_contributions.payroll_id").
+		Where("((payrolls.period_date >= ? AND payrolls.period_date < ?) OR (payrolls.period_date = ? AND payrolls.type = ?)) AND payrolls.deleted_at IS NULL",
+			startDate, endDate, endDate, constants.PayrollTypeTHR).
+		Where("payrolls.status != ?", constants.PayrollStatusVoid).
+		Where("payroll_contributions.program IN ?", []constants.BPJSProgram{constants.BPJSProgramJHT, constants.BPJSProgramJP}).
+		Group("payrolls.employee_id").
+		Scan(&pensions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	resultMap := make(map[uint]taxYearToDate)
+	for _, res := range results {
+		resultMap[res.EmployeeID] = res
+	}
+
+	for _, res := range pensions {
+		ytd := resultMap[res.EmployeeID]
+		ytd.EmployeeID = res.EmployeeID
+		ytd.PensionContribution = res.PensionContribution
+		resultMap[res.EmployeeID] = ytd
+	}
+
+	return resultMap, nil
+}
+
+// GetBulkTaxableIncome return PPh 21 base of payrolls of the type on the period, keyed by employee id
+func (r *repository) GetBulkTaxableIncome(ctx context.Context, month, year int, payrollType constants.PayrollType) (map[uint]money.Money, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var results []struct {
+		EmployeeID    uint
+		TaxableIncome money.Money
+	}
+
+	periodDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+
+	err := db.Model(&Payroll{}).
+		Select("employee_id, SUM(taxable_income) AS taxable_income").
+		Where("period_date = ?", periodDate).
+		Where("type = ?", payrollType).
+		Where("status != ?", constants.PayrollStatusVoid).
+		Group("employee_id").
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	resultMap := make(map[uint]money.Money)
+	for _, res := range results {
+		resultMap[res.EmployeeID] = res.TaxableIncome
+	}
+
+	return resultMap, nil
+}
+
+func (r *repository) FindAllWithContributions(ctx context.Context, month, year int, payrollTypes ...constants.PayrollType) ([]Payroll, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var payrolls []Payroll
+
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+	endDate := startDate.AddDate(0, 1, -1)
+
+	err := db.
+		Joins("JOIN employees ON employees.id = payrolls.employee_id").
+		Preload("Employee").
+		Preload("Contributions").
+		Where("period_date BETWEEN ? AND ?", startDate, endDate).
+		Where("payrolls.type IN ?", payrollTypes).
+		Where("payrolls.status != ?", constants.PayrollStatusVoid).
+		Order("employees.nik ASC").
+		Find(&payrolls).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return payrolls, nil
+}
+
+func (r *repository) FindAllWithDetails(ctx context.Context, month, year int) ([]Payroll, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var payrolls []Payroll
+
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+	endDate := startDate.AddDate(0, 1, -1)
+
+	err := db.
+		Preload("Employee").
+		Preload("Details").
+		Preload("Contributions").
+		Where("period_date BETWEEN ? AND ?", startDate, endDate).
+		Where("status != ?", constants.PayrollStatusVoid).
+		Order("id ASC").
+		Find(&payrolls).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return payrolls, nil
+}
+
+// FindAllByYear return every non void payroll of the year ordered by employee & period, employeeID zero mean all employee
+func (r *repository) FindAllByYear(ctx context.Context, year int, employeeID uint) ([]Payroll, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var payrolls []Payroll
+
+	startDate := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	endDate := startDate.AddDate(1, 0, -1)
+
+	query := db.
+		Preload("Employee").
+		Preload("Details").
+		Preload("Contributions").
+		Where("period_date BETWEEN ? AND ?", startDate, endDate).
+		Where("status != ?", constants.PayrollStatusVoid)
+
+	if employeeID > 0 {
+		query = query.Where("employee_id = ?", employeeID)
+	}
+
+	err := query.
+		Order("employee_id ASC").
+		Order("period_date ASC").
+		Find(&payrolls).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return payrolls, nil
+}
+
+func (r *repository) CreateRun(ctx context.Context, run *PayrollRun) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Create(run).Error
+}
+
+func (r *repository) UpdateRun(ctx context.Context, run *PayrollRun) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Omit("Payrolls").Save(run).Error
+}
+
+func (r *repository) FindRunByID(ctx context.Context, id uint) (*PayrollRun, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var run PayrollRun
+
+	err := db.
+		Preload("Payrolls").
+		Preload("Payrolls.Employee").
+		Preload("Payrolls.Details").
+		First(&run, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+func (r *repository) FindRunByPeriod(ctx context.Context, month, year int, payrollType constants.PayrollType) (*PayrollRun, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var run PayrollRun
+
+	periodDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+
+	err := db.Where("period_date = ? AND type = ?", periodDate, payrollType).First(&run).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+func (r *repository) FindAllRuns(ctx context.Context, filter *PayrollRunFilter) ([]PayrollRun, int64, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var runs []PayrollRun
+	var total int64
+
+	query := db.Model(&PayrollRun{})
+
+	if filter.Year > 0 {
+		query = query.Where("YEAR(period_date) = ?", filter.Year)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	err := query.
+		Order("period_date DESC").
+		Limit(filter.Limit).
+		Offset(offset).
+		Find(&runs).Error
+
+	return runs, total, err
+}
+
+func (r *repository) UpdateRunSummary(ctx context.Context, runID uint) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var summary struct {
+		TotalEmployee  int
+		TotalNetSalary money.Money
+	}
+
+	err := db.Model(&Payroll{}).
+		Select("COUNT(id) AS total_employee, COALESCE(SUM(net_salary), 0) AS total_net_salary").
+		Where("payroll_run_id = ?", runID).
+		Where("status != ?", constants.PayrollStatusVoid).
+		Scan(&summary).Error
+	if err != nil {
+		return err
+	}
+
+	return db.Model(&PayrollRun{}).
+		Where("id = ?", runID).
+		Updates(map[string]interface{}{
+			"total_employee":   summary.TotalEmployee,
+			"total_net_salary": summary.TotalNetSalary,
+		}).Error
+}
+
+func (r *repository) IsPeriodLocked(ctx context.Context, date time.Time) (bool, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var count int64
+
+	periodDate := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.Local)
+
+	// only monthly salary lock attendance, loan & reimbursement of the period
+	err := db.Model(&PayrollRun{}).
+		Where("period_date = ?", periodDate).
+		Where("type = ?", constants.PayrollTypeRegular).
+		Where("status IN ?", []constants.PayrollRunStatus{
+			constants.PayrollRunStatusApproved,
+			constants.PayrollRunStatusPaid,
+			constants.PayrollRunStatusClosed,
+		}).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *repository) FindAllByPeriodAndStatus(ctx context.Context, month, year int, status constants.PayrollStatus) ([]Payroll, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var payrolls []Payroll
+
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+	endDate := startDate.AddDate(0, 1, -1)
+
+	err := db.
+		Preload("Employee").
+		Preload("PayrollRun").
+		Where("period_date BETWEEN ? AND ?", startDate, endDate).
+		Where("status = ?", status).
+		Find(&payrolls).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return payrolls, nil
+}
+
+// ReplaceCalculation overwrite totals, details & contributions of an existing payroll
+func (r *repository) ReplaceCalculation(ctx context.Context, payroll *Payroll) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+
+	if err := db.Where("payroll_id = ?", payroll.ID).Delete(&PayrollDetail{}).Error; err != nil {
+		return err
+	}
+
+	if err := db.Where("payroll_id = ?", payroll.ID).Delete(&PayrollContribution{}).Error; err != nil {
+		return err
+	}
+
+	for i := range payroll.Details {
+		payroll.Details[i].PayrollID = payroll.ID
+	}
+	for i := range payroll.Contributions {
+		payroll.Contributions[i].PayrollID = payroll.ID
+	}
+
+	if len(payroll.Details) > 0 {
+		if err := db.Create(&payroll.Details).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(payroll.Contributions) > 0 {
+		if err := db.Create(&payroll.Contributions).Error; err != nil {
+			return err
+		}
+	}
+
+	return db.Model(&Payroll{}).
+		Where("id = ?", payroll.ID).
+		Updates(map[string]interface{}{
+			"loan_id":         payroll.LoanID,
+			"loan_deduction":  payroll.LoanDeduction,
+			"base_salary":     payroll.BaseSalary,
+			"total_allowance": payroll.TotalAllowance,
+			"total_deduction": payroll.TotalDeduction,
+			"net_salary":      payroll.NetSalary,
+			"taxable_income":  payroll.TaxableIncome,
+			"tax_amount":      payroll.TaxAmount,
+			"ptkp_status":     payroll.PTKPStatus,
+		}).Error
+}
+
+func (r *repository) CreateAudit(ctx context.Context, audit *PayrollAudit) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Create(audit).Error
+}
+
+func (r *repository) CreateEmailJob(ctx context.Context, job *PayslipEmailJob) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Create(job).Error
+}
+
+func (r *repository) UpdateEmailJob(ctx context.Context, job *PayslipEmailJob) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Omit("Deliveries").Save(job).Error
+}
+
+// StartEmailJob move queued job to running, no-op when it's already picked up by other worker
+func (r *repository) StartEmailJob(ctx context.Context, id uint) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+
+	return db.Model(&PayslipEmailJob{}).
+		Where("id = ? AND status = ?", id, constants.PayslipEmailJobStatusQueued).
+		Update("status", constants.PayslipEmailJobStatusRunning).Error
+}
+
+func (r *repository) FindEmailJobByID(ctx context.Context, id uint) (*PayslipEmailJob, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var job PayslipEmailJob
+
+	err := db.
+		Preload("Deliveries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("payslip_email_deliveries.status ASC, payslip_email_deliveries.id ASC")
+		}).
+		Preload("Deliveries.Employee").
+		First(&job, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *repository) FindEmailJobsByPeriod(ctx context.Context, month, year int) ([]PayslipEmailJob, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var jobs []PayslipEmailJob
+
+	periodDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+
+	err := db.
+		Where("period_date = ?", periodDate).
+		Order("created_at DESC").
+		Find(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (r *repository) HasActiveEmailJob(ctx context.Context, month, year int) (bool, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var count int64
+
+	periodDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+
+	err := db.Model(&PayslipEmailJob{}).
+		Where("period_date = ?", periodDate).
+		Where("status IN ?", []constants.PayslipEmailJobStatus{constants.PayslipEmailJobStatusQueued, constants.PayslipEmailJobStatusRunning}).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+func (r *repository) FindUnfinishedEmailJobs(ctx context.Context) ([]PayslipEmailJob, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var jobs []PayslipEmailJob
+
+	err := db.
+		Preload("Deliveries", "status = ?", constants.PayslipEmailDeliveryStatusPending).
+		Where("status IN ?", []constants.PayslipEmailJobStatus{constants.PayslipEmailJobStatusQueued, constants.PayslipEmailJobStatusRunning}).
+		Find(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// RefreshEmailJobCounts recount sent & failed deliveries, so concurrent workers never overwrite each other
+func (r *repository) RefreshEmailJobCounts(ctx context.Context, jobID uint) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+
+	countByStatus := func(status constants.PayslipEmailDeliveryStatus) *gorm.DB {
+		return db.Model(&PayslipEmailDelivery{}).
+			Select("COUNT(*)").
+			Where("job_id = ? AND status = ?", jobID, status)
+	}
+
+	return db.Model(&PayslipEmailJob{}).
+		Where("id = ?", jobID).
+		Updates(map[string]interface{}{
+			"sent_count":   countByStatus(constants.PayslipEmailDeliveryStatusSent),
+			"failed_count": countByStatus(constants.PayslipEmailDeliveryStatusFailed),
+		}).Error
+}
+
+func (r *repository) UpdateEmailDelivery(ctx context.Context, delivery *PayslipEmailDelivery) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Omit("Employee").Save(delivery).Error
+}
+
+func (r *repository) ResetFailedEmailDeliveries(ctx context.Context, jobID uint) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+
+	return db.Model(&PayslipEmailDelivery{}).
+		Where("job_id = ? AND status = ?", jobID, constants.PayslipEmailDeliveryStatusFailed).
+		Updates(map[string]interface{}{
+			"status":        constants.PayslipEmailDeliveryStatusPending,
+			"error_message": "",
+		}).Error
+}
+
+func (r *repository) FindJournalAccounts(ctx context.Context) ([]JournalAccount, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var accounts []JournalAccount
+
+	err := db.Order("id ASC").Find(&accounts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+func (r *repository) FindJournalAccountByID(ctx context.Context, id uint) (*JournalAccount, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var account JournalAccount
+
+	err := db.First(&account, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+func (r *repository) UpdateJournalAccount(ctx context.Context, account *JournalAccount) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Save(account).Error
+}
+
+func (r *repository) FindJournalMappings(ctx context.Context) ([]JournalAccountMapping, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var mappings []JournalAccountMapping
+
+	err := db.Order("detail_type ASC, title_prefix ASC").Find(&mappings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return mappings, nil
+}
+
+// ReplaceJournalMappings swap the whole mapping set, run inside a transaction
+func (r *repository) ReplaceJournalMappings(ctx context.Context, mappings []JournalAccountMapping) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+
+	if err := db.Where("1 = 1").Delete(&JournalAccountMapping{}).Error; err != nil {
+		return err
+	}
+
+	return db.Create(&mappings).Error
+}
+
+func (r *repository) FindSeveranceRules(ctx context.Context) ([]SeveranceRule, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var rules []SeveranceRule
+
+	err := db.Order("id ASC").Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r *repository) FindSeveranceRuleByID(ctx context.Context, id uint) (*SeveranceRule, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var rule SeveranceRule
+
+	err := db.First(&rule, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (r *repository) FindSeveranceRuleByReason(ctx context.Context, reason constants.TerminationReason) (*SeveranceRule, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var rule SeveranceRule
+
+	err := db.Where("reason = ?", reason).First(&rule).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (r *repository) UpdateSeveranceRule(ctx context.Context, rule *SeveranceRule) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Save(rule).Error
+}
+
+func (r *repository) CreateFinalSettlement(ctx context.Context, settlement *FinalSettlement) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Create(settlement).Error
+}
+
+func (r *repository) FindFinalSettlementByPayrollID(ctx context.Context, payrollID uint) (*FinalSettlement, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var settlement FinalSettlement
+
+	err := db.
+		Preload("Payroll.Details").
+		Preload("Employee.Department").
+		Where("payroll_id = ?", payrollID).
+		First(&settlement).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &settlement, nil
+}
+
+// HasActiveFinalSettlement return true when the employee already has a final settlement which payroll is not void
+func (r *repository) HasActiveFinalSettlement(ctx context.Context, employeeID uint) (bool, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var count int64
+
+	err := db.Model(&FinalSettlement{}).
+		Joins("JOIN payrolls ON payrolls.id = final_settlements.payroll_id").
+		Where("final_settlements.employee_id = ?", employeeID).
+		Where("payrolls.status != ? AND payrolls.deleted_at IS NULL", constants.PayrollStatusVoid).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
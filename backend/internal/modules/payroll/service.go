@@ -1,11 +1,15 @@
 package payroll
 
 import (
+	"archive/zip"
 	"basekarya-backend/internal/infrastructure"
+	"basekarya-backend/internal/modules/company"
+	"basekarya-backend/internal/modules/user"
 	"basekarya-backend/pkg/constants"
 	"basekarya-backend/pkg/logger"
+	"basekarya-backend/pkg/money"
 	"basekarya-backend/pkg/response"
-	"basekarya-backend/pkg/utils"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -14,15 +18,46 @@ import (
 	"time"
 
 	"github.com/signintech/gopdf"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
 )
 
 type Service interface {
 	GenerateAll(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error)
+	GenerateTHR(ctx context.Context, req *GenerateTHRRequest) (*GenerateResponse, error)
+	Simulate(ctx context.Context, req *SimulateRequest) (*SimulationResponse, error)
 	GetList(ctx context.Context, filter *PayrollFilter) ([]PayrollListResponse, *response.Meta, error)
 	GetDetail(ctx context.Context, id uint) (*PayrollDetailResponse, error)
 	GeneratePayslipPDF(ctx context.Context, id uint) (*gopdf.GoPdf, *Payroll, error)
+	GetMyList(ctx context.Context, employeeID uint, filter *PayrollFilter) ([]PayrollListResponse, *response.Meta, error)
+	GenerateMyPayslipPDF(ctx context.Context, id, employeeID uint) (*gopdf.GoPdf, *Payroll, error)
 	MarkAsPaid(ctx context.Context, id uint) error
 	BlastPayslipEmail(ctx context.Context, id uint) error
+	ExportBPJSRecap(ctx context.Context, month, year int) ([]byte, error)
+	ExportBankTransfer(ctx context.Context, month, year int, bank string, payrollType constants.PayrollType) ([]byte, string, error)
+	ComparePeriods(ctx context.Context, req *PayrollComparisonRequest) (*PayrollComparisonResponse, error)
+	ExportComparison(ctx context.Context, req *PayrollComparisonRequest) ([]byte, error)
+	ExportJournal(ctx context.Context, month, year int, format constants.JournalExportFormat) ([]byte, error)
+	GetJournalSetting(ctx context.Context) (*JournalSettingResponse, error)
+	UpdateJournalAccount(ctx context.Context, req *UpdateJournalAccountRequest) error
+	ReplaceJournalMappings(ctx context.Context, req *ReplaceJournalMappingsRequest) error
+	GenerateTaxFormPDF(ctx context.Context, employeeID uint, year int) ([]byte, *TaxForm, error)
+	ExportTaxForms(ctx context.Context, year int) ([]byte, error)
+	SendTaxFormEmails(ctx context.Context, req *SendTaxFormEmailRequest) (*SendTaxFormEmailResponse, error)
+	CreateFinalSettlement(ctx context.Context, req *FinalSettlementRequest) (*FinalSettlementResponse, error)
+	GenerateExitDocumentPDF(ctx context.Context, payrollID uint) ([]byte, *FinalSettlement, error)
+	GetSeveranceRules(ctx context.Context) ([]SeveranceRule, error)
+	UpdateSeveranceRule(ctx context.Context, req *UpdateSeveranceRuleRequest) error
+	CreatePayslipEmailJob(ctx context.Context, req *SendPayslipEmailRequest) (*PayslipEmailJob, error)
+	RetryPayslipEmailJob(ctx context.Context, id uint) (*PayslipEmailJob, error)
+	GetPayslipEmailJobs(ctx context.Context, month, year int) ([]PayslipEmailJobResponse, error)
+	GetPayslipEmailJobDetail(ctx context.Context, id uint) (*PayslipEmailJobResponse, error)
+	GetRuns(ctx context.Context, filter *PayrollRunFilter) ([]PayrollRunResponse, *response.Meta, error)
+	GetRunDetail(ctx context.Context, id uint) (*PayrollRunResponse, error)
+	ProcessRunAction(ctx context.Context, req *PayrollRunActionRequest) error
+	Regenerate(ctx context.Context, id, actorID uint) error
+	RegeneratePeriod(ctx context.Context, req *RegenerateRequest) (*GenerateResponse, error)
+	Void(ctx context.Context, req *VoidRequest) error
 }
 
 type service struct {
@@ -37,6 +72,12 @@ type service struct {
 	email              EmailProvider
 	loan               LoanProvider
 	overtime           OvertimeProvider
+	salaryComponent    SalaryComponentProvider
+	bpjs               BPJSProvider
+	excel              infrastructure.ExcelProvider
+	latePolicy         LatePolicyProvider
+	holiday            HolidayProvider
+	leave              LeaveProvider
 }
 
 func NewService(repo Repository,
@@ -49,11 +90,27 @@ func NewService(repo Repository,
 	client *http.Client,
 	email EmailProvider,
 	loan LoanProvider,
-	overtime OvertimeProvider) Service {
-	return &service{repo, user, reimbursement, attendance, company, notification, transactionManager, client, email, loan, overtime}
+	overtime OvertimeProvider,
+	salaryComponent SalaryComponentProvider,
+	bpjs BPJSProvider,
+	excel infrastructure.ExcelProvider,
+	latePolicy LatePolicyProvider,
+	holiday HolidayProvider,
+	leave LeaveProvider) Service {
+	return &service{repo, user, reimbursement, attendance, company, notification, transactionManager, client, email, loan, overtime, salaryComponent, bpjs, excel, latePolicy, holiday, leave}
 }
 
 func (s *service) GenerateAll(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error) {
+	run, err := s.repo.FindRunByPeriod(ctx, req.Month, req.Year, constants.PayrollTypeRegular)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to fetch payroll run: %w", err)
+	}
+
+	// only draft run can receive new payrolls
+	if run != nil && run.Status != constants.PayrollRunStatusDraft {
+		return nil, fmt.Errorf("payroll run of this period already %s", run.Status)
+	}
+
 	employees, err := s.user.FindAllEmployeeActive(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch all employee active: %w", err)
@@ -64,33 +121,17 @@ func (s *service) GenerateAll(ctx context.Context, req *GenerateRequest) (*Gener
 		employeeIds[i] = emp.ID
 	}
 
-	existingPayrollMap, err := s.repo.GetExistingEmployeeID(req.Month, req.Year)
+	existingPayrollMap, err := s.repo.GetExistingEmployeeID(req.Month, req.Year, constants.PayrollTypeRegular)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch existing employee id: %w", err)
 	}
 
-	attendanceMap, err := s.attendance.GetBulkLateDuration(ctx, req.Month, req.Year)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch bulk late duration: %w", err)
-	}
-
-	reimburseMap, err := s.reimbursement.GetBulkApprovedAmount(ctx, req.Month, req.Year)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch bulk approved amount: %w", err)
-	}
-
-	loanMap, err := s.loan.GetBulkActiveLoansByEmployeeIds(ctx, employeeIds)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch bulk active loans by employee ids: %w", err)
-	}
-
-	overtimeMap, err := s.overtime.GetBulkActiveOvertimesByEmployeeIds(ctx, req.Month, req.Year, employeeIds)
+	input, err := s.buildCalculationInput(ctx, req.Month, req.Year, employeeIds)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch bulk overtime amounts: %w", err)
+		return nil, err
 	}
 
 	successCount := 0
-	periodDate := time.Date(req.Year, time.Month(req.Month), 1, 0, 0, 0, 0, time.Local)
 
 	var payrollsToInsert []Payroll
 
@@ -100,534 +141,2100 @@ func (s *service) GenerateAll(ctx context.Context, req *GenerateRequest) (*Gener
 			continue
 		}
 
-		// take data with O(1) lookup
-		baseSalary := emp.BaseSalary
-		totalLateMinutes := attendanceMap[emp.ID]
-		reimburseAmount := reimburseMap[emp.UserID]
+		// skip employee who not yet joined or already left on this period
+		if !isPayable(&emp, input) {
+			continue
+		}
 
-		// calculate loan
-		loanData := loanMap[emp.ID]
-		loanAmount := loanData.InstallmentAmount
-		loanData.RemainingAmount -= loanAmount
+		// insert to slice & update success count
+		payrollsToInsert = append(payrollsToInsert, calculatePayroll(&emp, input))
+		successCount++
+	}
 
-		// calculate overtime nominal
-		// hourly wage = 1/173 * base salary
-		hourlyWage := baseSalary / 173
-		totalOvertimeMinutes := overtimeMap[emp.ID]
-		overtimeAmount := 0.0
+	// check if payrollsToInsert empty, return 0
+	if len(payrollsToInsert) == 0 {
+		return nil, nil
+	}
 
-		if totalOvertimeMinutes > 0 {
-			overtimeHours := float64(totalOvertimeMinutes) / 60.0
+	err = s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if run == nil {
+			run = &PayrollRun{
+				PeriodDate: input.PeriodDate,
+				Type:       constants.PayrollTypeRegular,
+				Status:     constants.PayrollRunStatusDraft,
+			}
 
-			// simplified rule: 1.5x for first hour, 2.0x for the rest
-			if overtimeHours <= 1.0 {
-				overtimeAmount = overtimeHours * 1.5 * hourlyWage
-			} else {
-				// first hour is 1.5x, remaining hours are 2.0x
-				overtimeAmount = (1.0 * 1.5 * hourlyWage) + ((overtimeHours - 1.0) * 2.0 * hourlyWage)
+			if err := s.repo.CreateRun(ctx, run); err != nil {
+				return fmt.Errorf("failed to create payroll run: %w", err)
 			}
 		}
 
-		// calculate net salary
-		latePenaltyAmount := float64(totalLateMinutes * constants.PenaltyPerMinuteLate)
-		totalAllowance := baseSalary + reimburseAmount + overtimeAmount
-		totalDeduction := latePenaltyAmount + loanAmount
-		netSalary := totalAllowance - totalDeduction
-
-		// construct object
-		payroll := Payroll{
-			EmployeeID:     emp.ID,
-			PeriodDate:     periodDate,
-			BaseSalary:     baseSalary,
-			TotalAllowance: totalAllowance,
-			TotalDeduction: totalDeduction,
-			NetSalary:      netSalary,
-			Status:         constants.PayrollStatusDraft,
-			Details:        []PayrollDetail{},
-		}
-
-		payroll.Details = append(payroll.Details, PayrollDetail{
-			Title:  "Base Salary",
-			Type:   constants.DetailTypeAllowance,
-			Amount: baseSalary,
-		})
-
-		// check if reimburse amount not zero
-		if reimburseAmount > 0 {
-			payroll.Details = append(payroll.Details, PayrollDetail{
-				Title:  "Reimbursement",
-				Type:   constants.DetailTypeAllowance,
-				Amount: reimburseAmount,
-			})
+		for i := range payrollsToInsert {
+			payrollsToInsert[i].PayrollRunID = &run.ID
 		}
 
-		// check if overtime amount not zero
-		if overtimeAmount > 0 {
-			payroll.Details = append(payroll.Details, PayrollDetail{
-				Title:  fmt.Sprintf("Uang Lembur (%d jam %d menit)", totalOvertimeMinutes/60, totalOvertimeMinutes%60),
-				Type:   constants.DetailTypeAllowance,
-				Amount: overtimeAmount,
-			})
+		// bulk insert payrolls
+		if err := s.repo.CreateBulk(ctx, &payrollsToInsert); err != nil {
+			return err
 		}
 
-		// check if late penalty amount not zero
-		if latePenaltyAmount > 0 {
-			payroll.Details = append(payroll.Details, PayrollDetail{
-				Title:  fmt.Sprintf("Potongan Terlambat (%d menit)", totalLateMinutes),
-				Type:   constants.DetailTypeDeduction,
-				Amount: latePenaltyAmount,
-			})
-		}
+		return s.repo.UpdateRunSummary(ctx, run.ID)
+	})
+	if err != nil {
+		logger.Errorf("Failed create bulk payrolls %w", err)
+
+		successCount = 0
+		return nil, err
+	}
+
+	return &GenerateResponse{
+		PayrollRunID: run.ID,
+		SuccessCount: successCount,
+		Year:         req.Year,
+		Month:        req.Month,
+	}, nil
+}
+
+// GenerateTHR create THR payrolls of the period in its own payroll run, separate from the monthly salary
+func (s *service) GenerateTHR(ctx context.Context, req *GenerateTHRRequest) (*GenerateResponse, error) {
+	cutoffDate, err := time.ParseInLocation(constants.DefaultTimeFormat, req.CutoffDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cutoff date: %w", err)
+	}
+
+	run, err := s.repo.FindRunByPeriod(ctx, req.Month, req.Year, constants.PayrollTypeTHR)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to fetch THR payroll run: %w", err)
+	}
+
+	// only draft run can receive new payrolls
+	if run != nil && run.Status != constants.PayrollRunStatusDraft {
+		return nil, fmt.Errorf("THR payroll run of this period already %s", run.Status)
+	}
+
+	employees, err := s.user.FindAllEmployeeActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch all employee active: %w", err)
+	}
+
+	existingPayrollMap, err := s.repo.GetExistingEmployeeID(req.Month, req.Year, constants.PayrollTypeTHR)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch existing employee id: %w", err)
+	}
+
+	regularTaxableMap, err := s.repo.GetBulkTaxableIncome(ctx, req.Month, req.Year, constants.PayrollTypeRegular)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch regular taxable income: %w", err)
+	}
+
+	input := &thrInput{
+		PeriodDate:        time.Date(req.Year, time.Month(req.Month), 1, 0, 0, 0, 0, time.Local),
+		CutoffDate:        cutoffDate,
+		RegularTaxableMap: regularTaxableMap,
+	}
 
-		// check if loan amount not zero
-		if loanAmount > 0 {
-			payroll.Details = append(payroll.Details, PayrollDetail{
-				Title:  "Potongan Kasbon",
-				Type:   constants.DetailTypeDeduction,
-				Amount: loanAmount,
-			})
+	var payrollsToInsert []Payroll
+	for _, emp := range employees {
+		if existingPayrollMap[emp.ID] || !isTHREligible(&emp, input) {
+			continue
 		}
 
-		// insert to slice & update success count
-		payrollsToInsert = append(payrollsToInsert, payroll)
-		successCount++
+		payrollsToInsert = append(payrollsToInsert, calculateTHRPayroll(&emp, input))
 	}
 
-	// check if payrollsToInsert empty, return 0
 	if len(payrollsToInsert) == 0 {
 		return nil, nil
 	}
 
-	// bulk insert payrolls
-	if err := s.repo.CreateBulk(&payrollsToInsert); err != nil {
-		logger.Errorf("Failed create bulk payrolls %w", err)
+	err = s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if run == nil {
+			run = &PayrollRun{
+				PeriodDate: input.PeriodDate,
+				Type:       constants.PayrollTypeTHR,
+				Status:     constants.PayrollRunStatusDraft,
+			}
+
+			if err := s.repo.CreateRun(ctx, run); err != nil {
+				return fmt.Errorf("failed to create THR payroll run: %w", err)
+			}
+		}
+
+		for i := range payrollsToInsert {
+			payrollsToInsert[i].PayrollRunID = &run.ID
+		}
+
+		if err := s.repo.CreateBulk(ctx, &payrollsToInsert); err != nil {
+			return err
+		}
+
+		return s.repo.UpdateRunSummary(ctx, run.ID)
+	})
+	if err != nil {
+		logger.Errorf("Failed create bulk THR payrolls %w", err)
 
-		successCount = 0
 		return nil, err
 	}
 
 	return &GenerateResponse{
-		SuccessCount: successCount,
+		PayrollRunID: run.ID,
+		SuccessCount: len(payrollsToInsert),
 		Year:         req.Year,
 		Month:        req.Month,
 	}, nil
 }
 
-func (s *service) GetList(ctx context.Context, filter *PayrollFilter) ([]PayrollListResponse, *response.Meta, error) {
-	data, total, err := s.repo.FindAll(filter)
+// Simulate run the same calculation as GenerateAll for every payable employee without inserting anything
+func (s *service) Simulate(ctx context.Context, req *SimulateRequest) (*SimulationResponse, error) {
+	employees, err := s.user.FindAllEmployeeActive(ctx)
 	if err != nil {
-		return nil, nil, err
+		return nil, fmt.Errorf("failed to fetch all employee active: %w", err)
 	}
 
-	if len(data) == 0 {
-		return []PayrollListResponse{}, nil, nil
+	employeeIds := make([]uint, len(employees))
+	for i, emp := range employees {
+		employeeIds[i] = emp.ID
 	}
 
-	var responses []PayrollListResponse
-	for _, p := range data {
-		empName := "Unknown"
-		empNIK := "-"
-		if p.Employee != nil {
-			empName = p.Employee.FullName
-			empNIK = p.Employee.NIK
-		}
-
-		responses = append(responses, PayrollListResponse{
-			ID:           p.ID,
-			EmployeeName: empName,
-			EmployeeNIK:  empNIK,
-			PeriodDate:   p.PeriodDate.Format(constants.DefaultTimeFormat),
-			NetSalary:    p.NetSalary,
-			Status:       string(p.Status),
-			CreatedAt:    p.CreatedAt,
-		})
+	existingPayrollMap, err := s.repo.GetExistingEmployeeID(req.Month, req.Year, constants.PayrollTypeRegular)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch existing employee id: %w", err)
 	}
 
-	meta := response.NewMetaOffset(filter.Page, filter.Limit, total)
-	return responses, meta, nil
-}
-
-func (s *service) GetDetail(ctx context.Context, id uint) (*PayrollDetailResponse, error) {
-	payroll, err := s.repo.FindByID(id)
+	input, err := s.buildCalculationInput(ctx, req.Month, req.Year, employeeIds)
 	if err != nil {
 		return nil, err
 	}
 
-	if payroll.Employee == nil {
-		return nil, errors.New("employee not found")
+	resp := &SimulationResponse{
+		Month:     req.Month,
+		Year:      req.Year,
+		Employees: []SimulatedPayroll{},
 	}
 
-	emp := payroll.Employee
+	for _, emp := range employees {
+		if !isPayable(&emp, input) {
+			continue
+		}
 
-	details := make([]Detail, len(payroll.Details))
-	for _, detail := range payroll.Details {
-		details = append(details, Detail{
-			ID:        detail.ID,
-			PayrollID: detail.PayrollID,
-			Title:     detail.Title,
-			Type:      detail.Type,
-			Amount:    detail.Amount,
+		payroll := calculatePayroll(&emp, input)
+		warnings := simulationWarnings(&emp, &payroll)
+
+		resp.Employees = append(resp.Employees, SimulatedPayroll{
+			EmployeeID:       emp.ID,
+			EmployeeName:     emp.FullName,
+			EmployeeNIK:      emp.NIK,
+			BaseSalary:       payroll.BaseSalary,
+			TotalAllowance:   payroll.TotalAllowance,
+			TotalDeduction:   payroll.TotalDeduction,
+			NetSalary:        payroll.NetSalary,
+			TaxableIncome:    payroll.TaxableIncome,
+			TaxAmount:        payroll.TaxAmount,
+			PTKPStatus:       payroll.PTKPStatus,
+			AlreadyGenerated: existingPayrollMap[emp.ID],
+			Warnings:         warnings,
+			Details:          toDetailResponses(payroll.Details),
+			Contributions:    toContributionResponses(payroll.Contributions),
 		})
+
+		resp.TotalEmployee++
+		resp.TotalNetSalary += payroll.NetSalary
+		if len(warnings) > 0 {
+			resp.TotalWarning++
+		}
 	}
 
-	payrollDetail := PayrollDetailResponse{
-		ID:                        payroll.ID,
-		EmployeeID:                emp.ID,
-		EmployeeName:              emp.FullName,
-		EmployeeNIK:               emp.NIK,
-		EmployeeBankNumber:        emp.BankAccountNumber,
-		EmployeeBankName:          emp.BankName,
-		EmployeeBankAccountHolder: emp.BankAccountHolder,
-		PeriodDate:                payroll.PeriodDate.Format(constants.DefaultTimeFormat),
-		BaseSalary:                payroll.BaseSalary,
-		TotalAllowance:            payroll.TotalAllowance,
-		TotalDeduction:            payroll.TotalDeduction,
-		NetSalary:                 payroll.NetSalary,
-		Status:                    string(payroll.Status),
-		CreatedAt:                 payroll.CreatedAt,
-		Details:                   details,
+	return resp, nil
+}
+
+// simulationWarnings return data problems that would block or break payment of the payroll
+func simulationWarnings(emp *user.Employee, payroll *Payroll) []constants.PayrollSimulationWarning {
+	warnings := []constants.PayrollSimulationWarning{}
+
+	if emp.BaseSalary <= 0 {
+		warnings = append(warnings, constants.PayrollSimulationZeroBaseSalary)
 	}
 
-	return &payrollDetail, nil
+	if strings.TrimSpace(emp.BankAccountNumber) == "" || strings.TrimSpace(emp.BankName) == "" {
+		warnings = append(warnings, constants.PayrollSimulationMissingBankAccount)
+	}
+
+	if payroll.NetSalary < 0 {
+		warnings = append(warnings, constants.PayrollSimulationNegativeNetSalary)
+	}
+
+	return warnings
 }
 
-func (s *service) GeneratePayslipPDF(ctx context.Context, id uint) (*gopdf.GoPdf, *Payroll, error) {
-	payroll, err := s.repo.FindByID(id)
+// buildCalculationInput fetch all bulk data of a period needed by calculatePayroll
+func (s *service) buildCalculationInput(ctx context.Context, month, year int, employeeIds []uint) (*calculationInput, error) {
+	attendanceMap, err := s.attendance.GetBulkLateOccurrences(ctx, month, year)
 	if err != nil {
-		return nil, nil, err
+		return nil, fmt.Errorf("failed to fetch bulk late occurrences: %w", err)
 	}
 
-	company, err := s.company.FindByID(ctx, 1)
+	latePolicy, err := s.latePolicy.FindActive(ctx)
 	if err != nil {
-		return nil, nil, err
+		return nil, fmt.Errorf("failed to fetch late policy: %w", err)
 	}
 
-	pdf := &gopdf.GoPdf{}
-	pdf.Start(gopdf.Config{PageSize: *gopdf.PageSizeA4})
-	pdf.AddPage()
+	reimburseMap, err := s.reimbursement.GetBulkApprovedAmount(ctx, month, year)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bulk approved amount: %w", err)
+	}
 
-	// Pastikan warna teks default hitam
-	pdf.SetTextColor(0, 0, 0)
+	loanMap, err := s.loan.GetBulkActiveLoansByEmployeeIds(ctx, employeeIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bulk active loans by employee ids: %w", err)
+	}
 
-	// --- SETUP FONT ---
-	err = pdf.AddTTFFont("Roboto", "assets/fonts/Roboto-Regular.ttf")
+	overtimeMap, err := s.overtime.GetBulkActiveOvertimesByEmployeeIds(ctx, month, year, employeeIds)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed load font regular: %w", err)
+		return nil, fmt.Errorf("failed to fetch bulk overtime amounts: %w", err)
 	}
-	err = pdf.AddTTFFont("Roboto-Bold", "assets/fonts/Roboto-Bold.ttf")
+
+	assignments, err := s.salaryComponent.FindAllActiveAssignments(ctx)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed load font bold: %w", err)
+		return nil, fmt.Errorf("failed to fetch salary component assignments: %w", err)
 	}
 
-	marginLeft := 30.0
-	marginRight := 565.0
-	contentWidth := marginRight - marginLeft // 535.0 pt
+	bpjsRates, err := s.bpjs.FindAllActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bpjs rates: %w", err)
+	}
 
-	startY := 30.0
-	currentY := startY
-	logoRendered := false
+	company, err := s.company.FindByID(ctx, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch company: %w", err)
+	}
 
-	// --- SECTION: HEADER ---
-	if company.LogoURL != "" {
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, company.LogoURL, nil)
+	periodDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+	holidays, err := s.holiday.FindByDateRange(ctx, periodDate, periodDate.AddDate(0, 1, -1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch holidays: %w", err)
+	}
 
-		if err == nil {
-			resp, err := s.client.Do(req)
-			if err == nil && resp.StatusCode == http.StatusOK {
-				defer resp.Body.Close()
+	holidayMap := make(map[string]string)
+	for _, h := range holidays {
+		holidayMap[h.Date.Format(constants.DefaultTimeFormat)] = h.Name
+	}
 
-				imgHolder, err := gopdf.ImageHolderByReader(resp.Body)
-				if err == nil {
-					_ = pdf.ImageByHolder(imgHolder, marginLeft, startY, &gopdf.Rect{W: 70, H: 70})
+	input := &calculationInput{
+		PeriodDate:   periodDate,
+		LateMap:      attendanceMap,
+		LatePolicy:   latePolicy,
+		ReimburseMap: reimburseMap,
+		LoanMap:      loanMap,
+		OvertimeMap:  overtimeMap,
+		Assignments:  assignments,
+		BPJSRates:    bpjsRates,
 
-					textStartX := marginLeft + 90.0
+		ProrationBasis: company.ProrationBasis,
 
-					pdf.SetXY(textStartX, startY+15)
-					_ = pdf.SetFont("Roboto-Bold", "", 20)
-					_ = pdf.Cell(nil, company.Name)
+		Holidays:        holidayMap,
+		WorkDaysPerWeek: company.WorkDaysPerWeek,
 
-					pdf.SetXY(textStartX, startY+35)
-					_ = pdf.SetFont("Roboto", "", 11)
-					_ = pdf.Cell(nil, company.Address)
+		DailyRateBasis:   company.DailyRateBasis,
+		DailyRateDivisor: company.DailyRateDivisor,
+	}
 
-					pdf.SetXY(textStartX, startY+50)
-					_ = pdf.Cell(nil, fmt.Sprintf("Telp: %s | Email: %s", company.PhoneNumber, company.Email))
+	if company.AbsenceDeductionEnabled {
+		input.AbsentMap, err = s.attendance.GetBulkAbsentDays(ctx, month, year)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch bulk absent days: %w", err)
+		}
+	}
 
-					currentY = startY + 90
-					logoRendered = true
-				}
-			}
+	if company.UnpaidLeaveDeductionEnabled {
+		input.UnpaidLeaveMap, err = s.leave.GetBulkUnpaidLeaveDays(ctx, month, year)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch bulk unpaid leave days: %w", err)
 		}
 	}
 
-	if !logoRendered {
-		pdf.SetXY(marginLeft, startY)
-		_ = pdf.SetFont("Roboto-Bold", "", 20)
-		_ = pdf.CellWithOption(&gopdf.Rect{W: contentWidth, H: 25}, company.Name, gopdf.CellOption{Align: gopdf.Center})
+	if month == int(time.December) {
+		input.TaxYearToDateMap, err = s.repo.GetBulkTaxYearToDate(ctx, month, year)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch tax year to date: %w", err)
+		}
+	}
 
-		pdf.SetXY(marginLeft, startY+25)
-		_ = pdf.SetFont("Roboto", "", 11)
-		_ = pdf.CellWithOption(&gopdf.Rect{W: contentWidth, H: 15}, company.Address, gopdf.CellOption{Align: gopdf.Center})
+	return input, nil
+}
 
-		pdf.SetXY(marginLeft, startY+40)
-		_ = pdf.CellWithOption(&gopdf.Rect{W: contentWidth, H: 15}, fmt.Sprintf("Telp: %s | Email: %s", company.PhoneNumber, company.Email), gopdf.CellOption{Align: gopdf.Center})
+func (s *service) Regenerate(ctx context.Context, id, actorID uint) error {
+	payroll, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
 
-		currentY = startY + 70
+	if payroll.Status != constants.PayrollStatusDraft {
+		return fmt.Errorf("only draft payroll can be regenerated, current status %s", payroll.Status)
 	}
 
-	pdf.SetLineWidth(1)
-	pdf.Line(marginLeft, currentY, marginRight, currentY)
-	currentY += 20
+	if payroll.IsTHR() {
+		return errors.New("THR payroll cannot be regenerated, void it & generate THR again")
+	}
 
-	// --- SECTION: EMPLOYEE INFO ---
-	printInfo := func(x float64, y float64, label, value string) {
-		pdf.SetXY(x, y)
-		_ = pdf.SetFont("Roboto-Bold", "", 11)
-		_ = pdf.Cell(nil, label)
+	if payroll.IsFinalSettlement() {
+		return errors.New("final settlement cannot be regenerated, void it & create the final settlement again")
+	}
 
-		pdf.SetXY(x+70, y)
-		_ = pdf.SetFont("Roboto", "", 11)
-		_ = pdf.Cell(nil, ": "+value)
+	if payroll.PayrollRun != nil && payroll.PayrollRun.Status != constants.PayrollRunStatusDraft {
+		return fmt.Errorf("payroll run of this period already %s", payroll.PayrollRun.Status)
 	}
 
-	periodStr := payroll.PeriodDate.Format("January 2006")
+	input, err := s.buildCalculationInput(ctx, int(payroll.PeriodDate.Month()), payroll.PeriodDate.Year(), []uint{payroll.EmployeeID})
+	if err != nil {
+		return err
+	}
 
-	printInfo(marginLeft, currentY, "Name", payroll.Employee.FullName)
+	return s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := s.recalculate(ctx, payroll, input, actorID); err != nil {
+			return err
+		}
+
+		if payroll.PayrollRunID != nil {
+			return s.repo.UpdateRunSummary(ctx, *payroll.PayrollRunID)
+		}
+
+		return nil
+	})
+}
+
+func (s *service) RegeneratePeriod(ctx context.Context, req *RegenerateRequest) (*GenerateResponse, error) {
+	run, err := s.repo.FindRunByPeriod(ctx, req.Month, req.Year, constants.PayrollTypeRegular)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to fetch payroll run: %w", err)
+	}
+
+	if run != nil && run.Status != constants.PayrollRunStatusDraft {
+		return nil, fmt.Errorf("payroll run of this period already %s", run.Status)
+	}
+
+	drafts, err := s.repo.FindAllByPeriodAndStatus(ctx, req.Month, req.Year, constants.PayrollStatusDraft)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch draft payrolls: %w", err)
+	}
+
+	payrolls := regularPayrolls(drafts)
+
+	if len(payrolls) == 0 {
+		return nil, errors.New("no draft payroll found on this period")
+	}
+
+	employeeIds := make([]uint, len(payrolls))
+	for i, p := range payrolls {
+		employeeIds[i] = p.EmployeeID
+	}
+
+	input, err := s.buildCalculationInput(ctx, req.Month, req.Year, employeeIds)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		for i := range payrolls {
+			if err := s.recalculate(ctx, &payrolls[i], input, req.ActorID); err != nil {
+				return fmt.Errorf("failed to regenerate payroll %d: %w", payrolls[i].ID, err)
+			}
+		}
+
+		if run != nil {
+			return s.repo.UpdateRunSummary(ctx, run.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &GenerateResponse{
+		SuccessCount: len(payrolls),
+		Month:        req.Month,
+		Year:         req.Year,
+	}
+	if run != nil {
+		resp.PayrollRunID = run.ID
+	}
+
+	return resp, nil
+}
+
+// recalculate rebuild a draft payroll from current data & record the change to audit
+func (s *service) recalculate(ctx context.Context, payroll *Payroll, input *calculationInput, actorID uint) error {
+	if payroll.Employee == nil {
+		return errors.New("employee not found")
+	}
+
+	previousNetSalary := payroll.NetSalary
+
+	recalculated := calculatePayroll(payroll.Employee, input)
+	recalculated.ID = payroll.ID
+
+	if err := s.repo.ReplaceCalculation(ctx, &recalculated); err != nil {
+		return err
+	}
+
+	return s.repo.CreateAudit(ctx, &PayrollAudit{
+		PayrollID:         payroll.ID,
+		ActorID:           actorID,
+		Action:            constants.PayrollAuditActionRegenerate,
+		PreviousStatus:    payroll.Status,
+		PreviousNetSalary: previousNetSalary,
+		NewNetSalary:      recalculated.NetSalary,
+	})
+}
+
+func (s *service) Void(ctx context.Context, req *VoidRequest) error {
+	return s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		payroll, err := s.repo.FindByIDForUpdate(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+
+		if payroll.Status == constants.PayrollStatusVoid {
+			return errors.New("payroll already void")
+		}
+
+		if payroll.PayrollRun != nil && payroll.PayrollRun.Status == constants.PayrollRunStatusClosed {
+			return errors.New("cannot void payroll of closed payroll run")
+		}
+
+		// reverse side effects of markPayrollPaid
+		if payroll.Status == constants.PayrollStatusPaid {
+			if err := s.reversePayment(ctx, payroll); err != nil {
+				return err
+			}
+		}
+
+		if err := s.repo.UpdateStatus(ctx, payroll.ID, constants.PayrollStatusVoid); err != nil {
+			return err
+		}
+
+		// void final settlement give the employment back, so a new settlement can be created
+		if payroll.IsFinalSettlement() {
+			if err := s.restoreSettledEmployee(ctx, payroll.ID); err != nil {
+				return err
+			}
+		}
+
+		err = s.repo.CreateAudit(ctx, &PayrollAudit{
+			PayrollID:         payroll.ID,
+			ActorID:           req.ActorID,
+			Action:            constants.PayrollAuditActionVoid,
+			Reason:            req.Reason,
+			PreviousStatus:    payroll.Status,
+			PreviousNetSalary: payroll.NetSalary,
+		})
+		if err != nil {
+			return err
+		}
+
+		if payroll.PayrollRunID != nil {
+			return s.repo.UpdateRunSummary(ctx, *payroll.PayrollRunID)
+		}
+
+		return nil
+	})
+}
+
+// restoreSettledEmployee undo the end of employment done by CreateFinalSettlement
+func (s *service) restoreSettledEmployee(ctx context.Context, payrollID uint) error {
+	settlement, err := s.repo.FindFinalSettlementByPayrollID(ctx, payrollID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch final settlement: %w", err)
+	}
+
+	emp, err := s.user.FindEmployeeByID(ctx, settlement.EmployeeID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch employee: %w", err)
+	}
+
+	emp.EndDate = settlement.PreviousEndDate
+	emp.User.IsActive = settlement.PreviousUserActive
+	if err := s.user.UpdateUser(ctx, &emp.User); err != nil {
+		return fmt.Errorf("failed to restore user: %w", err)
+	}
+
+	if err := s.user.UpdateEmployee(ctx, emp); err != nil {
+		return fmt.Errorf("failed to restore employee: %w", err)
+	}
+
+	return nil
+}
+
+// reversePayment restore loan remaining amount & overtime status of a paid payroll
+func (s *service) reversePayment(ctx context.Context, payroll *Payroll) error {
+	if payroll.LoanID != nil && payroll.LoanDeduction > 0 {
+		paidLoan, err := s.loan.FindByID(ctx, *payroll.LoanID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch deducted loan: %w", err)
+		}
+
+		paidLoan.RemainingAmount += payroll.LoanDeduction
+		if paidLoan.Status == constants.LoanStatusPaidOff {
+			paidLoan.Status = constants.LoanStatusApproved
+		}
+
+		if err := s.loan.Update(ctx, paidLoan); err != nil {
+			return fmt.Errorf("failed to restore loan: %w", err)
+		}
+	}
+
+	// overtime is only paid by monthly salary
+	if payroll.IsTHR() {
+		return nil
+	}
+
+	periodMonth := int(payroll.PeriodDate.Month())
+	periodYear := payroll.PeriodDate.Year()
+	if err := s.overtime.RevertBulkPaidStatusByEmployeeId(ctx, payroll.EmployeeID, periodMonth, periodYear); err != nil {
+		return fmt.Errorf("failed to revert overtimes status: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) GetList(ctx context.Context, filter *PayrollFilter) ([]PayrollListResponse, *response.Meta, error) {
+	data, total, err := s.repo.FindAll(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(data) == 0 {
+		return []PayrollListResponse{}, nil, nil
+	}
+
+	var responses []PayrollListResponse
+	for _, p := range data {
+		empName := "Unknown"
+		empNIK := "-"
+		if p.Employee != nil {
+			empName = p.Employee.FullName
+			empNIK = p.Employee.NIK
+		}
+
+		responses = append(responses, PayrollListResponse{
+			ID:           p.ID,
+			EmployeeName: empName,
+			EmployeeNIK:  empNIK,
+			PeriodDate:   p.PeriodDate.Format(constants.DefaultTimeFormat),
+			Type:         string(p.Type),
+			NetSalary:    p.NetSalary,
+			Status:       string(p.Status),
+			CreatedAt:    p.CreatedAt,
+		})
+	}
+
+	meta := response.NewMetaOffset(filter.Page, filter.Limit, total)
+	return responses, meta, nil
+}
+
+func (s *service) GetDetail(ctx context.Context, id uint) (*PayrollDetailResponse, error) {
+	payroll, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if payroll.Employee == nil {
+		return nil, errors.New("employee not found")
+	}
+
+	emp := payroll.Employee
+
+	payrollDetail := PayrollDetailResponse{
+		ID:                        payroll.ID,
+		EmployeeID:                emp.ID,
+		EmployeeName:              emp.FullName,
+		EmployeeNIK:               emp.NIK,
+		EmployeeBankNumber:        emp.BankAccountNumber,
+		EmployeeBankName:          emp.BankName,
+		EmployeeBankAccountHolder: emp.BankAccountHolder,
+		PeriodDate:                payroll.PeriodDate.Format(constants.DefaultTimeFormat),
+		Type:                      string(payroll.Type),
+		BaseSalary:                payroll.BaseSalary,
+		TotalAllowance:            payroll.TotalAllowance,
+		TotalDeduction:            payroll.TotalDeduction,
+		NetSalary:                 payroll.NetSalary,
+		TaxableIncome:             payroll.TaxableIncome,
+		TaxAmount:                 payroll.TaxAmount,
+		PTKPStatus:                payroll.PTKPStatus,
+		Status:                    string(payroll.Status),
+		CreatedAt:                 payroll.CreatedAt,
+		Details:                   toDetailResponses(payroll.Details),
+		Contributions:             toContributionResponses(payroll.Contributions),
+	}
+
+	return &payrollDetail, nil
+}
+
+func toDetailResponses(payrollDetails []PayrollDetail) []Detail {
+	details := make([]Detail, 0, len(payrollDetails))
+	for _, detail := range payrollDetails {
+		details = append(details, Detail{
+			ID:        detail.ID,
+			PayrollID: detail.PayrollID,
+			Title:     detail.Title,
+			Type:      detail.Type,
+			Amount:    detail.Amount,
+			IsTaxable: detail.IsTaxable,
+		})
+	}
+
+	return details
+}
+
+func toContributionResponses(payrollContributions []PayrollContribution) []Contribution {
+	contributions := []Contribution{}
+	for _, c := range payrollContributions {
+		contributions = append(contributions, Contribution{
+			Program:        c.Program,
+			BaseAmount:     c.BaseAmount,
+			EmployeeRate:   c.EmployeeRate,
+			EmployerRate:   c.EmployerRate,
+			EmployeeAmount: c.EmployeeAmount,
+			EmployerAmount: c.EmployerAmount,
+		})
+	}
+
+	return contributions
+}
+
+// GetMyList only show paid payroll, draft & void payroll are not final yet for the employee
+func (s *service) GetMyList(ctx context.Context, employeeID uint, filter *PayrollFilter) ([]PayrollListResponse, *response.Meta, error) {
+	filter.EmployeeID = employeeID
+	filter.Status = string(constants.PayrollStatusPaid)
+
+	return s.GetList(ctx, filter)
+}
+
+func (s *service) GenerateMyPayslipPDF(ctx context.Context, id, employeeID uint) (*gopdf.GoPdf, *Payroll, error) {
+	payroll, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// treat other employee payroll as not found so the id can't be probed
+	if payroll.EmployeeID != employeeID || payroll.Status != constants.PayrollStatusPaid {
+		return nil, nil, gorm.ErrRecordNotFound
+	}
+
+	return s.GeneratePayslipPDF(ctx, id)
+}
+
+func (s *service) GeneratePayslipPDF(ctx context.Context, id uint) (*gopdf.GoPdf, *Payroll, error) {
+	payroll, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	company, err := s.company.FindByID(ctx, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if payroll.Employee == nil {
+		return nil, nil, errors.New("employee not found")
+	}
+
+	config, err := payslipPDFConfig(company, payroll.Employee)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pdf := &gopdf.GoPdf{}
+	pdf.Start(config)
+	pdf.AddPage()
+
+	// Pastikan warna teks default hitam
+	pdf.SetTextColor(0, 0, 0)
+
+	// --- SETUP FONT ---
+	err = pdf.AddTTFFont("Roboto", "assets/fonts/Roboto-Regular.ttf")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed load font regular: %w", err)
+	}
+	err = pdf.AddTTFFont("Roboto-Bold", "assets/fonts/Roboto-Bold.ttf")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed load font bold: %w", err)
+	}
+
+	marginLeft := 30.0
+	marginRight := 565.0
+	contentWidth := marginRight - marginLeft // 535.0 pt
+
+	startY := 30.0
+	currentY := startY
+	logoRendered := false
+
+	// --- SECTION: HEADER ---
+	if company.LogoURL != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, company.LogoURL, nil)
+
+		if err == nil {
+			resp, err := s.client.Do(req)
+			if err == nil && resp.StatusCode == http.StatusOK {
+				defer resp.Body.Close()
+
+				imgHolder, err := gopdf.ImageHolderByReader(resp.Body)
+				if err == nil {
+					_ = pdf.ImageByHolder(imgHolder, marginLeft, startY, &gopdf.Rect{W: 70, H: 70})
+
+					textStartX := marginLeft + 90.0
+
+					pdf.SetXY(textStartX, startY+15)
+					_ = pdf.SetFont("Roboto-Bold", "", 20)
+					_ = pdf.Cell(nil, company.Name)
+
+					pdf.SetXY(textStartX, startY+35)
+					_ = pdf.SetFont("Roboto", "", 11)
+					_ = pdf.Cell(nil, company.Address)
+
+					pdf.SetXY(textStartX, startY+50)
+					_ = pdf.Cell(nil, fmt.Sprintf("Telp: %s | Email: %s", company.PhoneNumber, company.Email))
+
+					currentY = startY + 90
+					logoRendered = true
+				}
+			}
+		}
+	}
+
+	if !logoRendered {
+		pdf.SetXY(marginLeft, startY)
+		_ = pdf.SetFont("Roboto-Bold", "", 20)
+		_ = pdf.CellWithOption(&gopdf.Rect{W: contentWidth, H: 25}, company.Name, gopdf.CellOption{Align: gopdf.Center})
+
+		pdf.SetXY(marginLeft, startY+25)
+		_ = pdf.SetFont("Roboto", "", 11)
+		_ = pdf.CellWithOption(&gopdf.Rect{W: contentWidth, H: 15}, company.Address, gopdf.CellOption{Align: gopdf.Center})
+
+		pdf.SetXY(marginLeft, startY+40)
+		_ = pdf.CellWithOption(&gopdf.Rect{W: contentWidth, H: 15}, fmt.Sprintf("Telp: %s | Email: %s", company.PhoneNumber, company.Email), gopdf.CellOption{Align: gopdf.Center})
+
+		currentY = startY + 70
+	}
+
+	pdf.SetLineWidth(1)
+	pdf.Line(marginLeft, currentY, marginRight, currentY)
+	currentY += 20
+
+	// --- SECTION: EMPLOYEE INFO ---
+	printInfo := func(x float64, y float64, label, value string) {
+		pdf.SetXY(x, y)
+		_ = pdf.SetFont("Roboto-Bold", "", 11)
+		_ = pdf.Cell(nil, label)
+
+		pdf.SetXY(x+70, y)
+		_ = pdf.SetFont("Roboto", "", 11)
+		_ = pdf.Cell(nil, ": "+value)
+	}
+
+	periodStr := payroll.periodLabel("January 2006")
+
+	printInfo(marginLeft, currentY, "Name", payroll.Employee.FullName)
 	printInfo(320, currentY, "Period", periodStr)
 	currentY += 20
 
-	printInfo(marginLeft, currentY, "NIK", payroll.Employee.NIK)
-	printInfo(320, currentY, "Status", string(payroll.Status))
-	currentY += 30
+	printInfo(marginLeft, currentY, "NIK", payroll.Employee.NIK)
+	printInfo(320, currentY, "Status", string(payroll.Status))
+	currentY += 20
+
+	npwp := payroll.Employee.NPWP
+	if npwp == "" {
+		npwp = "-"
+	}
+	ptkp := payroll.PTKPStatus
+	if ptkp == "" {
+		ptkp = "-"
+	}
+
+	printInfo(marginLeft, currentY, "NPWP", npwp)
+	printInfo(320, currentY, "PTKP", ptkp)
+	currentY += 30
+
+	// --- SECTION: PAYROLL TABLE ---
+	halfWidth := contentWidth / 2
+	col2X := marginLeft + halfWidth
+
+	// Header Tabel
+	pdf.SetFillColor(240, 240, 240)
+	pdf.RectFromUpperLeftWithStyle(marginLeft, currentY, halfWidth, 25, "F")
+	pdf.RectFromUpperLeftWithStyle(col2X, currentY, halfWidth, 25, "F")
+
+	pdf.SetTextColor(0, 0, 0)
+
+	pdf.SetXY(marginLeft, currentY)
+	_ = pdf.SetFont("Roboto-Bold", "", 11)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: halfWidth, H: 25}, "  EARNINGS (PENDAPATAN)", gopdf.CellOption{Border: gopdf.AllBorders, Align: gopdf.Middle | gopdf.Left})
+
+	pdf.SetXY(col2X, currentY)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: halfWidth, H: 25}, "  DEDUCTIONS (POTONGAN)", gopdf.CellOption{Border: gopdf.AllBorders, Align: gopdf.Middle | gopdf.Left})
+	currentY += 25
+
+	var earnings, deductions []PayrollDetail
+	for _, d := range payroll.Details {
+		if d.Type == constants.DetailTypeAllowance {
+			earnings = append(earnings, d)
+		} else {
+			deductions = append(deductions, d)
+		}
+	}
+
+	maxRows := len(earnings)
+	if len(deductions) > maxRows {
+		maxRows = len(deductions)
+	}
+
+	_ = pdf.SetFont("Roboto", "", 10)
+	formatCurrency := func(amount money.Money) string {
+		return fmt.Sprintf("Rp %s", amount.Format())
+	}
+
+	rowH := 20.0
+	labelW := 150.0
+	valueW := halfWidth - labelW
+
+	// Data Rows
+	for i := 0; i < maxRows; i++ {
+		// (Earnings)
+		pdf.SetXY(marginLeft, currentY)
+		if i < len(earnings) {
+			_ = pdf.CellWithOption(&gopdf.Rect{W: labelW, H: rowH}, "   "+earnings[i].Title, gopdf.CellOption{Border: gopdf.Left, Align: gopdf.Middle | gopdf.Left})
+			pdf.SetXY(marginLeft+labelW, currentY)
+			_ = pdf.CellWithOption(&gopdf.Rect{W: valueW, H: rowH}, formatCurrency(earnings[i].Amount)+"   ", gopdf.CellOption{Border: gopdf.Right, Align: gopdf.Middle | gopdf.Right})
+		} else {
+			_ = pdf.CellWithOption(&gopdf.Rect{W: labelW, H: rowH}, "", gopdf.CellOption{Border: gopdf.Left})
+			pdf.SetXY(marginLeft+labelW, currentY)
+			_ = pdf.CellWithOption(&gopdf.Rect{W: valueW, H: rowH}, "", gopdf.CellOption{Border: gopdf.Right})
+		}
+
+		// (Deductions)
+		pdf.SetXY(col2X, currentY)
+		if i < len(deductions) {
+			_ = pdf.CellWithOption(&gopdf.Rect{W: labelW, H: rowH}, "   "+deductions[i].Title, gopdf.CellOption{Border: gopdf.Left, Align: gopdf.Middle | gopdf.Left})
+			pdf.SetXY(col2X+labelW, currentY)
+			_ = pdf.CellWithOption(&gopdf.Rect{W: valueW, H: rowH}, formatCurrency(deductions[i].Amount)+"   ", gopdf.CellOption{Border: gopdf.Right, Align: gopdf.Middle | gopdf.Right})
+		} else {
+			_ = pdf.CellWithOption(&gopdf.Rect{W: labelW, H: rowH}, "", gopdf.CellOption{Border: gopdf.Left})
+			pdf.SetXY(col2X+labelW, currentY)
+			_ = pdf.CellWithOption(&gopdf.Rect{W: valueW, H: rowH}, "", gopdf.CellOption{Border: gopdf.Right})
+		}
+		currentY += rowH
+	}
+
+	// Total Row
+	_ = pdf.SetFont("Roboto-Bold", "", 11)
+
+	pdf.Line(marginLeft, currentY, marginRight, currentY)
+
+	pdf.SetXY(marginLeft, currentY)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: labelW, H: 25}, "   Total Earnings", gopdf.CellOption{Border: gopdf.Left | gopdf.Bottom, Align: gopdf.Middle | gopdf.Left})
+	pdf.SetXY(marginLeft+labelW, currentY)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: valueW, H: 25}, formatCurrency(payroll.TotalAllowance)+"   ", gopdf.CellOption{Border: gopdf.Right | gopdf.Bottom, Align: gopdf.Middle | gopdf.Right})
+
+	pdf.SetXY(col2X, currentY)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: labelW, H: 25}, "   Total Deductions", gopdf.CellOption{Border: gopdf.Left | gopdf.Bottom, Align: gopdf.Middle | gopdf.Left})
+	pdf.SetXY(col2X+labelW, currentY)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: valueW, H: 25}, formatCurrency(payroll.TotalDeduction)+"   ", gopdf.CellOption{Border: gopdf.Right | gopdf.Bottom, Align: gopdf.Middle | gopdf.Right})
+
+	currentY += 40
+
+	// --- SECTION: TAKE HOME PAY ---
+	thpLabelW := 350.0
+	thpValueW := contentWidth - thpLabelW
+
+	pdf.SetFillColor(220, 230, 241)
+	pdf.RectFromUpperLeftWithStyle(marginLeft, currentY, contentWidth, 30, "F")
+
+	pdf.SetTextColor(0, 0, 0)
+
+	_ = pdf.SetFont("Roboto-Bold", "", 14)
+	pdf.SetXY(marginLeft, currentY)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: thpLabelW, H: 30}, "   TAKE HOME PAY ", gopdf.CellOption{Border: gopdf.Left | gopdf.Top | gopdf.Bottom, Align: gopdf.Middle | gopdf.Left})
+
+	pdf.SetXY(marginLeft+thpLabelW, currentY)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: thpValueW, H: 30}, formatCurrency(payroll.NetSalary)+"   ", gopdf.CellOption{Border: gopdf.Right | gopdf.Top | gopdf.Bottom, Align: gopdf.Middle | gopdf.Right})
+
+	currentY += 80
+
+	// --- SECTION: SIGNATURE ---
+	signatureX := marginRight - 150.0
+	_ = pdf.SetFont("Roboto", "", 11)
+	pdf.SetXY(signatureX, currentY)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: 150, H: 15}, "Authorized Signature,", gopdf.CellOption{Align: gopdf.Center})
+
+	currentY += 70
+	_ = pdf.SetFont("Roboto-Bold", "", 11)
+	pdf.SetXY(signatureX, currentY)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: 150, H: 15}, "( HR Manager )", gopdf.CellOption{Border: gopdf.Top, Align: gopdf.Center})
+
+	return pdf, payroll, nil
+}
+
+func (s *service) MarkAsPaid(ctx context.Context, id uint) error {
+	var paid *Payroll
+	err := s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		// row lock keep concurrent payment from deducting the loan twice
+		payroll, err := s.repo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if payroll.Status == constants.PayrollStatusPaid {
+			return nil
+		}
+
+		if payroll.Status == constants.PayrollStatusVoid {
+			return errors.New("cannot pay void payroll")
+		}
+
+		// payroll inside a run can only be paid after the run approved
+		if payroll.PayrollRun != nil && !payroll.PayrollRun.IsLocked() {
+			return fmt.Errorf("payroll run must be approved before payment, current status %s", payroll.PayrollRun.Status)
+		}
+
+		if err := s.markPayrollPaid(ctx, payroll); err != nil {
+			return err
+		}
+
+		paid = payroll
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if paid != nil {
+		s.notifyPayrollPaid(paid)
+	}
+
+	return nil
+}
+
+func (s *service) markPayrollPaid(ctx context.Context, payroll *Payroll) error {
+	if err := s.repo.UpdateStatus(ctx, payroll.ID, constants.PayrollStatusPaid); err != nil {
+		return err
+	}
+
+	// reduce the same loan & amount the payroll deducted, reversePayment restore exactly this
+	if payroll.LoanID != nil && payroll.LoanDeduction > 0 {
+		deductedLoan, err := s.loan.FindByID(ctx, *payroll.LoanID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch deducted loan: %w", err)
+		}
+
+		deductedLoan.RemainingAmount -= payroll.LoanDeduction
+		if deductedLoan.RemainingAmount <= 0 {
+			deductedLoan.RemainingAmount = 0
+			deductedLoan.Status = constants.LoanStatusPaidOff
+		}
+
+		if err := s.loan.Update(ctx, deductedLoan); err != nil {
+			return fmt.Errorf("failed to update loan status: %w", err)
+		}
+	}
+
+	if !payroll.IsTHR() {
+		periodMonth := int(payroll.PeriodDate.Month())
+		periodYear := payroll.PeriodDate.Year()
+		if err := s.overtime.UpdateBulkStatusByEmployeeId(ctx, payroll.EmployeeID, periodMonth, periodYear, constants.OvertimeStatusPaid); err != nil {
+			return fmt.Errorf("failed to update overtimes status to paid: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// notifyPayrollPaid tell the employee the payroll is paid, only call it after the payment transaction is committed
+func (s *service) notifyPayrollPaid(payroll *Payroll) {
+	if payroll.Employee == nil {
+		return
+	}
+
+	userID := payroll.Employee.UserID
+	label := "Payroll"
+	if payroll.IsTHR() {
+		label = "THR"
+	} else if payroll.IsFinalSettlement() {
+		label = "Final settlement"
+	}
+
+	go func() {
+		_ = s.notification.SendNotification(
+			userID,
+			string(constants.NotificationTypePayrollPaid),
+			label+" Sudah dibayarkan",
+			fmt.Sprintf("%s %s sudah dibayarkan.", label, payroll.PeriodDate.Format(constants.PayrollTimeFormat)),
+			payroll.ID,
+		)
+	}()
+}
+
+func (s *service) GetRuns(ctx context.Context, filter *PayrollRunFilter) ([]PayrollRunResponse, *response.Meta, error) {
+	runs, total, err := s.repo.FindAllRuns(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(runs) == 0 {
+		return []PayrollRunResponse{}, nil, nil
+	}
+
+	var responses []PayrollRunResponse
+	for _, run := range runs {
+		responses = append(responses, toPayrollRunResponse(&run))
+	}
+
+	meta := response.NewMetaOffset(filter.Page, filter.Limit, total)
+	return responses, meta, nil
+}
+
+func (s *service) GetRunDetail(ctx context.Context, id uint) (*PayrollRunResponse, error) {
+	run, err := s.repo.FindRunByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toPayrollRunResponse(run)
+	resp.Payrolls = []PayrollListResponse{}
+
+	for _, p := range run.Payrolls {
+		empName := "Unknown"
+		empNIK := "-"
+		if p.Employee != nil {
+			empName = p.Employee.FullName
+			empNIK = p.Employee.NIK
+		}
+
+		resp.Payrolls = append(resp.Payrolls, PayrollListResponse{
+			ID:           p.ID,
+			EmployeeName: empName,
+			EmployeeNIK:  empNIK,
+			PeriodDate:   p.PeriodDate.Format(constants.DefaultTimeFormat),
+			Type:         string(p.Type),
+			NetSalary:    p.NetSalary,
+			Status:       string(p.Status),
+			CreatedAt:    p.CreatedAt,
+		})
+	}
+
+	return &resp, nil
+}
+
+func (s *service) ProcessRunAction(ctx context.Context, req *PayrollRunActionRequest) error {
+	var paid []*Payroll
+	err := s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		run, err := s.repo.FindRunByID(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+
+		switch constants.PayrollRunAction(req.Action) {
+		case constants.PayrollRunActionReview:
+			if run.Status != constants.PayrollRunStatusDraft {
+				return fmt.Errorf("cannot review payroll run with status %s", run.Status)
+			}
+
+			if len(run.Payrolls) == 0 {
+				return fmt.Errorf("payroll run has no payrolls")
+			}
+
+			run.Status = constants.PayrollRunStatusReviewed
+			run.ReviewedBy = &req.SuperAdminID
+			run.ReviewedAt = &now
+		case constants.PayrollRunActionApprove:
+			if run.Status != constants.PayrollRunStatusReviewed {
+				return fmt.Errorf("cannot approve payroll run with status %s", run.Status)
+			}
+
+			run.Status = constants.PayrollRunStatusApproved
+			run.ApprovedBy = &req.SuperAdminID
+			run.ApprovedAt = &now
+		case constants.PayrollRunActionPay:
+			if run.Status != constants.PayrollRunStatusApproved {
+				return fmt.Errorf("cannot pay payroll run with status %s", run.Status)
+			}
+
+			// pay every payroll of this run in the same transaction, so partial payment never happen
+			for _, p := range run.Payrolls {
+				// status is checked again under row lock, a single payment may have paid or void it meanwhile
+				payroll, err := s.repo.FindByIDForUpdate(ctx, p.ID)
+				if err != nil {
+					return err
+				}
+				if payroll.Status == constants.PayrollStatusPaid || payroll.Status == constants.PayrollStatusVoid {
+					continue
+				}
+
+				if err := s.markPayrollPaid(ctx, payroll); err != nil {
+					return fmt.Errorf("failed to pay payroll %d: %w", payroll.ID, err)
+				}
+				paid = append(paid, payroll)
+			}
+
+			run.Status = constants.PayrollRunStatusPaid
+			run.PaidBy = &req.SuperAdminID
+			run.PaidAt = &now
+		case constants.PayrollRunActionClose:
+			if run.Status != constants.PayrollRunStatusPaid {
+				return fmt.Errorf("cannot close payroll run with status %s", run.Status)
+			}
+
+			run.Status = constants.PayrollRunStatusClosed
+			run.ClosedBy = &req.SuperAdminID
+			run.ClosedAt = &now
+		default:
+			return fmt.Errorf("invalid action: %s", req.Action)
+		}
+
+		return s.repo.UpdateRun(ctx, run)
+	})
+	if err != nil {
+		return err
+	}
+
+	// employees are only told once the whole run is committed
+	for _, payroll := range paid {
+		s.notifyPayrollPaid(payroll)
+	}
+
+	return nil
+}
+
+func toPayrollRunResponse(run *PayrollRun) PayrollRunResponse {
+	return PayrollRunResponse{
+		ID:             run.ID,
+		PeriodDate:     run.PeriodDate.Format(constants.DefaultTimeFormat),
+		Type:           string(run.Type),
+		Status:         string(run.Status),
+		TotalEmployee:  run.TotalEmployee,
+		TotalNetSalary: run.TotalNetSalary,
+		ReviewedAt:     run.ReviewedAt,
+		ApprovedAt:     run.ApprovedAt,
+		PaidAt:         run.PaidAt,
+		ClosedAt:       run.ClosedAt,
+		CreatedAt:      run.CreatedAt,
+	}
+}
+
+func (s *service) BlastPayslipEmail(ctx context.Context, id uint) error {
+	pdfBytes, payroll, err := s.generatePayslipPDFBytes(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed generate pdf: %w", err)
+	}
+
+	if payroll.Status != constants.PayrollStatusPaid {
+		return fmt.Errorf("payroll status must paid")
+	}
+
+	if payroll.Employee.Email == "" {
+		return fmt.Errorf("email required, make sure to update first")
+	}
+
+	company, err := s.company.FindByID(ctx, 1)
+	if err != nil {
+		return err
+	}
+
+	passwordNote := ""
+	if company.PayslipPasswordEnabled {
+		passwordNote = fmt.Sprintf("<p>Dokumen ini dilindungi kata sandi, gunakan %s untuk membukanya.</p>", payslipPasswordHint(company.PayslipPasswordRule))
+	}
+
+	periodStr := payroll.periodLabel(constants.PayrollTimeFormat)
+
+	subject := fmt.Sprintf("Payslip: %s - %s", periodStr, payroll.Employee.FullName)
+	fileName := fmt.Sprintf("Payslip_%s_%s.pdf", strings.ReplaceAll(payroll.Employee.FullName, " ", "-"), payroll.payslipPeriod())
+
+	htmlBody := fmt.Sprintf(`
+		<h3>Hello %s,</h3>
+		<p>Terlampir adalah slip gaji Anda untuk periode <strong>%s</strong>.</p>
+		%s
+		<p>Harap jaga kerahasiaan dokumen ini. Jika ada pertanyaan, silakan hubungi tim HR.</p>
+		<br>
+		<p>Salam,</p>
+		<p><strong>HR Manager</strong></p>
+	`, payroll.Employee.FullName, periodStr, passwordNote)
+
+	err = s.email.SendWithAttachment(
+		payroll.Employee.Email,
+		subject,
+		htmlBody,
+		fileName,
+		pdfBytes,
+	)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", errEmailDelivery, payroll.Employee.Email, err)
+	}
+
+	return nil
+}
+
+// CreatePayslipEmailJob queue every paid payslip of the period, sending is done by PayslipEmailWorker
+func (s *service) CreatePayslipEmailJob(ctx context.Context, req *SendPayslipEmailRequest) (*PayslipEmailJob, error) {
+	active, err := s.repo.HasActiveEmailJob(ctx, req.Month, req.Year)
+	if err != nil {
+		return nil, err
+	}
+
+	if active {
+		return nil, errors.New("payslip email of this period is still being sent")
+	}
+
+	payrolls, err := s.repo.FindAllByPeriodAndStatus(ctx, req.Month, req.Year, constants.PayrollStatusPaid)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payrolls) == 0 {
+		return nil, errors.New("no paid payroll found on this period")
+	}
+
+	job := PayslipEmailJob{
+		PeriodDate: time.Date(req.Year, time.Month(req.Month), 1, 0, 0, 0, 0, time.Local),
+		Status:     constants.PayslipEmailJobStatusQueued,
+		TotalCount: len(payrolls),
+		CreatedBy:  req.ActorID,
+	}
+
+	for _, p := range payrolls {
+		delivery := PayslipEmailDelivery{
+			PayrollID:  p.ID,
+			EmployeeID: p.EmployeeID,
+			Status:     constants.PayslipEmailDeliveryStatusPending,
+		}
+
+		if p.Employee != nil {
+			delivery.Email = p.Employee.Email
+		}
+
+		job.Deliveries = append(job.Deliveries, delivery)
+	}
+
+	if err := s.repo.CreateEmailJob(ctx, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// RetryPayslipEmailJob put failed deliveries of a completed job back to pending
+func (s *service) RetryPayslipEmailJob(ctx context.Context, id uint) (*PayslipEmailJob, error) {
+	job, err := s.repo.FindEmailJobByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status != constants.PayslipEmailJobStatusCompleted {
+		return nil, errors.New("payslip email job is still running")
+	}
+
+	if job.FailedCount == 0 {
+		return nil, errors.New("no failed payslip email to retry")
+	}
+
+	err = s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.ResetFailedEmailDeliveries(ctx, job.ID); err != nil {
+			return err
+		}
+
+		if err := s.repo.RefreshEmailJobCounts(ctx, job.ID); err != nil {
+			return err
+		}
+
+		job.Status = constants.PayslipEmailJobStatusQueued
+		job.FinishedAt = nil
+
+		return s.repo.UpdateEmailJob(ctx, job)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindEmailJobByID(ctx, job.ID)
+}
+
+func (s *service) GetPayslipEmailJobs(ctx context.Context, month, year int) ([]PayslipEmailJobResponse, error) {
+	jobs, err := s.repo.FindEmailJobsByPeriod(ctx, month, year)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []PayslipEmailJobResponse{}
+	for _, job := range jobs {
+		responses = append(responses, toPayslipEmailJobResponse(&job))
+	}
+
+	return responses, nil
+}
+
+func (s *service) GetPayslipEmailJobDetail(ctx context.Context, id uint) (*PayslipEmailJobResponse, error) {
+	job, err := s.repo.FindEmailJobByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toPayslipEmailJobResponse(job)
+	for _, d := range job.Deliveries {
+		delivery := PayslipEmailDeliveryResponse{
+			ID:           d.ID,
+			PayrollID:    d.PayrollID,
+			Email:        d.Email,
+			Status:       string(d.Status),
+			Attempts:     d.Attempts,
+			ErrorMessage: d.ErrorMessage,
+			SentAt:       d.SentAt,
+		}
+
+		if d.Employee != nil {
+			delivery.EmployeeName = d.Employee.FullName
+			delivery.EmployeeNIK = d.Employee.NIK
+		}
+
+		resp.Deliveries = append(resp.Deliveries, delivery)
+	}
+
+	return &resp, nil
+}
+
+func toPayslipEmailJobResponse(job *PayslipEmailJob) PayslipEmailJobResponse {
+	return PayslipEmailJobResponse{
+		ID:          job.ID,
+		PeriodDate:  job.PeriodDate.Format(constants.DefaultTimeFormat),
+		Status:      string(job.Status),
+		TotalCount:  job.TotalCount,
+		SentCount:   job.SentCount,
+		FailedCount: job.FailedCount,
+		CreatedAt:   job.CreatedAt,
+		FinishedAt:  job.FinishedAt,
+	}
+}
+
+func (s *service) ExportBPJSRecap(ctx context.Context, month, year int) ([]byte, error) {
+	payrolls, err := s.repo.FindAllWithContributions(ctx, month, year, constants.PayrollTypeRegular, constants.PayrollTypeFinalSettlement)
+	if err != nil {
+		return nil, err
+	}
+
+	f := s.excel.NewFile()
+
+	// BPJS Kesehatan & BPJS Ketenagakerjaan are uploaded to different portal, so split into two sheets
+	healthSheet := "BPJS Kesehatan"
+	laborSheet := "BPJS Ketenagakerjaan"
+	f.SetSheetName("Sheet1", healthSheet)
+	if _, err := f.NewSheet(laborSheet); err != nil {
+		return nil, err
+	}
+
+	healthHeaders := []interface{}{"No", "NIK", "Nama Karyawan", "Upah", "Iuran Pekerja", "Iuran Pemberi Kerja", "Total Iuran"}
+	laborHeaders := []interface{}{"No", "NIK", "Nama Karyawan", "Upah", "JHT Pekerja", "JHT Pemberi Kerja", "JP Pekerja", "JP Pemberi Kerja", "JKK", "JKM", "Total Iuran"}
+
+	if err := f.SetSheetRow(healthSheet, "A1", &healthHeaders); err != nil {
+		return nil, err
+	}
+	if err := f.SetSheetRow(laborSheet, "A1", &laborHeaders); err != nil {
+		return nil, err
+	}
+
+	healthRow, laborRow := 2, 2
+	for _, p := range payrolls {
+		if p.Employee == nil || len(p.Contributions) == 0 {
+			continue
+		}
+
+		contributions := make(map[constants.BPJSProgram]PayrollContribution)
+		for _, c := range p.Contributions {
+			contributions[c.Program] = c
+		}
+
+		if c, ok := contributions[constants.BPJSProgramKesehatan]; ok {
+			row := []interface{}{
+				healthRow - 1,
+				p.Employee.NIK,
+				p.Employee.FullName,
+				c.BaseAmount.Float64(),
+				c.EmployeeAmount.Float64(),
+				c.EmployerAmount.Float64(),
+				(c.EmployeeAmount + c.EmployerAmount).Float64(),
+			}
+			if err := f.SetSheetRow(healthSheet, fmt.Sprintf("A%d", healthRow), &row); err != nil {
+				return nil, err
+			}
+			healthRow++
+		}
+
+		jht := contributions[constants.BPJSProgramJHT]
+		jp := contributions[constants.BPJSProgramJP]
+		jkk := contributions[constants.BPJSProgramJKK]
+		jkm := contributions[constants.BPJSProgramJKM]
+
+		total := jht.EmployeeAmount + jht.EmployerAmount + jp.EmployeeAmount + jp.EmployerAmount + jkk.EmployerAmount + jkm.EmployerAmount
+		if total == 0 {
+			continue
+		}
+
+		row := []interface{}{
+			laborRow - 1,
+			p.Employee.NIK,
+			p.Employee.FullName,
+			p.BaseSalary.Float64(),
+			jht.EmployeeAmount.Float64(),
+			jht.EmployerAmount.Float64(),
+			jp.EmployeeAmount.Float64(),
+			jp.EmployerAmount.Float64(),
+			jkk.EmployerAmount.Float64(),
+			jkm.EmployerAmount.Float64(),
+			total.Float64(),
+		}
+		if err := f.SetSheetRow(laborSheet, fmt.Sprintf("A%d", laborRow), &row); err != nil {
+			return nil, err
+		}
+		laborRow++
+	}
+
+	return s.excel.WriteToBuffer(f)
+}
+
+// ExportBankTransfer produce bulk transfer file of a period for a bank, default to the company source bank.
+// Employees of other supported banks are left out since they go to that bank's file.
+func (s *service) ExportBankTransfer(ctx context.Context, month, year int, bank string, payrollType constants.PayrollType) ([]byte, string, error) {
+	company, err := s.company.FindByID(ctx, 1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if company.BankAccountNumber == "" {
+		return nil, "", errors.New("company bank account is not set")
+	}
+
+	if bank == "" {
+		bank = company.BankName
+	}
+
+	formatter, ok := bankTransferFormatters[resolveBankCode(bank)]
+	if !ok {
+		return nil, "", fmt.Errorf("bank %s is not supported", bank)
+	}
+
+	payrolls, err := s.repo.FindAllWithContributions(ctx, month, year, payrollType)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var rows []bankTransferRow
+	var issues []BankTransferIssue
+	for _, p := range payrolls {
+		if p.Employee == nil || p.NetSalary <= 0 {
+			continue
+		}
+
+		issue := BankTransferIssue{
+			PayrollID:    p.ID,
+			EmployeeNIK:  p.Employee.NIK,
+			EmployeeName: p.Employee.FullName,
+		}
+
+		bankCode := resolveBankCode(p.Employee.BankName)
+		employeeFormatter, supported := bankTransferFormatters[bankCode]
+
+		switch {
+		case p.Employee.BankName == "":
+			issue.Reason = "bank name is empty"
+		case !supported:
+			issue.Reason = fmt.Sprintf("bank %s is not supported", p.Employee.BankName)
+		case employeeFormatter.Code() != formatter.Code():
+			continue
+		case p.Employee.BankAccountNumber == "":
+			issue.Reason = "bank account number is empty"
+		case p.Employee.BankAccountHolder == "":
+			issue.Reason = "bank account holder is empty"
+		default:
+			if err := formatter.ValidateAccountNumber(p.Employee.BankAccountNumber); err != nil {
+				issue.Reason = err.Error()
+			}
+		}
+
+		if issue.Reason != "" {
+			issues = append(issues, issue)
+			continue
+		}
+
+		rows = append(rows, bankTransferRow{
+			PayrollID:     p.ID,
+			EmployeeNIK:   p.Employee.NIK,
+			EmployeeName:  p.Employee.FullName,
+			AccountNumber: p.Employee.BankAccountNumber,
+			AccountHolder: p.Employee.BankAccountHolder,
+			Amount:        p.NetSalary,
+		})
+	}
+
+	if len(issues) > 0 {
+		return nil, "", &BankTransferValidationError{Issues: issues}
+	}
+
+	if len(rows) == 0 {
+		return nil, "", errors.New("no payroll to transfer on this period")
+	}
+
+	header := bankTransferHeader{
+		CompanyName:   company.Name,
+		AccountNumber: company.BankAccountNumber,
+		PeriodDate:    time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local),
+		TransferDate:  time.Now(),
+	}
+
+	file, err := formatter.Format(header, rows)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return file, formatter.Code(), nil
+}
+
+// ComparePeriods compare payroll of two periods per employee, used as review before approving a period
+func (s *service) ComparePeriods(ctx context.Context, req *PayrollComparisonRequest) (*PayrollComparisonResponse, error) {
+	previous, err := s.repo.FindAllWithDetails(ctx, req.BaseMonth, req.BaseYear)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.repo.FindAllWithDetails(ctx, req.Month, req.Year)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &PayrollComparisonResponse{
+		BasePeriod: time.Date(req.BaseYear, time.Month(req.BaseMonth), 1, 0, 0, 0, 0, time.Local).Format(constants.DefaultTimeFormat),
+		Period:     time.Date(req.Year, time.Month(req.Month), 1, 0, 0, 0, 0, time.Local).Format(constants.DefaultTimeFormat),
+		Threshold:  req.Threshold,
+		Employees:  comparePayrolls(previous, current, req.Threshold),
+	}
+
+	for _, e := range resp.Employees {
+		resp.TotalBaseNetSalary += e.NetSalary.Previous
+		resp.TotalNetSalary += e.NetSalary.Current
+	}
+	resp.TotalDelta = resp.TotalNetSalary - resp.TotalBaseNetSalary
+
+	return resp, nil
+}
+
+func (s *service) ExportComparison(ctx context.Context, req *PayrollComparisonRequest) ([]byte, error) {
+	comparison, err := s.ComparePeriods(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	basePeriod := time.Date(req.BaseYear, time.Month(req.BaseMonth), 1, 0, 0, 0, 0, time.Local).Format("Jan 2006")
+	period := time.Date(req.Year, time.Month(req.Month), 1, 0, 0, 0, 0, time.Local).Format("Jan 2006")
+
+	f := s.excel.NewFile()
+
+	summarySheet := "Ringkasan"
+	detailSheet := "Rincian"
+	f.SetSheetName("Sheet1", summarySheet)
+	if _, err := f.NewSheet(detailSheet); err != nil {
+		return nil, err
+	}
+
+	summaryHeaders := []interface{}{
+		"No", "NIK", "Nama Karyawan", "Keterangan",
+		"Gaji Pokok " + basePeriod, "Gaji Pokok " + period, "Selisih Gaji Pokok", "Selisih Gaji Pokok (%)",
+		"Gaji Bersih " + basePeriod, "Gaji Bersih " + period, "Selisih Gaji Bersih", "Selisih Gaji Bersih (%)",
+	}
+	detailHeaders := []interface{}{"NIK", "Nama Karyawan", "Komponen", "Tipe", basePeriod, period, "Selisih", "Selisih (%)"}
+
+	if err := f.SetSheetRow(summarySheet, "A1", &summaryHeaders); err != nil {
+		return nil, err
+	}
+	if err := f.SetSheetRow(detailSheet, "A1", &detailHeaders); err != nil {
+		return nil, err
+	}
+
+	// flagged employee is highlighted so reviewer can spot it quickly
+	flaggedStyle, err := f.NewStyle(&excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"FFF2CC"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	detailRow := 2
+	for i, e := range comparison.Employees {
+		flags := make([]string, 0, len(e.Flags))
+		for _, flag := range e.Flags {
+			flags = append(flags, string(flag))
+		}
+
+		summaryRow := i + 2
+		row := []interface{}{
+			i + 1,
+			e.EmployeeNIK,
+			e.EmployeeName,
+			strings.Join(flags, ", "),
+			e.BaseSalary.Previous.Float64(),
+			e.BaseSalary.Current.Float64(),
+			e.BaseSalary.Delta.Float64(),
+			varianceCell(e.BaseSalary.DeltaPercent),
+			e.NetSalary.Previous.Float64(),
+			e.NetSalary.Current.Float64(),
+			e.NetSalary.Delta.Float64(),
+			varianceCell(e.NetSalary.DeltaPercent),
+		}
+		if err := f.SetSheetRow(summarySheet, fmt.Sprintf("A%d", summaryRow), &row); err != nil {
+			return nil, err
+		}
+
+		if len(e.Flags) > 0 {
+			if err := f.SetCellStyle(summarySheet, fmt.Sprintf("A%d", summaryRow), fmt.Sprintf("L%d", summaryRow), flaggedStyle); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, d := range e.Details {
+			row := []interface{}{
+				e.EmployeeNIK,
+				e.EmployeeName,
+				d.Title,
+				string(d.Type),
+				d.Previous.Float64(),
+				d.Current.Float64(),
+				d.Delta.Float64(),
+				varianceCell(d.DeltaPercent),
+			}
+			if err := f.SetSheetRow(detailSheet, fmt.Sprintf("A%d", detailRow), &row); err != nil {
+				return nil, err
+			}
+			detailRow++
+		}
+	}
+
+	return s.excel.WriteToBuffer(f)
+}
+
+// varianceCell leave the cell blank when percentage cannot be calculated
+func varianceCell(percent *float64) interface{} {
+	if percent == nil {
+		return ""
+	}
+
+	return *percent
+}
+
+// ExportJournal turn a payroll period into a balanced double-entry journal as CSV or generic JSON
+func (s *service) ExportJournal(ctx context.Context, month, year int, format constants.JournalExportFormat) ([]byte, error) {
+	accounts, err := s.repo.FindJournalAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings, err := s.repo.FindJournalMappings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payrolls, err := s.repo.FindAllWithDetails(ctx, month, year)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := newJournalBuilder(accounts, mappings)
+	for i := range payrolls {
+		if err := builder.addPayroll(&payrolls[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	journal, err := builder.build(time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		return nil, err
+	}
+
+	if format == constants.JournalExportFormatJSON {
+		return journal.JSON()
+	}
+
+	return journal.CSV()
+}
+
+func (s *service) GetJournalSetting(ctx context.Context) (*JournalSettingResponse, error) {
+	accounts, err := s.repo.FindJournalAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings, err := s.repo.FindJournalMappings(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	// --- SECTION: PAYROLL TABLE ---
-	halfWidth := contentWidth / 2
-	col2X := marginLeft + halfWidth
+	return &JournalSettingResponse{
+		Accounts: accounts,
+		Mappings: mappings,
+	}, nil
+}
 
-	// Header Tabel
-	pdf.SetFillColor(240, 240, 240)
-	pdf.RectFromUpperLeftWithStyle(marginLeft, currentY, halfWidth, 25, "F")
-	pdf.RectFromUpperLeftWithStyle(col2X, currentY, halfWidth, 25, "F")
+func (s *service) UpdateJournalAccount(ctx context.Context, req *UpdateJournalAccountRequest) error {
+	account, err := s.repo.FindJournalAccountByID(ctx, req.ID)
+	if err != nil {
+		return errors.New("journal account not found")
+	}
 
-	pdf.SetTextColor(0, 0, 0)
+	account.Code = strings.TrimSpace(req.Code)
+	account.Name = strings.TrimSpace(req.Name)
 
-	pdf.SetXY(marginLeft, currentY)
-	_ = pdf.SetFont("Roboto-Bold", "", 11)
-	_ = pdf.CellWithOption(&gopdf.Rect{W: halfWidth, H: 25}, "  EARNINGS (PENDAPATAN)", gopdf.CellOption{Border: gopdf.AllBorders, Align: gopdf.Middle | gopdf.Left})
+	return s.repo.UpdateJournalAccount(ctx, account)
+}
 
-	pdf.SetXY(col2X, currentY)
-	_ = pdf.CellWithOption(&gopdf.Rect{W: halfWidth, H: 25}, "  DEDUCTIONS (POTONGAN)", gopdf.CellOption{Border: gopdf.AllBorders, Align: gopdf.Middle | gopdf.Left})
-	currentY += 25
+func (s *service) ReplaceJournalMappings(ctx context.Context, req *ReplaceJournalMappingsRequest) error {
+	accounts, err := s.repo.FindJournalAccounts(ctx)
+	if err != nil {
+		return err
+	}
 
-	var earnings, deductions []PayrollDetail
-	for _, d := range payroll.Details {
-		if d.Type == constants.DetailTypeAllowance {
-			earnings = append(earnings, d)
-		} else {
-			deductions = append(deductions, d)
+	accountKeys := make(map[constants.JournalAccountKey]bool)
+	for _, a := range accounts {
+		accountKeys[a.AccountKey] = true
+	}
+
+	mappings := make([]JournalAccountMapping, 0, len(req.Mappings))
+	for _, m := range req.Mappings {
+		key := constants.JournalAccountKey(m.AccountKey)
+		if !accountKeys[key] {
+			return fmt.Errorf("journal account %s is not found", m.AccountKey)
 		}
+
+		mappings = append(mappings, JournalAccountMapping{
+			DetailType:  constants.PayrollDetailType(m.DetailType),
+			TitlePrefix: strings.TrimSpace(m.TitlePrefix),
+			AccountKey:  key,
+		})
 	}
 
-	maxRows := len(earnings)
-	if len(deductions) > maxRows {
-		maxRows = len(deductions)
+	return s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		return s.repo.ReplaceJournalMappings(ctx, mappings)
+	})
+}
+
+// taxForms build 1721-A1 of the year, employeeID zero mean every employee with payroll on the year
+func (s *service) taxForms(ctx context.Context, year int, employeeID uint) ([]TaxForm, *company.Company, error) {
+	payrolls, err := s.repo.FindAllByYear(ctx, year, employeeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch payrolls of the year: %w", err)
 	}
 
-	_ = pdf.SetFont("Roboto", "", 10)
-	formatCurrency := func(amount float64) string {
-		return fmt.Sprintf("Rp %s", utils.FormatNumber(amount))
+	forms := buildTaxForms(payrolls, year)
+	if len(forms) == 0 {
+		return nil, nil, gorm.ErrRecordNotFound
 	}
 
-	rowH := 20.0
-	labelW := 150.0
-	valueW := halfWidth - labelW
+	comp, err := s.company.FindByID(ctx, 1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch company: %w", err)
+	}
 
-	// Data Rows
-	for i := 0; i < maxRows; i++ {
-		// (Earnings)
-		pdf.SetXY(marginLeft, currentY)
-		if i < len(earnings) {
-			_ = pdf.CellWithOption(&gopdf.Rect{W: labelW, H: rowH}, "   "+earnings[i].Title, gopdf.CellOption{Border: gopdf.Left, Align: gopdf.Middle | gopdf.Left})
-			pdf.SetXY(marginLeft+labelW, currentY)
-			_ = pdf.CellWithOption(&gopdf.Rect{W: valueW, H: rowH}, formatCurrency(earnings[i].Amount)+"   ", gopdf.CellOption{Border: gopdf.Right, Align: gopdf.Middle | gopdf.Right})
-		} else {
-			_ = pdf.CellWithOption(&gopdf.Rect{W: labelW, H: rowH}, "", gopdf.CellOption{Border: gopdf.Left})
-			pdf.SetXY(marginLeft+labelW, currentY)
-			_ = pdf.CellWithOption(&gopdf.Rect{W: valueW, H: rowH}, "", gopdf.CellOption{Border: gopdf.Right})
+	return forms, comp, nil
+}
+
+func (s *service) GenerateTaxFormPDF(ctx context.Context, employeeID uint, year int) ([]byte, *TaxForm, error) {
+	forms, comp, err := s.taxForms(ctx, year, employeeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	form := &forms[0]
+	pdfBytes, err := renderTaxFormPDF(comp, form)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pdfBytes, form, nil
+}
+
+// ExportTaxForms bundle 1721-A1 of every employee of the year into a zip
+func (s *service) ExportTaxForms(ctx context.Context, year int) ([]byte, error) {
+	forms, comp, err := s.taxForms(ctx, year, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buf)
+
+	for i := range forms {
+		pdfBytes, err := renderTaxFormPDF(comp, &forms[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate 1721-A1 of %s: %w", forms[i].Employee.FullName, err)
 		}
 
-		// (Deductions)
-		pdf.SetXY(col2X, currentY)
-		if i < len(deductions) {
-			_ = pdf.CellWithOption(&gopdf.Rect{W: labelW, H: rowH}, "   "+deductions[i].Title, gopdf.CellOption{Border: gopdf.Left, Align: gopdf.Middle | gopdf.Left})
-			pdf.SetXY(col2X+labelW, currentY)
-			_ = pdf.CellWithOption(&gopdf.Rect{W: valueW, H: rowH}, formatCurrency(deductions[i].Amount)+"   ", gopdf.CellOption{Border: gopdf.Right, Align: gopdf.Middle | gopdf.Right})
-		} else {
-			_ = pdf.CellWithOption(&gopdf.Rect{W: labelW, H: rowH}, "", gopdf.CellOption{Border: gopdf.Left})
-			pdf.SetXY(col2X+labelW, currentY)
-			_ = pdf.CellWithOption(&gopdf.Rect{W: valueW, H: rowH}, "", gopdf.CellOption{Border: gopdf.Right})
+		w, err := zipWriter.Create(forms[i].FileName())
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := w.Write(pdfBytes); err != nil {
+			return nil, err
 		}
-		currentY += rowH
 	}
 
-	// Total Row
-	_ = pdf.SetFont("Roboto-Bold", "", 11)
+	if err := zipWriter.Close(); err != nil {
+		return nil, err
+	}
 
-	pdf.Line(marginLeft, currentY, marginRight, currentY)
+	return buf.Bytes(), nil
+}
 
-	pdf.SetXY(marginLeft, currentY)
-	_ = pdf.CellWithOption(&gopdf.Rect{W: labelW, H: 25}, "   Total Earnings", gopdf.CellOption{Border: gopdf.Left | gopdf.Bottom, Align: gopdf.Middle | gopdf.Left})
-	pdf.SetXY(marginLeft+labelW, currentY)
-	_ = pdf.CellWithOption(&gopdf.Rect{W: valueW, H: 25}, formatCurrency(payroll.TotalAllowance)+"   ", gopdf.CellOption{Border: gopdf.Right | gopdf.Bottom, Align: gopdf.Middle | gopdf.Right})
+// SendTaxFormEmails email 1721-A1 to every employee of the year or a single employee,
+// a failed delivery does not stop the others & is reported back per employee
+func (s *service) SendTaxFormEmails(ctx context.Context, req *SendTaxFormEmailRequest) (*SendTaxFormEmailResponse, error) {
+	forms, comp, err := s.taxForms(ctx, req.Year, req.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
 
-	pdf.SetXY(col2X, currentY)
-	_ = pdf.CellWithOption(&gopdf.Rect{W: labelW, H: 25}, "   Total Deductions", gopdf.CellOption{Border: gopdf.Left | gopdf.Bottom, Align: gopdf.Middle | gopdf.Left})
-	pdf.SetXY(col2X+labelW, currentY)
-	_ = pdf.CellWithOption(&gopdf.Rect{W: valueW, H: 25}, formatCurrency(payroll.TotalDeduction)+"   ", gopdf.CellOption{Border: gopdf.Right | gopdf.Bottom, Align: gopdf.Middle | gopdf.Right})
+	passwordNote := ""
+	if comp.PayslipPasswordEnabled {
+		passwordNote = fmt.Sprintf("<p>Dokumen ini dilindungi kata sandi, gunakan %s untuk membukanya.</p>", payslipPasswordHint(comp.PayslipPasswordRule))
+	}
 
-	currentY += 40
+	resp := &SendTaxFormEmailResponse{
+		Year:       req.Year,
+		TotalCount: len(forms),
+		Results:    []TaxFormEmailResult{},
+	}
 
-	// --- SECTION: TAKE HOME PAY ---
-	thpLabelW := 350.0
-	thpValueW := contentWidth - thpLabelW
+	for i := range forms {
+		form := &forms[i]
+		result := TaxFormEmailResult{
+			EmployeeID:   form.Employee.ID,
+			EmployeeName: form.Employee.FullName,
+			EmployeeNIK:  form.Employee.NIK,
+			Email:        form.Employee.Email,
+			Status:       string(constants.PayslipEmailDeliveryStatusSent),
+		}
 
-	pdf.SetFillColor(220, 230, 241)
-	pdf.RectFromUpperLeftWithStyle(marginLeft, currentY, contentWidth, 30, "F")
+		if err := s.sendTaxFormEmail(comp, form, passwordNote); err != nil {
+			result.Status = string(constants.PayslipEmailDeliveryStatusFailed)
+			result.ErrorMessage = err.Error()
+			resp.FailedCount++
+		} else {
+			resp.SentCount++
+		}
 
-	pdf.SetTextColor(0, 0, 0)
+		resp.Results = append(resp.Results, result)
+	}
 
-	_ = pdf.SetFont("Roboto-Bold", "", 14)
-	pdf.SetXY(marginLeft, currentY)
-	_ = pdf.CellWithOption(&gopdf.Rect{W: thpLabelW, H: 30}, "   TAKE HOME PAY ", gopdf.CellOption{Border: gopdf.Left | gopdf.Top | gopdf.Bottom, Align: gopdf.Middle | gopdf.Left})
+	return resp, nil
+}
 
-	pdf.SetXY(marginLeft+thpLabelW, currentY)
-	_ = pdf.CellWithOption(&gopdf.Rect{W: thpValueW, H: 30}, formatCurrency(payroll.NetSalary)+"   ", gopdf.CellOption{Border: gopdf.Right | gopdf.Top | gopdf.Bottom, Align: gopdf.Middle | gopdf.Right})
+func (s *service) sendTaxFormEmail(comp *company.Company, form *TaxForm, passwordNote string) error {
+	if form.Employee.Email == "" {
+		return errors.New("email required, make sure to update first")
+	}
 
-	currentY += 80
+	pdfBytes, err := renderTaxFormPDF(comp, form)
+	if err != nil {
+		return err
+	}
 
-	// --- SECTION: SIGNATURE ---
-	signatureX := marginRight - 150.0
-	_ = pdf.SetFont("Roboto", "", 11)
-	pdf.SetXY(signatureX, currentY)
-	_ = pdf.CellWithOption(&gopdf.Rect{W: 150, H: 15}, "Authorized Signature,", gopdf.CellOption{Align: gopdf.Center})
+	subject := fmt.Sprintf("Bukti Potong PPh 21 (1721-A1) %d - %s", form.Year, form.Employee.FullName)
+	htmlBody := fmt.Sprintf(`
+		<h3>Hello %s,</h3>
+		<p>Terlampir adalah bukti potong PPh 21 (formulir 1721-A1) Anda untuk tahun pajak <strong>%d</strong>.</p>
+		%s
+		<p>Gunakan dokumen ini untuk pelaporan SPT Tahunan Anda. Jika ada pertanyaan, silakan hubungi tim HR.</p>
+		<br>
+		<p>Salam,</p>
+		<p><strong>HR Manager</strong></p>
+	`, form.Employee.FullName, form.Year, passwordNote)
 
-	currentY += 70
-	_ = pdf.SetFont("Roboto-Bold", "", 11)
-	pdf.SetXY(signatureX, currentY)
-	_ = pdf.CellWithOption(&gopdf.Rect{W: 150, H: 15}, "( HR Manager )", gopdf.CellOption{Border: gopdf.Top, Align: gopdf.Center})
+	return s.email.SendWithAttachment(form.Employee.Email, subject, htmlBody, form.FileName(), pdfBytes)
+}
 
-	return pdf, payroll, nil
+func (s *service) generatePayslipPDFBytes(ctx context.Context, id uint) ([]byte, *Payroll, error) {
+	pdf, payroll, err := s.GeneratePayslipPDF(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pdfBytes := pdf.GetBytesPdf()
+
+	return pdfBytes, payroll, nil
 }
 
-func (s *service) MarkAsPaid(ctx context.Context, id uint) error {
-	return s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
-		payroll, err := s.repo.FindByID(id)
+// CreateFinalSettlement end the employment of an employee & create the payroll that settle its last salary,
+// unused leave, outstanding loan & severance. the employee account is deactivated instead of deleted
+func (s *service) CreateFinalSettlement(ctx context.Context, req *FinalSettlementRequest) (*FinalSettlementResponse, error) {
+	endDate, err := time.ParseInLocation(constants.DefaultTimeFormat, req.EndDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date: %w", err)
+	}
+
+	emp, err := s.user.FindEmployeeByID(ctx, req.EmployeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch employee: %w", err)
+	}
+
+	if emp.JoinDate != nil && endDate.Before(truncateDate(*emp.JoinDate)) {
+		return nil, errors.New("end date cannot be before join date")
+	}
+
+	hasSettlement, err := s.repo.HasActiveFinalSettlement(ctx, emp.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing final settlement: %w", err)
+	}
+
+	if hasSettlement {
+		return nil, errors.New("employee already has a final settlement, void it first to create a new one")
+	}
+
+	month, year := int(endDate.Month()), endDate.Year()
+
+	// the last salary is paid by the final settlement, not by the monthly payroll
+	existingPayrollMap, err := s.repo.GetExistingEmployeeID(month, year, constants.PayrollTypeRegular)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch existing employee id: %w", err)
+	}
+
+	if existingPayrollMap[emp.ID] {
+		return nil, errors.New("monthly payroll of the last period already generated, void it first")
+	}
+
+	reason := constants.TerminationReason(req.Reason)
+	rule, err := s.repo.FindSeveranceRuleByReason(ctx, reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch severance rule of %s: %w", reason, err)
+	}
+
+	input, err := s.buildCalculationInput(ctx, month, year, []uint{emp.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if input.TaxYearToDateMap == nil {
+		input.TaxYearToDateMap, err = s.repo.GetBulkTaxYearToDate(ctx, month, year)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("failed to fetch tax year to date: %w", err)
 		}
+	}
 
-		if payroll.Status == constants.PayrollStatusPaid {
-			return nil
-		}
+	// whole remaining loan is deducted at once
+	if activeLoan, exists := input.LoanMap[emp.ID]; exists {
+		activeLoan.InstallmentAmount = activeLoan.RemainingAmount
+		input.LoanMap[emp.ID] = activeLoan
+	}
 
-		if err := s.repo.UpdateStatus(ctx, id, constants.PayrollStatusPaid); err != nil {
-			return err
-		}
+	quotaTotal, quotaUsed, err := s.leave.GetPaidQuota(ctx, emp.ID, year)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch leave quota: %w", err)
+	}
 
-		var deductedLoan float64
-		for _, detail := range payroll.Details {
-			if detail.Title == "Potongan Kasbon" && detail.Type == constants.DetailTypeDeduction {
-				deductedLoan += detail.Amount
-			}
-		}
+	previousEndDate := emp.EndDate
+	emp.EndDate = &endDate
+	payroll, settlement := calculateFinalSettlement(emp, input, &settlementInput{
+		EndDate:         endDate,
+		Reason:          reason,
+		Rule:            *rule,
+		UnusedLeaveDays: unusedLeaveDays(quotaTotal, quotaUsed, emp.JoinDate, endDate),
+	})
 
-		if deductedLoan > 0 {
-			loanMap, err := s.loan.GetBulkActiveLoansByEmployeeIds(ctx, []uint{payroll.EmployeeID})
-			if err != nil {
-				return fmt.Errorf("failed to fetch active loan: %w", err)
-			}
+	if payroll.NetSalary < 0 {
+		return nil, fmt.Errorf("outstanding loan Rp %s exceed the final settlement, settle the loan first", settlement.OutstandingLoan.Format())
+	}
 
-			if activeLoan, exists := loanMap[payroll.EmployeeID]; exists {
-				activeLoan.RemainingAmount -= deductedLoan
-				if activeLoan.RemainingAmount <= 0 {
-					activeLoan.RemainingAmount = 0
-					activeLoan.Status = constants.LoanStatusPaidOff
-				}
+	payroll.Notes = req.Notes
+	settlement.Notes = req.Notes
+	settlement.CreatedBy = req.ActorID
+	settlement.PreviousEndDate = previousEndDate
+	settlement.PreviousUserActive = emp.User.IsActive
 
-				if err := s.loan.Update(ctx, &activeLoan); err != nil {
-					return fmt.Errorf("failed to update loan status: %w", err)
-				}
-			}
+	err = s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		payrolls := []Payroll{payroll}
+		if err := s.repo.CreateBulk(ctx, &payrolls); err != nil {
+			return err
 		}
 
-		periodMonth := int(payroll.PeriodDate.Month())
-		periodYear := payroll.PeriodDate.Year()
-		if err := s.overtime.UpdateBulkStatusByEmployeeId(ctx, payroll.EmployeeID, periodMonth, periodYear, constants.OvertimeStatusPaid); err != nil {
-			return fmt.Errorf("failed to update overtimes status to paid: %w", err)
+		payroll = payrolls[0]
+		settlement.PayrollID = payroll.ID
+		if err := s.repo.CreateFinalSettlement(ctx, &settlement); err != nil {
+			return fmt.Errorf("failed to create final settlement: %w", err)
 		}
 
-		go func() {
-			_ = s.notification.SendNotification(
-				payroll.Employee.UserID,
-				string(constants.NotificationTypePayrollPaid),
-				"Payroll Sudah dibayarkan",
-				fmt.Sprintf("Payroll %s sudah dibayarkan.", payroll.PeriodDate.Format(constants.PayrollTimeFormat)),
-				id,
-			)
-		}()
+		emp.User.IsActive = false
+		if err := s.user.UpdateUser(ctx, &emp.User); err != nil {
+			return fmt.Errorf("failed to deactivate user: %w", err)
+		}
 
-		return nil
+		return s.user.UpdateEmployee(ctx, emp)
 	})
+	if err != nil {
+		logger.Errorf("Failed create final settlement %w", err)
+
+		return nil, err
+	}
+
+	return &FinalSettlementResponse{
+		ID:              settlement.ID,
+		PayrollID:       payroll.ID,
+		EmployeeID:      emp.ID,
+		EmployeeName:    emp.FullName,
+		EmployeeNIK:     emp.NIK,
+		EndDate:         endDate.Format(constants.DefaultTimeFormat),
+		Reason:          string(reason),
+		TenureMonths:    settlement.TenureMonths,
+		UnusedLeaveDays: settlement.UnusedLeaveDays,
+		OutstandingLoan: settlement.OutstandingLoan,
+		SeverancePay:    settlement.SeverancePay,
+		ServicePay:      settlement.ServicePay,
+		NetSalary:       payroll.NetSalary,
+		Details:         toDetailResponses(payroll.Details),
+	}, nil
 }
 
-func (s *service) BlastPayslipEmail(ctx context.Context, id uint) error {
-	pdfBytes, payroll, err := s.generatePayslipPDFBytes(ctx, id)
+func (s *service) GenerateExitDocumentPDF(ctx context.Context, payrollID uint) ([]byte, *FinalSettlement, error) {
+	settlement, err := s.repo.FindFinalSettlementByPayrollID(ctx, payrollID)
 	if err != nil {
-		return fmt.Errorf("failed generate pdf: %w", err)
+		return nil, nil, err
 	}
 
-	if payroll.Status != constants.PayrollStatusPaid {
-		return fmt.Errorf("payroll status must paid")
+	if settlement.Employee == nil || settlement.Payroll == nil {
+		return nil, nil, errors.New("employee or payroll of the final settlement not found")
 	}
 
-	if payroll.Employee.Email == "" {
-		return fmt.Errorf("email required, make sure to update first")
+	if settlement.Payroll.Status == constants.PayrollStatusVoid {
+		return nil, nil, errors.New("final settlement already void")
 	}
 
-	periodStr := payroll.PeriodDate.Format(constants.PayrollTimeFormat)
-	subject := fmt.Sprintf("Payslip: %s - %s", periodStr, payroll.Employee.FullName)
-	fileName := fmt.Sprintf("Payslip_%s_%s.pdf", strings.ReplaceAll(payroll.Employee.FullName, " ", "-"), payroll.PeriodDate.Format("Jan2006"))
-
-	htmlBody := fmt.Sprintf(`
-		<h3>Hello %s,</h3>
-		<p>Terlampir adalah slip gaji Anda untuk periode <strong>%s</strong>.</p>
-		<p>Harap jaga kerahasiaan dokumen ini. Jika ada pertanyaan, silakan hubungi tim HR.</p>
-		<br>
-		<p>Salam,</p>
-		<p><strong>HR Manager</strong></p>
-	`, payroll.Employee.FullName, periodStr)
+	comp, err := s.company.FindByID(ctx, 1)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	err = s.email.SendWithAttachment(
-		payroll.Employee.Email,
-		subject,
-		htmlBody,
-		fileName,
-		pdfBytes,
-	)
+	pdfBytes, err := renderExitDocumentPDF(comp, settlement)
 	if err != nil {
-		return fmt.Errorf("failed to send email %s: %w", payroll.Employee.Email, err)
+		return nil, nil, err
 	}
 
-	return nil
+	return pdfBytes, settlement, nil
 }
 
-func (s *service) generatePayslipPDFBytes(ctx context.Context, id uint) ([]byte, *Payroll, error) {
-	pdf, payroll, err := s.GeneratePayslipPDF(ctx, id)
+func (s *service) GetSeveranceRules(ctx context.Context) ([]SeveranceRule, error) {
+	return s.repo.FindSeveranceRules(ctx)
+}
+
+func (s *service) UpdateSeveranceRule(ctx context.Context, req *UpdateSeveranceRuleRequest) error {
+	rule, err := s.repo.FindSeveranceRuleByID(ctx, req.ID)
 	if err != nil {
-		return nil, nil, err
+		return errors.New("severance rule not found")
 	}
 
-	pdfBytes := pdf.GetBytesPdf()
+	rule.SeveranceMultiplier = *req.SeveranceMultiplier
+	rule.ServicePayMultiplier = *req.ServicePayMultiplier
 
-	return pdfBytes, payroll, nil
+	return s.repo.UpdateSeveranceRule(ctx, rule)
 }
@@ -0,0 +1,351 @@
+package payroll
+
+import (
+	"basekarya-backend/internal/modules/company"
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/signintech/gopdf"
+)
+
+// uang pesangon is one month salary per started year of service up to 9 months, PP 35/2021 pasal 40 ayat 2
+const maxSeveranceMonths = 9
+
+// uang penghargaan masa kerja by completed years of service, PP 35/2021 pasal 40 ayat 3
+var servicePaySchedule = []struct {
+	MinYears int
+	Months   int
+}{
+	{24, 10}, {21, 8}, {18, 7}, {15, 6}, {12, 5}, {9, 4}, {6, 3}, {3, 2},
+}
+
+// cumulative severance pay final tax rate of PP 68/2009
+var severanceTaxBrackets = []terBracket{
+	{50_000_000, 0},
+	{100_000_000, 5},
+	{500_000_000, 15},
+	{noUpperBound, 25},
+}
+
+var terminationReasonLabels = map[constants.TerminationReason]string{
+	constants.TerminationReasonResignation: "Pengunduran Diri",
+	constants.TerminationReasonEfficiency:  "Efisiensi",
+	constants.TerminationReasonRetirement:  "Pensiun",
+	constants.TerminationReasonDeath:       "Meninggal Dunia",
+	constants.TerminationReasonMisconduct:  "Pelanggaran",
+	constants.TerminationReasonContractEnd: "Berakhirnya Kontrak Kerja",
+}
+
+// settlementInput hold what a final settlement need on top of the calculation input of its last period
+type settlementInput struct {
+	EndDate         time.Time
+	Reason          constants.TerminationReason
+	Rule            SeveranceRule
+	UnusedLeaveDays int
+}
+
+// unusedLeaveDays return leave earned until the end date that is not taken yet. the quota of the year is earned per started month
+// from january, or from the join month when the employee joined that year same as its initial balance
+func unusedLeaveDays(quotaTotal, quotaUsed int, joinDate *time.Time, endDate time.Time) int {
+	firstMonth := 1
+	if joinDate != nil && joinDate.Year() == endDate.Year() {
+		firstMonth = int(joinDate.Month())
+	}
+
+	workedMonths := int(endDate.Month()) - firstMonth + 1
+	if workedMonths <= 0 {
+		return 0
+	}
+
+	earned := quotaTotal * workedMonths / (12 - firstMonth + 1)
+	if earned <= quotaUsed {
+		return 0
+	}
+
+	return earned - quotaUsed
+}
+
+// serviceMonths return full months of service from join date until end date, employee without join date has no tenure
+func serviceMonths(emp *user.Employee, endDate time.Time) int {
+	if emp.JoinDate == nil {
+		return 0
+	}
+
+	return monthsBetween(*emp.JoinDate, endDate)
+}
+
+// severanceMonths return months of salary paid as uang pesangon for the tenure
+func severanceMonths(tenure int) int {
+	return min(tenure/12+1, maxSeveranceMonths)
+}
+
+// servicePayMonths return months of salary paid as uang penghargaan masa kerja for the tenure
+func servicePayMonths(tenure int) int {
+	years := tenure / 12
+	for _, s := range servicePaySchedule {
+		if years >= s.MinYears {
+			return s.Months
+		}
+	}
+
+	return 0
+}
+
+// calculateSeveranceTax return the final PPh 21 of severance pay, withheld separately from the monthly PPh 21
+func calculateSeveranceTax(amount money.Money) money.Money {
+	var tax, lowerBound money.Money
+	for _, b := range severanceTaxBrackets {
+		if amount <= lowerBound {
+			break
+		}
+
+		tax += (money.Min(amount, b.upperBound()) - lowerBound).Percent(b.Rate)
+		lowerBound = b.upperBound()
+	}
+
+	return tax.Floor(money.Rupiah)
+}
+
+// severanceLine return amount & payslip line of a severance component, e.g. "Uang Pesangon (3 bulan x 0.5 x Rp 8.000.000)"
+func severanceLine(title string, salary money.Money, months int, multiplier float64) (money.Money, string) {
+	if months <= 0 || multiplier <= 0 {
+		return 0, ""
+	}
+
+	amount := salary.Mul(int64(months)).Percent(multiplier * 100).Round()
+	label := fmt.Sprintf("%s (%d bulan x %s x Rp %s)", title, months, strconv.FormatFloat(multiplier, 'f', -1, 64), salary.Format())
+
+	return amount, label
+}
+
+// calculateFinalSettlement build the final settlement payroll of a leaving employee whose end date is already set.
+// loan of the calculation input is expected to carry the whole remaining amount as installment
+func calculateFinalSettlement(emp *user.Employee, in *calculationInput, st *settlementInput) (Payroll, FinalSettlement) {
+	payroll := buildPayroll(emp, in)
+	payroll.Type = constants.PayrollTypeFinalSettlement
+
+	settlement := FinalSettlement{
+		EmployeeID:      emp.ID,
+		EndDate:         st.EndDate,
+		Reason:          st.Reason,
+		TenureMonths:    serviceMonths(emp, st.EndDate),
+		UnusedLeaveDays: st.UnusedLeaveDays,
+	}
+	if payroll.LoanID != nil {
+		settlement.OutstandingLoan = in.LoanMap[emp.ID].RemainingAmount
+	}
+
+	// unused leave is paid at the same daily rate used for absence deduction
+	leavePay := in.calculateDailyPay(emp.BaseSalary, st.UnusedLeaveDays)
+	if leavePay.Amount > 0 {
+		payroll.Details = append(payroll.Details, PayrollDetail{
+			Title:     leavePay.Label("Penggantian Cuti Tahunan"),
+			Type:      constants.DetailTypeAllowance,
+			Amount:    leavePay.Amount,
+			IsTaxable: true,
+		})
+	}
+
+	// severance is not part of the monthly PPh 21 base, it carry its own final tax
+	var title string
+	settlement.SeverancePay, title = severanceLine("Uang Pesangon", emp.BaseSalary, severanceMonths(settlement.TenureMonths), st.Rule.SeveranceMultiplier)
+	if settlement.SeverancePay > 0 {
+		payroll.Details = append(payroll.Details, PayrollDetail{
+			Title:  title,
+			Type:   constants.DetailTypeAllowance,
+			Amount: settlement.SeverancePay,
+		})
+	}
+
+	settlement.ServicePay, title = severanceLine("Uang Penghargaan Masa Kerja", emp.BaseSalary, servicePayMonths(settlement.TenureMonths), st.Rule.ServicePayMultiplier)
+	if settlement.ServicePay > 0 {
+		payroll.Details = append(payroll.Details, PayrollDetail{
+			Title:  title,
+			Type:   constants.DetailTypeAllowance,
+			Amount: settlement.ServicePay,
+		})
+	}
+
+	if severanceTax := calculateSeveranceTax(settlement.SeverancePay + settlement.ServicePay); severanceTax > 0 {
+		payroll.Details = append(payroll.Details, PayrollDetail{
+			Title:  "PPh 21 Final Pesangon",
+			Type:   constants.DetailTypeDeduction,
+			Amount: severanceTax,
+		})
+	}
+
+	payroll.finalize(emp, in)
+
+	return payroll, settlement
+}
+
+// renderExitDocumentPDF render the employment certificate of a leaving employee together with its settlement breakdown
+func renderExitDocumentPDF(comp *company.Company, settlement *FinalSettlement) ([]byte, error) {
+	emp := settlement.Employee
+	payroll := settlement.Payroll
+
+	config, err := payslipPDFConfig(comp, emp)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := &gopdf.GoPdf{}
+	pdf.Start(config)
+	pdf.AddPage()
+	pdf.SetTextColor(0, 0, 0)
+
+	if err := pdf.AddTTFFont("Roboto", "assets/fonts/Roboto-Regular.ttf"); err != nil {
+		return nil, fmt.Errorf("failed load font regular: %w", err)
+	}
+	if err := pdf.AddTTFFont("Roboto-Bold", "assets/fonts/Roboto-Bold.ttf"); err != nil {
+		return nil, fmt.Errorf("failed load font bold: %w", err)
+	}
+
+	marginLeft := 30.0
+	marginRight := 565.0
+	contentWidth := marginRight - marginLeft
+	currentY := 30.0
+
+	// --- SECTION: HEADER ---
+	pdf.SetXY(marginLeft, currentY)
+	_ = pdf.SetFont("Roboto-Bold", "", 18)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: contentWidth, H: 22}, comp.Name, gopdf.CellOption{Align: gopdf.Center})
+	currentY += 24
+
+	pdf.SetXY(marginLeft, currentY)
+	_ = pdf.SetFont("Roboto", "", 10)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: contentWidth, H: 14}, comp.Address, gopdf.CellOption{Align: gopdf.Center})
+	currentY += 20
+
+	pdf.SetLineWidth(1)
+	pdf.Line(marginLeft, currentY, marginRight, currentY)
+	currentY += 20
+
+	pdf.SetXY(marginLeft, currentY)
+	_ = pdf.SetFont("Roboto-Bold", "", 13)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: contentWidth, H: 18}, "SURAT KETERANGAN KERJA", gopdf.CellOption{Align: gopdf.Center})
+	currentY += 18
+
+	pdf.SetXY(marginLeft, currentY)
+	_ = pdf.SetFont("Roboto", "", 10)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: contentWidth, H: 14},
+		fmt.Sprintf("Nomor: SKK/%04d/%s", settlement.ID, settlement.EndDate.Format("01/2006")),
+		gopdf.CellOption{Align: gopdf.Center})
+	currentY += 30
+
+	printParagraph := func(text string) {
+		_ = pdf.SetFont("Roboto", "", 10)
+		lines, err := pdf.SplitText(text, contentWidth)
+		if err != nil {
+			lines = []string{text}
+		}
+
+		for _, line := range lines {
+			pdf.SetXY(marginLeft, currentY)
+			_ = pdf.Cell(nil, line)
+			currentY += 15
+		}
+		currentY += 5
+	}
+
+	printInfo := func(label, value string) {
+		pdf.SetXY(marginLeft+10, currentY)
+		_ = pdf.SetFont("Roboto", "", 10)
+		_ = pdf.Cell(nil, label)
+
+		pdf.SetXY(marginLeft+150, currentY)
+		_ = pdf.Cell(nil, ": "+value)
+		currentY += 16
+	}
+
+	formatDate := func(date *time.Time) string {
+		if date == nil {
+			return "-"
+		}
+
+		return date.Format("02 January 2006")
+	}
+
+	department := "-"
+	if emp.Department != nil {
+		department = emp.Department.Name
+	}
+
+	reason := terminationReasonLabels[settlement.Reason]
+	if reason == "" {
+		reason = string(settlement.Reason)
+	}
+
+	// --- SECTION: EMPLOYEE ---
+	printParagraph("Yang bertanda tangan di bawah ini menerangkan bahwa:")
+	printInfo("Nama", emp.FullName)
+	printInfo("NIK", emp.NIK)
+	printInfo("Departemen", department)
+	printInfo("Tanggal Bergabung", formatDate(emp.JoinDate))
+	printInfo("Tanggal Berakhir", formatDate(&settlement.EndDate))
+	printInfo("Masa Kerja", fmt.Sprintf("%d tahun %d bulan", settlement.TenureMonths/12, settlement.TenureMonths%12))
+	printInfo("Alasan Berakhir", reason)
+	currentY += 10
+
+	printParagraph(fmt.Sprintf("telah bekerja pada %s dan hubungan kerja berakhir terhitung sejak tanggal %s. "+
+		"Seluruh hak yang bersangkutan diselesaikan dengan rincian sebagai berikut:",
+		comp.Name, formatDate(&settlement.EndDate)))
+
+	// --- SECTION: SETTLEMENT BREAKDOWN ---
+	amountW := 160.0
+	labelW := contentWidth - amountW
+	rowH := 18.0
+
+	printRow := func(label string, amount money.Money, font string) {
+		_ = pdf.SetFont(font, "", 9)
+		pdf.SetXY(marginLeft, currentY)
+		_ = pdf.CellWithOption(&gopdf.Rect{W: labelW, H: rowH}, "  "+label, gopdf.CellOption{Border: gopdf.AllBorders, Align: gopdf.Middle | gopdf.Left})
+		pdf.SetXY(marginLeft+labelW, currentY)
+		_ = pdf.CellWithOption(&gopdf.Rect{W: amountW, H: rowH}, "Rp "+amount.Format()+"  ", gopdf.CellOption{Border: gopdf.AllBorders, Align: gopdf.Middle | gopdf.Right})
+		currentY += rowH
+	}
+
+	for _, detailType := range []constants.PayrollDetailType{constants.DetailTypeAllowance, constants.DetailTypeDeduction} {
+		for _, d := range payroll.Details {
+			if d.Type != detailType {
+				continue
+			}
+
+			label := d.Title
+			if d.Type == constants.DetailTypeDeduction {
+				label = "(-) " + label
+			}
+			printRow(label, d.Amount, "Roboto")
+		}
+	}
+
+	pdf.SetFillColor(220, 230, 241)
+	pdf.RectFromUpperLeftWithStyle(marginLeft, currentY, contentWidth, rowH, "F")
+	printRow("TOTAL DITERIMA", payroll.NetSalary, "Roboto-Bold")
+	currentY += 15
+
+	if strings.TrimSpace(settlement.Notes) != "" {
+		printParagraph("Catatan: " + settlement.Notes)
+	}
+
+	printParagraph("Demikian surat keterangan ini dibuat untuk dapat dipergunakan sebagaimana mestinya.")
+	currentY += 20
+
+	// --- SECTION: SIGNATURE ---
+	signatureX := marginRight - 150.0
+	_ = pdf.SetFont("Roboto", "", 10)
+	pdf.SetXY(signatureX, currentY)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: 150, H: 15}, time.Now().Format("02 January 2006"), gopdf.CellOption{Align: gopdf.Center})
+	currentY += 70
+
+	_ = pdf.SetFont("Roboto-Bold", "", 10)
+	pdf.SetXY(signatureX, currentY)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: 150, H: 15}, "( HR Manager )", gopdf.CellOption{Border: gopdf.Top, Align: gopdf.Center})
+
+	return pdf.GetBytesPdf(), nil
+}
@@ -0,0 +1,93 @@
+package payroll
+
+import (
+	"basekarya-backend/pkg/money"
+	"testing"
+	"time"
+)
+
+func TestSeveranceSchedule(t *testing.T) {
+	tests := []struct {
+		tenure    int
+		severance int
+		service   int
+	}{
+		{0, 1, 0},
+		{11, 1, 0},
+		{12, 2, 0},
+		{36, 4, 2},
+		{95, 8, 3},
+		{96, 9, 3},
+		{300, 9, 10},
+	}
+
+	for _, tt := range tests {
+		if got := severanceMonths(tt.tenure); got != tt.severance {
+			t.Errorf("tenure %d: expected %d severance months, got %d", tt.tenure, tt.severance, got)
+		}
+		if got := servicePayMonths(tt.tenure); got != tt.service {
+			t.Errorf("tenure %d: expected %d service pay months, got %d", tt.tenure, tt.service, got)
+		}
+	}
+}
+
+func TestCalculateSeveranceTax(t *testing.T) {
+	tests := []struct {
+		amount   money.Money
+		expected money.Money
+	}{
+		{money.New(40_000_000), 0},
+		{money.New(80_000_000), money.New(1_500_000)},
+		{money.New(200_000_000), money.New(17_500_000)},
+		{money.New(600_000_000), money.New(87_500_000)},
+	}
+
+	for _, tt := range tests {
+		if got := calculateSeveranceTax(tt.amount); got != tt.expected {
+			t.Errorf("amount %s: expected %s, got %s", tt.amount.Format(), tt.expected.Format(), got.Format())
+		}
+	}
+}
+
+func TestSeveranceLine(t *testing.T) {
+	amount, title := severanceLine("Uang Pesangon", money.New(8_000_000), 3, 0.5)
+	if amount != money.New(12_000_000) {
+		t.Errorf("expected 12.000.000, got %s", amount.Format())
+	}
+	if title != "Uang Pesangon (3 bulan x 0.5 x Rp 8.000.000)" {
+		t.Errorf("unexpected title %q", title)
+	}
+
+	if amount, _ := severanceLine("Uang Pesangon", money.New(8_000_000), 3, 0); amount != 0 {
+		t.Errorf("expected no severance without multiplier, got %s", amount.Format())
+	}
+}
+
+func TestUnusedLeaveDays(t *testing.T) {
+	joinedEarlier := time.Date(2020, 3, 1, 0, 0, 0, 0, time.Local)
+	joinedThisYear := time.Date(2026, 7, 1, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		total    int
+		used     int
+		joinDate *time.Time
+		endDate  time.Time
+		want     int
+	}{
+		{"leave in february earn two months", 12, 0, &joinedEarlier, time.Date(2026, 2, 10, 0, 0, 0, 0, time.Local), 2},
+		{"leave in december earn the whole year", 12, 4, &joinedEarlier, time.Date(2026, 12, 31, 0, 0, 0, 0, time.Local), 8},
+		{"used more than earned", 12, 5, &joinedEarlier, time.Date(2026, 3, 31, 0, 0, 0, 0, time.Local), 0},
+		{"joined this year earn from join month", 6, 0, &joinedThisYear, time.Date(2026, 9, 30, 0, 0, 0, 0, time.Local), 3},
+		{"no join date earn from january", 12, 1, nil, time.Date(2026, 6, 30, 0, 0, 0, 0, time.Local), 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := unusedLeaveDays(tt.total, tt.used, tt.joinDate, tt.endDate)
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package payroll
+
+import (
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"fmt"
+	"math"
+)
+
+// TER (tarif efektif rata-rata) category based on PTKP status, PP 58/2023
+type terCategory string
+
+const (
+	terCategoryA terCategory = "A"
+	terCategoryB terCategory = "B"
+	terCategoryC terCategory = "C"
+)
+
+const (
+	ptkpBase            = 54_000_000 * money.Rupiah
+	ptkpMarried         = 4_500_000 * money.Rupiah
+	ptkpPerDependent    = 4_500_000 * money.Rupiah
+	maxPTKPDependents   = 3
+	occupationalRate    = 5.0
+	maxOccupationalCost = 6_000_000 * money.Rupiah
+	pkpRoundingUnit     = 1_000 * money.Rupiah
+
+	// non NPWP holder pay 120% of the tax
+	nonNPWPSurchargeNum = 120
+	nonNPWPSurchargeDen = 100
+
+	noUpperBound = math.MaxInt64
+)
+
+type terBracket struct {
+	UpperBound int64 // in rupiah
+	Rate       float64
+}
+
+func (b terBracket) upperBound() money.Money {
+	if b.UpperBound == noUpperBound {
+		return money.Money(math.MaxInt64)
+	}
+
+	return money.New(b.UpperBound)
+}
+
+// monthly gross upper bound & its rate in percent, last bracket has no upper bound
+var terRates = map[terCategory][]terBracket{
+	terCategoryA: {
+		{5_400_000, 0}, {5_650_000, 0.25}, {5_950_000, 0.5}, {6_300_000, 0.75},
+		{6_750_000, 1}, {7_500_000, 1.25}, {8_550_000, 1.5}, {9_650_000, 1.75},
+		{10_050_000, 2}, {10_350_000, 2.25}, {10_700_000, 2.5}, {11_050_000, 3},
+		{11_600_000, 3.5}, {12_500_000, 4}, {13_750_000, 5}, {15_100_000, 6},
+		{16_950_000, 7}, {19_750_000, 8}, {24_150_000, 9}, {26_450_000, 10},
+		{28_000_000, 11}, {30_050_000, 12}, {32_400_000, 13}, {35_400_000, 14},
+		{39_100_000, 15}, {43_850_000, 16}, {47_800_000, 17}, {51_400_000, 18},
+		{56_300_000, 19}, {62_200_000, 20}, {68_600_000, 21}, {77_500_000, 22},
+		{89_000_000, 23}, {103_000_000, 24}, {125_000_000, 25}, {157_000_000, 26},
+		{206_000_000, 27}, {337_000_000, 28}, {454_000_000, 29}, {550_000_000, 30},
+		{695_000_000, 31}, {910_000_000, 32}, {1_400_000_000, 33}, {noUpperBound, 34},
+	},
+	terCategoryB: {
+		{6_200_000, 0}, {6_500_000, 0.25}, {6_850_000, 0.5}, {7_300_000, 0.75},
+		{9_200_000, 1}, {10_750_000, 1.5}, {11_250_000, 2}, {11_600_000, 2.5},
+		{12_600_000, 3}, {13_600_000, 4}, {14_950_000, 5}, {16_400_000, 6},
+		{18_450_000, 7}, {21_850_000, 8}, {26_000_000, 9}, {27_700_000, 10},
+		{29_350_000, 11}, {31_450_000, 12}, {33_950_000, 13}, {37_100_000, 14},
+		{41_100_000, 15}, {45_800_000, 16}, {49_500_000, 17}, {53_800_000, 18},
+		{58_500_000, 19}, {64_000_000, 20}, {71_000_000, 21}, {80_000_000, 22},
+		{93_000_000, 23}, {109_000_000, 24}, {129_000_000, 25}, {163_000_000, 26},
+		{211_000_000, 27}, {374_000_000, 28}, {459_000_000, 29}, {555_000_000, 30},
+		{704_000_000, 31}, {957_000_000, 32}, {1_405_000_000, 33}, {noUpperBound, 34},
+	},
+	terCategoryC: {
+		{6_600_000, 0}, {6_950_000, 0.25}, {7_350_000, 0.5}, {7_800_000, 0.75},
+		{8_850_000, 1}, {9_800_000, 1.25}, {10_950_000, 1.5}, {11_200_000, 1.75},
+		{12_050_000, 2}, {12_950_000, 3}, {14_150_000, 4}, {15_550_000, 5},
+		{17_050_000, 6}, {19_500_000, 7}, {22_700_000, 8}, {26_600_000, 9},
+		{28_100_000, 10}, {30_100_000, 11}, {32_600_000, 12}, {35_400_000, 13},
+		{38_900_000, 14}, {43_000_000, 15}, {47_400_000, 16}, {51_200_000, 17},
+		{55_800_000, 18}, {60_400_000, 19}, {66_700_000, 20}, {74_500_000, 21},
+		{83_200_000, 22}, {95_600_000, 23}, {110_000_000, 24}, {134_000_000, 25},
+		{169_000_000, 26}, {221_000_000, 27}, {390_000_000, 28}, {463_000_000, 29},
+		{561_000_000, 30}, {709_000_000, 31}, {965_000_000, 32}, {1_419_000_000, 33},
+		{noUpperBound, 34},
+	},
+}
+
+// annual progressive rate of UU HPP pasal 17
+var progressiveBrackets = []terBracket{
+	{60_000_000, 5},
+	{250_000_000, 15},
+	{500_000_000, 25},
+	{5_000_000_000, 30},
+	{noUpperBound, 35},
+}
+
+// taxProfile is the PTKP status of an employee
+type taxProfile struct {
+	MaritalStatus constants.MaritalStatus
+	Dependents    int
+	HasNPWP       bool
+}
+
+func (p taxProfile) dependents() int {
+	if p.Dependents > maxPTKPDependents {
+		return maxPTKPDependents
+	}
+	if p.Dependents < 0 {
+		return 0
+	}
+
+	return p.Dependents
+}
+
+// PTKPCode return status code as written on tax forms, e.g. TK/0 or K/2
+func (p taxProfile) PTKPCode() string {
+	status := p.MaritalStatus
+	if status == "" {
+		status = constants.MaritalStatusSingle
+	}
+
+	return fmt.Sprintf("%s/%d", status, p.dependents())
+}
+
+func (p taxProfile) terCategory() terCategory {
+	dependents := p.dependents()
+
+	if p.MaritalStatus == constants.MaritalStatusMarried {
+		switch dependents {
+		case 0:
+			return terCategoryA
+		case 3:
+			return terCategoryC
+		default:
+			return terCategoryB
+		}
+	}
+
+	if dependents <= 1 {
+		return terCategoryA
+	}
+
+	return terCategoryB
+}
+
+// annualPTKP return yearly non taxable income (penghasilan tidak kena pajak)
+func (p taxProfile) annualPTKP() money.Money {
+	ptkp := ptkpBase + ptkpPerDependent.Mul(int64(p.dependents()))
+	if p.MaritalStatus == constants.MaritalStatusMarried {
+		ptkp += ptkpMarried
+	}
+
+	return ptkp
+}
+
+// terRate return the monthly TER rate in percent for the given gross income
+func terRate(category terCategory, monthlyGross money.Money) float64 {
+	for _, b := range terRates[category] {
+		if monthlyGross <= b.upperBound() {
+			return b.Rate
+		}
+	}
+
+	return 0
+}
+
+// calculateMonthlyTax return PPh 21 withholding for january - november using TER rate
+func calculateMonthlyTax(profile taxProfile, monthlyGross money.Money) (money.Money, float64) {
+	if monthlyGross <= 0 {
+		return 0, 0
+	}
+
+	rate := terRate(profile.terCategory(), monthlyGross)
+	tax := monthlyGross.Percent(rate).Floor(money.Rupiah)
+
+	if !profile.HasNPWP {
+		tax = tax.MulRatio(nonNPWPSurchargeNum, nonNPWPSurchargeDen).Floor(money.Rupiah)
+	}
+
+	return tax, rate
+}
+
+// calculateAnnualTax return yearly PPh 21 payable from annual gross income using pasal 17 rate,
+// annualPension is employee JHT & JP contribution which reduce net income
+func calculateAnnualTax(profile taxProfile, annualGross, annualPension money.Money) money.Money {
+	occupationalCost := money.Min(annualGross.Percent(occupationalRate), maxOccupationalCost)
+	netIncome := annualGross - occupationalCost - annualPension
+
+	// PKP is rounded down to thousands
+	taxableIncome := (netIncome - profile.annualPTKP()).Floor(pkpRoundingUnit)
+	if taxableIncome <= 0 {
+		return 0
+	}
+
+	var tax, lowerBound money.Money
+	for _, b := range progressiveBrackets {
+		if taxableIncome <= lowerBound {
+			break
+		}
+
+		tax += (money.Min(taxableIncome, b.upperBound()) - lowerBound).Percent(b.Rate)
+		lowerBound = b.upperBound()
+	}
+
+	tax = tax.Floor(money.Rupiah)
+	if !profile.HasNPWP {
+		tax = tax.MulRatio(nonNPWPSurchargeNum, nonNPWPSurchargeDen).Floor(money.Rupiah)
+	}
+
+	return tax
+}
+
+// calculateDecemberTax return the true-up of december, annual tax minus tax withheld january - november.
+// negative result means over withheld tax that must be returned to employee
+func calculateDecemberTax(profile taxProfile, monthlyGross, monthlyPension money.Money, ytd taxYearToDate) money.Money {
+	annualTax := calculateAnnualTax(profile, ytd.TaxableIncome+monthlyGross, ytd.PensionContribution+monthlyPension)
+
+	return annualTax - ytd.TaxAmount
+}
@@ -0,0 +1,297 @@
+package payroll
+
+import (
+	"basekarya-backend/internal/modules/company"
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/signintech/gopdf"
+)
+
+// TaxForm is the annual PPh 21 withholding summary of an employee (bukti potong 1721-A1),
+// built from every non void payroll of the year including THR
+type TaxForm struct {
+	Number     string
+	Year       int
+	StartMonth time.Month
+	EndMonth   time.Month
+	Employee   *user.Employee
+	Profile    taxProfile
+
+	Salary              money.Money // gaji, after taxable salary cut such as absence
+	OtherAllowance      money.Money // tunjangan lainnya, uang lembur & sebagainya
+	InsurancePremium    money.Money // premi asuransi yang dibayar pemberi kerja
+	Bonus               money.Money // tantiem, bonus, gratifikasi & THR
+	OccupationalCost    money.Money
+	PensionContribution money.Money
+	PTKP                money.Money
+	AnnualTax           money.Money
+	WithheldTax         money.Money
+}
+
+// taxFormLine is a numbered row of the income & tax calculation section
+type taxFormLine struct {
+	No     int
+	Label  string
+	Amount money.Money
+}
+
+// buildTaxForms group payrolls of a year by employee into one tax form each, payrolls must be ordered by employee
+func buildTaxForms(payrolls []Payroll, year int) []TaxForm {
+	var forms []TaxForm
+	index := make(map[uint]int)
+
+	for i := range payrolls {
+		p := &payrolls[i]
+		if p.Employee == nil {
+			continue
+		}
+
+		idx, ok := index[p.EmployeeID]
+		if !ok {
+			idx = len(forms)
+			index[p.EmployeeID] = idx
+			forms = append(forms, newTaxForm(p.Employee, year))
+		}
+
+		forms[idx].add(p)
+	}
+
+	for i := range forms {
+		forms[i].calculate()
+	}
+
+	return forms
+}
+
+func newTaxForm(emp *user.Employee, year int) TaxForm {
+	return TaxForm{
+		Year:     year,
+		Employee: emp,
+		Profile: taxProfile{
+			MaritalStatus: emp.MaritalStatus,
+			Dependents:    emp.Dependents,
+			HasNPWP:       strings.TrimSpace(emp.NPWP) != "",
+		},
+	}
+}
+
+func (f *TaxForm) add(p *Payroll) {
+	month := p.PeriodDate.Month()
+	if f.StartMonth == 0 || month < f.StartMonth {
+		f.StartMonth = month
+	}
+	if month > f.EndMonth {
+		f.EndMonth = month
+	}
+
+	for _, d := range p.Details {
+		if !d.IsTaxable {
+			continue
+		}
+
+		switch {
+		case d.Type != constants.DetailTypeAllowance:
+			f.Salary -= d.Amount
+		case p.IsTHR():
+			f.Bonus += d.Amount
+		case strings.HasPrefix(d.Title, "Base Salary"):
+			f.Salary += d.Amount
+		default:
+			f.OtherAllowance += d.Amount
+		}
+	}
+
+	for _, c := range p.Contributions {
+		switch c.Program {
+		case constants.BPJSProgramKesehatan, constants.BPJSProgramJKK, constants.BPJSProgramJKM:
+			f.InsurancePremium += c.EmployerAmount
+		case constants.BPJSProgramJHT, constants.BPJSProgramJP:
+			f.PensionContribution += c.EmployeeAmount
+		}
+	}
+
+	f.WithheldTax += p.TaxAmount
+}
+
+func (f *TaxForm) calculate() {
+	f.Number = fmt.Sprintf("1.1-%02d.%02d-%07d", f.EndMonth, f.Year%100, f.Employee.ID)
+	f.OccupationalCost = money.Min(f.Gross().Percent(occupationalRate), maxOccupationalCost)
+	f.PTKP = f.Profile.annualPTKP()
+	f.AnnualTax = calculateAnnualTax(f.Profile, f.Gross(), f.PensionContribution)
+}
+
+// Gross return jumlah penghasilan bruto
+func (f *TaxForm) Gross() money.Money {
+	return f.Salary + f.OtherAllowance + f.InsurancePremium + f.Bonus
+}
+
+// NetIncome return penghasilan neto setahun
+func (f *TaxForm) NetIncome() money.Money {
+	return f.Gross() - f.OccupationalCost - f.PensionContribution
+}
+
+// TaxableIncome return PKP setahun, rounded down to thousands
+func (f *TaxForm) TaxableIncome() money.Money {
+	return money.Max((f.NetIncome() - f.PTKP).Floor(pkpRoundingUnit), 0)
+}
+
+// Lines return the numbered rows of section B of 1721-A1
+func (f *TaxForm) Lines() []taxFormLine {
+	return []taxFormLine{
+		{1, "Gaji/Pensiun atau THT/JHT", f.Salary},
+		{2, "Tunjangan PPh", 0},
+		{3, "Tunjangan Lainnya, Uang Lembur dan sebagainya", f.OtherAllowance},
+		{4, "Honorarium dan Imbalan Lain Sejenisnya", 0},
+		{5, "Premi Asuransi yang Dibayar Pemberi Kerja", f.InsurancePremium},
+		{6, "Penerimaan dalam Bentuk Natura dan Kenikmatan Lainnya", 0},
+		{7, "Tantiem, Bonus, Gratifikasi, Jasa Produksi dan THR", f.Bonus},
+		{8, "Jumlah Penghasilan Bruto (1 s.d. 7)", f.Gross()},
+		{9, "Biaya Jabatan/Biaya Pensiun", f.OccupationalCost},
+		{10, "Iuran Pensiun atau Iuran THT/JHT", f.PensionContribution},
+		{11, "Jumlah Pengurangan (9 s.d. 10)", f.OccupationalCost + f.PensionContribution},
+		{12, "Jumlah Penghasilan Neto (8 - 11)", f.NetIncome()},
+		{13, "Penghasilan Neto Masa Sebelumnya", 0},
+		{14, "Jumlah Penghasilan Neto untuk Penghitungan PPh 21", f.NetIncome()},
+		{15, "Penghasilan Tidak Kena Pajak (PTKP)", f.PTKP},
+		{16, "Penghasilan Kena Pajak Setahun/Disetahunkan", f.TaxableIncome()},
+		{17, "PPh 21 atas Penghasilan Kena Pajak Setahun/Disetahunkan", f.AnnualTax},
+		{18, "PPh 21 yang Telah Dipotong Masa Sebelumnya", 0},
+		{19, "PPh 21 Terutang", f.AnnualTax},
+		{20, "PPh 21 yang Telah Dipotong dan Dilunasi", f.WithheldTax},
+	}
+}
+
+// FileName return pdf file name of the form, e.g. 1721-A1-EMP001-2026.pdf
+func (f *TaxForm) FileName() string {
+	return fmt.Sprintf("1721-A1-%s-%d.pdf", f.Employee.NIK, f.Year)
+}
+
+// renderTaxFormPDF draw the form with the same layout & fonts as payslip, protected by the payslip password rule
+func renderTaxFormPDF(comp *company.Company, form *TaxForm) ([]byte, error) {
+	config, err := payslipPDFConfig(comp, form.Employee)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := &gopdf.GoPdf{}
+	pdf.Start(config)
+	pdf.AddPage()
+	pdf.SetTextColor(0, 0, 0)
+
+	if err := pdf.AddTTFFont("Roboto", "assets/fonts/Roboto-Regular.ttf"); err != nil {
+		return nil, fmt.Errorf("failed load font regular: %w", err)
+	}
+	if err := pdf.AddTTFFont("Roboto-Bold", "assets/fonts/Roboto-Bold.ttf"); err != nil {
+		return nil, fmt.Errorf("failed load font bold: %w", err)
+	}
+
+	marginLeft := 30.0
+	marginRight := 565.0
+	contentWidth := marginRight - marginLeft
+	currentY := 30.0
+
+	// --- SECTION: HEADER ---
+	pdf.SetXY(marginLeft, currentY)
+	_ = pdf.SetFont("Roboto-Bold", "", 13)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: contentWidth, H: 18}, "BUKTI PEMOTONGAN PAJAK PENGHASILAN PASAL 21", gopdf.CellOption{Align: gopdf.Center})
+	currentY += 18
+
+	pdf.SetXY(marginLeft, currentY)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: contentWidth, H: 18}, "BAGI PEGAWAI TETAP (FORMULIR 1721-A1)", gopdf.CellOption{Align: gopdf.Center})
+	currentY += 22
+
+	pdf.SetXY(marginLeft, currentY)
+	_ = pdf.SetFont("Roboto", "", 10)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: contentWidth, H: 15},
+		fmt.Sprintf("Nomor: %s    Masa Perolehan: %02d - %02d / %d", form.Number, form.StartMonth, form.EndMonth, form.Year),
+		gopdf.CellOption{Align: gopdf.Center})
+	currentY += 25
+
+	pdf.SetLineWidth(1)
+	pdf.Line(marginLeft, currentY, marginRight, currentY)
+	currentY += 15
+
+	printSection := func(title string) {
+		pdf.SetXY(marginLeft, currentY)
+		_ = pdf.SetFont("Roboto-Bold", "", 11)
+		_ = pdf.Cell(nil, title)
+		currentY += 20
+	}
+
+	printInfo := func(label, value string) {
+		pdf.SetXY(marginLeft+10, currentY)
+		_ = pdf.SetFont("Roboto", "", 10)
+		_ = pdf.Cell(nil, label)
+
+		pdf.SetXY(marginLeft+150, currentY)
+		_ = pdf.Cell(nil, ": "+value)
+		currentY += 16
+	}
+
+	orDash := func(value string) string {
+		if strings.TrimSpace(value) == "" {
+			return "-"
+		}
+
+		return value
+	}
+
+	// --- SECTION A: EMPLOYEE ---
+	printSection("A. IDENTITAS PENERIMA PENGHASILAN")
+	printInfo("NPWP", orDash(form.Employee.NPWP))
+	printInfo("NIK", form.Employee.NIK)
+	printInfo("Nama", form.Employee.FullName)
+	printInfo("Status PTKP", form.Profile.PTKPCode())
+	currentY += 10
+
+	// --- SECTION B: INCOME & TAX ---
+	printSection("B. RINCIAN PENGHASILAN DAN PENGHITUNGAN PPh PASAL 21")
+
+	noW := 30.0
+	amountW := 140.0
+	labelW := contentWidth - noW - amountW
+	rowH := 18.0
+
+	pdf.SetFillColor(240, 240, 240)
+	pdf.RectFromUpperLeftWithStyle(marginLeft, currentY, contentWidth, rowH, "F")
+
+	_ = pdf.SetFont("Roboto-Bold", "", 10)
+	pdf.SetXY(marginLeft, currentY)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: noW, H: rowH}, "No", gopdf.CellOption{Border: gopdf.AllBorders, Align: gopdf.Middle | gopdf.Center})
+	pdf.SetXY(marginLeft+noW, currentY)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: labelW, H: rowH}, "  Uraian", gopdf.CellOption{Border: gopdf.AllBorders, Align: gopdf.Middle | gopdf.Left})
+	pdf.SetXY(marginLeft+noW+labelW, currentY)
+	_ = pdf.CellWithOption(&gopdf.Rect{W: amountW, H: rowH}, "Jumlah (Rp)  ", gopdf.CellOption{Border: gopdf.AllBorders, Align: gopdf.Middle | gopdf.Right})
+	currentY += rowH
+
+	for _, line := range form.Lines() {
+		font := "Roboto"
+		switch line.No {
+		case 8, 12, 16, 19, 20:
+			font = "Roboto-Bold"
+		}
+		_ = pdf.SetFont(font, "", 9)
+
+		pdf.SetXY(marginLeft, currentY)
+		_ = pdf.CellWithOption(&gopdf.Rect{W: noW, H: rowH}, fmt.Sprintf("%d", line.No), gopdf.CellOption{Border: gopdf.AllBorders, Align: gopdf.Middle | gopdf.Center})
+		pdf.SetXY(marginLeft+noW, currentY)
+		_ = pdf.CellWithOption(&gopdf.Rect{W: labelW, H: rowH}, "  "+line.Label, gopdf.CellOption{Border: gopdf.AllBorders, Align: gopdf.Middle | gopdf.Left})
+		pdf.SetXY(marginLeft+noW+labelW, currentY)
+		_ = pdf.CellWithOption(&gopdf.Rect{W: amountW, H: rowH}, line.Amount.Format()+"  ", gopdf.CellOption{Border: gopdf.AllBorders, Align: gopdf.Middle | gopdf.Right})
+		currentY += rowH
+	}
+	currentY += 20
+
+	// --- SECTION C: WITHHOLDER ---
+	printSection("C. IDENTITAS PEMOTONG")
+	printInfo("NPWP Pemotong", orDash(comp.TaxNumber))
+	printInfo("Nama Pemotong", comp.Name)
+	printInfo("Tanggal", time.Now().Format("02 January 2006"))
+
+	return pdf.GetBytesPdf(), nil
+}
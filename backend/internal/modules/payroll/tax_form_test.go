@@ -0,0 +1,78 @@
+package payroll
+
+import (
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"testing"
+	"time"
+)
+
+func TestBuildTaxForms(t *testing.T) {
+	emp := &user.Employee{ID: 7, NIK: "EMP007", NPWP: "123", MaritalStatus: constants.MaritalStatusSingle}
+
+	payrolls := []Payroll{
+		{
+			EmployeeID: 7,
+			Employee:   emp,
+			Type:       constants.PayrollTypeRegular,
+			PeriodDate: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.Local),
+			TaxAmount:  money.New(300_000),
+			Details: []PayrollDetail{
+				{Title: "Base Salary", Type: constants.DetailTypeAllowance, Amount: money.New(10_000_000), IsTaxable: true},
+				{Title: "Uang Lembur (05 Jan, hari kerja, 2 jam 0 menit)", Type: constants.DetailTypeAllowance, Amount: money.New(500_000), IsTaxable: true},
+				{Title: "Reimbursement", Type: constants.DetailTypeAllowance, Amount: money.New(250_000)},
+				{Title: "Potongan Tidak Hadir (1 hari x Rp 200.000)", Type: constants.DetailTypeDeduction, Amount: money.New(200_000), IsTaxable: true},
+			},
+			Contributions: []PayrollContribution{
+				{Program: constants.BPJSProgramKesehatan, EmployeeAmount: money.New(100_000), EmployerAmount: money.New(400_000)},
+				{Program: constants.BPJSProgramJHT, EmployeeAmount: money.New(200_000), EmployerAmount: money.New(370_000)},
+			},
+		},
+		{
+			EmployeeID: 7,
+			Employee:   emp,
+			Type:       constants.PayrollTypeTHR,
+			PeriodDate: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.Local),
+			TaxAmount:  money.New(1_000_000),
+			Details: []PayrollDetail{
+				{Title: "THR Keagamaan", Type: constants.DetailTypeAllowance, Amount: money.New(10_000_000), IsTaxable: true},
+			},
+		},
+	}
+
+	forms := buildTaxForms(payrolls, 2026)
+	if len(forms) != 1 {
+		t.Fatalf("expected 1 form, got %d", len(forms))
+	}
+
+	form := forms[0]
+	if form.Number != "1.1-03.26-0000007" {
+		t.Errorf("expected number 1.1-03.26-0000007, got %s", form.Number)
+	}
+	if form.StartMonth != time.January || form.EndMonth != time.March {
+		t.Errorf("expected period 01 - 03, got %02d - %02d", form.StartMonth, form.EndMonth)
+	}
+
+	expected := map[int]money.Money{
+		1:  money.New(9_800_000),
+		3:  money.New(500_000),
+		5:  money.New(400_000),
+		7:  money.New(10_000_000),
+		8:  money.New(20_700_000),
+		9:  money.New(1_035_000),
+		10: money.New(200_000),
+		12: money.New(19_465_000),
+		15: money.New(54_000_000),
+		16: 0,
+		19: 0,
+		20: money.New(1_300_000),
+	}
+
+	for _, line := range form.Lines() {
+		want, ok := expected[line.No]
+		if ok && line.Amount != want {
+			t.Errorf("line %d %s: expected %s, got %s", line.No, line.Label, want, line.Amount)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package payroll
+
+import (
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"testing"
+)
+
+func TestTaxProfile_TERCategory(t *testing.T) {
+	tests := []struct {
+		profile  taxProfile
+		expected terCategory
+	}{
+		{taxProfile{MaritalStatus: constants.MaritalStatusSingle, Dependents: 0}, terCategoryA},
+		{taxProfile{MaritalStatus: constants.MaritalStatusSingle, Dependents: 1}, terCategoryA},
+		{taxProfile{MaritalStatus: constants.MaritalStatusMarried, Dependents: 0}, terCategoryA},
+		{taxProfile{MaritalStatus: constants.MaritalStatusSingle, Dependents: 2}, terCategoryB},
+		{taxProfile{MaritalStatus: constants.MaritalStatusMarried, Dependents: 2}, terCategoryB},
+		{taxProfile{MaritalStatus: constants.MaritalStatusMarried, Dependents: 3}, terCategoryC},
+		{taxProfile{MaritalStatus: constants.MaritalStatusMarried, Dependents: 5}, terCategoryC},
+	}
+
+	for _, tt := range tests {
+		if got := tt.profile.terCategory(); got != tt.expected {
+			t.Errorf("%s: expected category %s, got %s", tt.profile.PTKPCode(), tt.expected, got)
+		}
+	}
+}
+
+func TestCalculateMonthlyTax(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  taxProfile
+		gross    money.Money
+		expected money.Money
+	}{
+		{"TK/0 below threshold", taxProfile{MaritalStatus: constants.MaritalStatusSingle, HasNPWP: true}, money.New(5_000_000), money.New(0)},
+		{"TK/0 category A", taxProfile{MaritalStatus: constants.MaritalStatusSingle, HasNPWP: true}, money.New(10_000_000), money.New(200_000)},
+		{"K/3 category C", taxProfile{MaritalStatus: constants.MaritalStatusMarried, Dependents: 3, HasNPWP: true}, money.New(10_000_000), money.New(150_000)},
+		{"TK/0 without NPWP", taxProfile{MaritalStatus: constants.MaritalStatusSingle}, money.New(10_000_000), money.New(240_000)},
+	}
+
+	for _, tt := range tests {
+		got, _ := calculateMonthlyTax(tt.profile, tt.gross)
+		if got != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestCalculateAnnualTax(t *testing.T) {
+	single := taxProfile{MaritalStatus: constants.MaritalStatusSingle, HasNPWP: true}
+	if got := calculateAnnualTax(single, money.New(120_000_000), money.New(0)); got != money.New(3_000_000) {
+		t.Errorf("TK/0: expected 3000000, got %s", got)
+	}
+
+	married := taxProfile{MaritalStatus: constants.MaritalStatusMarried, Dependents: 1, HasNPWP: true}
+	if got := calculateAnnualTax(married, money.New(240_000_000), money.New(0)); got != money.New(19_650_000) {
+		t.Errorf("K/1: expected 19650000, got %s", got)
+	}
+
+	// 3.6M of JHT & JP contribution lower PKP from 60M to 56.4M
+	if got := calculateAnnualTax(single, money.New(120_000_000), money.New(3_600_000)); got != money.New(2_820_000) {
+		t.Errorf("TK/0 with pension: expected 2820000, got %s", got)
+	}
+}
+
+func TestCalculateDecemberTax(t *testing.T) {
+	profile := taxProfile{MaritalStatus: constants.MaritalStatusSingle, HasNPWP: true}
+	ytd := taxYearToDate{TaxableIncome: money.New(110_000_000), TaxAmount: money.New(2_200_000)}
+
+	if got := calculateDecemberTax(profile, money.New(10_000_000), 0, ytd); got != money.New(800_000) {
+		t.Errorf("expected december true-up 800000, got %s", got)
+	}
+}
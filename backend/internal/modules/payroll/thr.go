@@ -0,0 +1,118 @@
+package payroll
+
+import (
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// employee with 12 months of service get one month salary as THR, shorter tenure is prorated, Permenaker 6/2016
+const (
+	thrFullTenureMonths = 12
+	thrMinTenureMonths  = 1
+)
+
+// thrInput hold all bulk data of a THR period, fetched once and looked up per employee
+type thrInput struct {
+	PeriodDate time.Time
+	CutoffDate time.Time
+
+	// RegularTaxableMap is PPh 21 base of the monthly salary of the same period,
+	// THR is taxed with the extra TER withholding it cause on top of the monthly salary
+	RegularTaxableMap map[uint]money.Money
+}
+
+// thrTenureMonths return full months of service from join date until cutoff date,
+// employee without join date is treated as full tenure
+func thrTenureMonths(emp *user.Employee, cutoff time.Time) int {
+	if emp.JoinDate == nil {
+		return thrFullTenureMonths
+	}
+
+	return monthsBetween(*emp.JoinDate, cutoff)
+}
+
+// isTHREligible return false when employee tenure is under a month or already left before cutoff date
+func isTHREligible(emp *user.Employee, in *thrInput) bool {
+	if emp.EndDate != nil && truncateDate(*emp.EndDate).Before(truncateDate(in.CutoffDate)) {
+		return false
+	}
+
+	return thrTenureMonths(emp, in.CutoffDate) >= thrMinTenureMonths
+}
+
+// calculateTHRPayroll build a draft THR payroll with its detail lines for a single employee
+func calculateTHRPayroll(emp *user.Employee, in *thrInput) Payroll {
+	salary := emp.BaseSalary
+	tenure := thrTenureMonths(emp, in.CutoffDate)
+
+	title := "THR Keagamaan"
+	amount := salary
+	if tenure < thrFullTenureMonths {
+		title = fmt.Sprintf("THR Keagamaan Prorata (%d/%d bulan x Rp %s)", tenure, thrFullTenureMonths, salary.Format())
+		amount = salary.MulRatio(int64(tenure), thrFullTenureMonths).Round()
+	}
+
+	payroll := Payroll{
+		EmployeeID: emp.ID,
+		Type:       constants.PayrollTypeTHR,
+		PeriodDate: in.PeriodDate,
+		BaseSalary: salary,
+		Status:     constants.PayrollStatusDraft,
+		Details: []PayrollDetail{
+			{
+				Title:     title,
+				Type:      constants.DetailTypeAllowance,
+				Amount:    amount,
+				IsTaxable: true,
+			},
+		},
+	}
+
+	payroll.applyTHRTax(emp, in)
+	payroll.recalculateTotals()
+
+	return payroll
+}
+
+// applyTHRTax withhold PPh 21 of THR as the difference between TER of monthly salary plus THR and TER of monthly salary only.
+// when monthly salary of the period is not generated yet, base salary is used as its estimate
+func (p *Payroll) applyTHRTax(emp *user.Employee, in *thrInput) {
+	profile := taxProfile{
+		MaritalStatus: emp.MaritalStatus,
+		Dependents:    emp.Dependents,
+		HasNPWP:       strings.TrimSpace(emp.NPWP) != "",
+	}
+
+	var thrAmount money.Money
+	for _, d := range p.Details {
+		if d.IsTaxable && d.Type == constants.DetailTypeAllowance {
+			thrAmount += d.Amount
+		}
+	}
+
+	regularTaxable, ok := in.RegularTaxableMap[emp.ID]
+	if !ok {
+		regularTaxable = emp.BaseSalary
+	}
+
+	combinedTax, rate := calculateMonthlyTax(profile, regularTaxable+thrAmount)
+	regularTax, _ := calculateMonthlyTax(profile, regularTaxable)
+	taxAmount := money.Max(combinedTax-regularTax, 0)
+
+	p.TaxableIncome = thrAmount
+	p.PTKPStatus = profile.PTKPCode()
+	p.TaxAmount = taxAmount
+
+	if taxAmount > 0 {
+		p.Details = append(p.Details, PayrollDetail{
+			Title:  fmt.Sprintf("PPh 21 THR (TER %s %s%%)", profile.terCategory(), strconv.FormatFloat(rate, 'f', -1, 64)),
+			Type:   constants.DetailTypeDeduction,
+			Amount: taxAmount,
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package payroll
+
+import (
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/money"
+	"testing"
+	"time"
+)
+
+func TestTHRTenureMonths(t *testing.T) {
+	cutoff := time.Date(2026, time.March, 13, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		joinDate time.Time
+		expected int
+	}{
+		{"over a year", time.Date(2024, time.January, 2, 0, 0, 0, 0, time.Local), 26},
+		{"day not yet reached", time.Date(2025, time.October, 20, 0, 0, 0, 0, time.Local), 4},
+		{"exactly one month", time.Date(2026, time.February, 13, 0, 0, 0, 0, time.Local), 1},
+		{"joined after cutoff", time.Date(2026, time.April, 1, 0, 0, 0, 0, time.Local), 0},
+	}
+
+	for _, tt := range tests {
+		emp := user.Employee{JoinDate: &tt.joinDate}
+		if got := thrTenureMonths(&emp, cutoff); got != tt.expected {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestCalculateTHRPayroll(t *testing.T) {
+	cutoff := time.Date(2026, time.March, 13, 0, 0, 0, 0, time.Local)
+	joinDate := time.Date(2025, time.October, 20, 0, 0, 0, 0, time.Local)
+
+	in := &thrInput{
+		PeriodDate:        time.Date(2026, time.March, 1, 0, 0, 0, 0, time.Local),
+		CutoffDate:        cutoff,
+		RegularTaxableMap: map[uint]money.Money{1: money.New(6_000_000)},
+	}
+
+	tests := []struct {
+		name      string
+		emp       user.Employee
+		title     string
+		amount    money.Money
+		taxAmount money.Money
+	}{
+		{
+			name:      "full tenure",
+			emp:       user.Employee{ID: 1, BaseSalary: money.New(6_000_000), NPWP: "123"},
+			title:     "THR Keagamaan",
+			amount:    money.New(6_000_000),
+			taxAmount: money.New(435_000),
+		},
+		{
+			name:      "prorated tenure",
+			emp:       user.Employee{ID: 1, BaseSalary: money.New(6_000_000), NPWP: "123", JoinDate: &joinDate},
+			title:     "THR Keagamaan Prorata (4/12 bulan x Rp 6.000.000)",
+			amount:    money.New(2_000_000),
+			taxAmount: money.New(75_000),
+		},
+	}
+
+	for _, tt := range tests {
+		p := calculateTHRPayroll(&tt.emp, in)
+		if !p.IsTHR() {
+			t.Errorf("%s: expected THR payroll, got %s", tt.name, p.Type)
+		}
+		if p.Details[0].Title != tt.title {
+			t.Errorf("%s: expected title %s, got %s", tt.name, tt.title, p.Details[0].Title)
+		}
+		if p.TaxableIncome != tt.amount {
+			t.Errorf("%s: expected amount %s, got %s", tt.name, tt.amount, p.TaxableIncome)
+		}
+		if p.TaxAmount != tt.taxAmount {
+			t.Errorf("%s: expected tax %s, got %s", tt.name, tt.taxAmount, p.TaxAmount)
+		}
+		if p.NetSalary != tt.amount-tt.taxAmount {
+			t.Errorf("%s: expected net %s, got %s", tt.name, tt.amount-tt.taxAmount, p.NetSalary)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package reimbursement
 import (
 	"context"
 	"mime/multipart"
+	"time"
 )
 
 type StorageProvider interface {
@@ -19,3 +20,7 @@ type NotificationProvider interface {
 type UserProvider interface {
 	FindAdminID(ctx context.Context) (uint, error)
 }
+
+type PayrollLockProvider interface {
+	IsPeriodLocked(ctx context.Context, date time.Time) (bool, error)
+}
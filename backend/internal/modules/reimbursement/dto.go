@@ -1,6 +1,7 @@
 package reimbursement
 
 import (
+	"basekarya-backend/pkg/money"
 	"mime/multipart"
 	"time"
 )
@@ -16,7 +17,7 @@ type ReimbursementRequest struct {
 	UserID      uint                  `form:"-"`
 	Title       string                `form:"title" validate:"required,max=255"`
 	Description string                `form:"description" validate:"omitempty"`
-	Amount      float64               `form:"amount" validate:"required,min=1000"`
+	Amount      money.Money           `form:"amount" validate:"required,min=1000"`
 	Date        string                `form:"date" validate:"required"`
 	File        *multipart.FileHeader `form:"file" validate:"required"`
 }
@@ -29,23 +30,23 @@ type ActionRequest struct {
 }
 
 type ReimbursementDetailResponse struct {
-	ID              uint      `json:"id"`
-	Title           string    `json:"title"`
-	Description     string    `json:"description"`
-	Amount          float64   `json:"amount"`
-	DateOfExpense   time.Time `json:"date_of_expense"`
-	ProofFileURL    string    `json:"proof_file_url"`
-	Status          string    `json:"status"`
-	RejectionReason *string   `json:"rejection_reason"`
+	ID              uint        `json:"id"`
+	Title           string      `json:"title"`
+	Description     string      `json:"description"`
+	Amount          money.Money `json:"amount"`
+	DateOfExpense   time.Time   `json:"date_of_expense"`
+	ProofFileURL    string      `json:"proof_file_url"`
+	Status          string      `json:"status"`
+	RejectionReason *string     `json:"rejection_reason"`
 
 	RequesterName string `json:"requester_name"`
 }
 
 type ReimbursementListResponse struct {
-	ID            uint      `json:"id"`
-	Title         string    `json:"title"`
-	Amount        float64   `json:"amount"`
-	DateOfExpense time.Time `json:"date_of_expense"`
-	ProofFileURL  string    `json:"proof_file_url"`
-	Status        string    `json:"status"`
+	ID            uint        `json:"id"`
+	Title         string      `json:"title"`
+	Amount        money.Money `json:"amount"`
+	DateOfExpense time.Time   `json:"date_of_expense"`
+	ProofFileURL  string      `json:"proof_file_url"`
+	Status        string      `json:"status"`
 }
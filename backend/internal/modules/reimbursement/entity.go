@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"basekarya-backend/internal/modules/user"
 	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
 	"time"
 
 	"gorm.io/gorm"
@@ -23,7 +24,7 @@ type Reimbursement struct {
 
 	Title           string                        `gorm:"type:varchar(255);not null" json:"title"`
 	Description     string                        `gorm:"type:text" json:"description"`
-	Amount          float64                       `gorm:"type:decimal(15,2);not null" json:"amount"`
+	Amount          money.Money                   `gorm:"type:decimal(15,2);not null" json:"amount"`
 	DateOfExpense   time.Time                     `gorm:"type:date;not null" json:"date_of_expense"`
 	ProofFileURL    string                        `gorm:"type:varchar(255);not null" json:"proof_file_url"`
 	Status          constants.ReimbursementStatus `gorm:"type:enum('PENDING','APPROVED','REJECTED');default:'PENDING'" json:"status"`
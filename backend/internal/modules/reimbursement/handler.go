@@ -3,6 +3,7 @@ package reimbursement
 import (
 	"basekarya-backend/pkg/constants"
 	"basekarya-backend/pkg/logger"
+	"basekarya-backend/pkg/money"
 	"basekarya-backend/pkg/response"
 	"basekarya-backend/pkg/utils"
 	"fmt"
@@ -127,7 +128,7 @@ func (h *Handler) ProcessAction(ctx echo.Context) error {
 }
 
 func (h *Handler) parseAndValidateFormData(ctx echo.Context, userID uint) (*ReimbursementRequest, error) {
-	amount, err := strconv.ParseFloat(ctx.FormValue("amount"), 64)
+	amount, err := money.Parse(ctx.FormValue("amount"))
 	if err != nil {
 		return nil, fmt.Errorf("invalid amount")
 	}
@@ -3,6 +3,7 @@ package reimbursement
 import (
 	"context"
 	"basekarya-backend/pkg/utils"
+	"basekarya-backend/pkg/money"
 
 	"gorm.io/gorm"
 )
@@ -12,7 +13,7 @@ type Repository interface {
 	FindByID(ctx context.Context, id uint) (*Reimbursement, error)
 	FindAll(ctx context.Context, filter ReimbursementFilter) ([]Reimbursement, int64, error)
 	Update(ctx context.Context, reimbursement *Reimbursement) error
-	GetBulkApprovedAmount(ctx context.Context, month, year int) (map[uint]float64, error)
+	GetBulkApprovedAmount(ctx context.Context, month, year int) (map[uint]money.Money, error)
 }
 
 type repository struct {
@@ -74,11 +75,11 @@ func (r *repository) Update(ctx context.Context, reimbursement *Reimbursement) e
 	return db.Save(reimbursement).Error
 }
 
-func (r *repository) GetBulkApprovedAmount(ctx context.Context, month, year int) (map[uint]float64, error) {
+func (r *repository) GetBulkApprovedAmount(ctx context.Context, month, year int) (map[uint]money.Money, error) {
 	db := utils.GetDBFromContext(ctx, r.db)
 	type Result struct {
 		UserID      uint
-		TotalAmount float64
+		TotalAmount money.Money
 	}
 	var results []Result
 
@@ -93,7 +94,7 @@ func (r *repository) GetBulkApprovedAmount(ctx context.Context, month, year int)
 		return nil, err
 	}
 
-	dataMap := make(map[uint]float64)
+	dataMap := make(map[uint]money.Money)
 	for _, res := range results {
 		dataMap[res.UserID] = res.TotalAmount
 	}
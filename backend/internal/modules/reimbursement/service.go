@@ -27,10 +27,11 @@ type service struct {
 	user               UserProvider
 	transactionManager infrastructure.TransactionManager
 	excel              infrastructure.ExcelProvider
+	payrollLock        PayrollLockProvider
 }
 
-func NewService(repo Repository, storage StorageProvider, notification NotificationProvider, user UserProvider, transactionManager infrastructure.TransactionManager, excel infrastructure.ExcelProvider) Service {
-	return &service{repo, storage, notification, user, transactionManager, excel}
+func NewService(repo Repository, storage StorageProvider, notification NotificationProvider, user UserProvider, transactionManager infrastructure.TransactionManager, excel infrastructure.ExcelProvider, payrollLock PayrollLockProvider) Service {
+	return &service{repo, storage, notification, user, transactionManager, excel, payrollLock}
 }
 
 func (s *service) Create(ctx context.Context, req *ReimbursementRequest) error {
@@ -39,6 +40,15 @@ func (s *service) Create(ctx context.Context, req *ReimbursementRequest) error {
 			return fmt.Errorf("user id is invalid")
 		}
 
+		dateExpense, err := time.Parse(constants.DefaultTimeFormat, req.Date)
+		if err != nil {
+			return fmt.Errorf("invalid date format: %w", err)
+		}
+
+		if err := s.checkPeriodLocked(ctx, dateExpense); err != nil {
+			return err
+		}
+
 		ext := filepath.Ext(req.File.Filename)
 		newFileName := fmt.Sprintf("%s%s", uuid.New().String(), ext)
 
@@ -50,11 +60,6 @@ func (s *service) Create(ctx context.Context, req *ReimbursementRequest) error {
 			return fmt.Errorf("failed to upload proof: %w", err)
 		}
 
-		dateExpense, err := time.Parse(constants.DefaultTimeFormat, req.Date)
-		if err != nil {
-			return fmt.Errorf("invalid date format: %w", err)
-		}
-
 		reimburstment := &Reimbursement{
 			UserID:        req.UserID,
 			Title:         req.Title,
@@ -163,6 +168,10 @@ func (s *service) ProcessAction(ctx context.Context, req *ActionRequest) error {
 		)
 		switch constants.ReimbursementAction(req.Action) {
 		case constants.ReimbursementActionApprove:
+			if err := s.checkPeriodLocked(ctx, data.DateOfExpense); err != nil {
+				return err
+			}
+
 			data.Status = constants.ReimbursementStatusApproved
 			data.ApprovedBy = &req.SuperAdminID
 
@@ -231,7 +240,7 @@ func (s *service) Export(ctx context.Context, filter ReimbursementFilter) ([]byt
 			rem.ID,
 			empName,
 			rem.Title,
-			rem.Amount,
+			rem.Amount.Float64(),
 			rem.DateOfExpense.Format("2006-01-02"),
 			rem.Status,
 			rem.Description,
@@ -242,3 +251,17 @@ func (s *service) Export(ctx context.Context, filter ReimbursementFilter) ([]byt
 
 	return s.excel.GenerateSimpleExcel("Reimbursements", headers, rows)
 }
+
+// checkPeriodLocked reject changes of reimbursement which payroll period already approved
+func (s *service) checkPeriodLocked(ctx context.Context, date time.Time) error {
+	locked, err := s.payrollLock.IsPeriodLocked(ctx, date)
+	if err != nil {
+		return err
+	}
+
+	if locked {
+		return fmt.Errorf("payroll period %s is locked", date.Format(constants.PayrollTimeFormat))
+	}
+
+	return nil
+}
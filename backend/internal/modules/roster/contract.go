@@ -0,0 +1,10 @@
+package roster
+
+import (
+	"basekarya-backend/internal/modules/user"
+	"context"
+)
+
+type UserProvider interface {
+	FindAllEmployeeActive(ctx context.Context) ([]user.Employee, error)
+}
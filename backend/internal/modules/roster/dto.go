@@ -0,0 +1,76 @@
+package roster
+
+type RosterFilter struct {
+	Month        int
+	Year         int
+	DepartmentID uint
+	EmployeeID   uint
+}
+
+// AssignRequest set the same shift, or day off, on every date of the range for the employees or a whole department
+type AssignRequest struct {
+	EmployeeIDs  []uint `json:"employee_ids"`
+	DepartmentID *uint  `json:"department_id"`
+	StartDate    string `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate      string `json:"end_date" validate:"required,datetime=2006-01-02"`
+	ShiftID      *uint  `json:"shift_id" validate:"required_without=IsDayOff"`
+	IsDayOff     bool   `json:"is_day_off"`
+}
+
+// ApplyPatternRequest roster the employees by the rotation pattern, the first step start on AnchorDate (default StartDate)
+type ApplyPatternRequest struct {
+	EmployeeIDs  []uint `json:"employee_ids"`
+	DepartmentID *uint  `json:"department_id"`
+	PatternID    uint   `json:"pattern_id" validate:"required"`
+	StartDate    string `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate      string `json:"end_date" validate:"required,datetime=2006-01-02"`
+	AnchorDate   string `json:"anchor_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type RotationPatternRequest struct {
+	Name  string        `json:"name" validate:"required,max=100"`
+	Steps []StepRequest `json:"steps" validate:"required,min=1,dive"`
+}
+
+type StepRequest struct {
+	ShiftID *uint `json:"shift_id"`
+	Days    int   `json:"days" validate:"required,min=1,max=31"`
+}
+
+type AssignResponse struct {
+	EmployeeCount int `json:"employee_count"`
+	RosterCount   int `json:"roster_count"`
+}
+
+type EmployeeRosterResponse struct {
+	EmployeeID   uint                `json:"employee_id"`
+	EmployeeName string              `json:"employee_name"`
+	EmployeeNIK  string              `json:"employee_nik"`
+	Department   string              `json:"department"`
+	Days         []RosterDayResponse `json:"days"`
+}
+
+// RosterDayResponse is the resolved shift of a date, IsRostered is false when it is the fixed shift of the employee
+type RosterDayResponse struct {
+	Date       string `json:"date"`
+	ShiftID    *uint  `json:"shift_id"`
+	ShiftName  string `json:"shift_name"`
+	IsDayOff   bool   `json:"is_day_off"`
+	IsRostered bool   `json:"is_rostered"`
+}
+
+type ImportResponse struct {
+	Month         int           `json:"month"`
+	Year          int           `json:"year"`
+	TotalRows     int           `json:"total_rows"`
+	ImportedRows  int           `json:"imported_rows"`
+	RosterCount   int           `json:"roster_count"`
+	FailedRows    int           `json:"failed_rows"`
+	FailedDetails []ImportError `json:"failed_details"`
+}
+
+type ImportError struct {
+	Row     int    `json:"row"`
+	NIK     string `json:"nik"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,58 @@
+package roster
+
+import (
+	"basekarya-backend/internal/modules/master"
+	"basekarya-backend/internal/modules/user"
+	"time"
+)
+
+// ShiftRoster assign a shift to an employee on a date, overriding the fixed shift of the employee
+type ShiftRoster struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	EmployeeID uint      `gorm:"not null;uniqueIndex:idx_shift_rosters_employee_date,priority:1" json:"employee_id"`
+	Date       time.Time `gorm:"type:date;not null;uniqueIndex:idx_shift_rosters_employee_date,priority:2" json:"date"`
+
+	// ShiftID is nil when the employee is off on the date
+	ShiftID *uint `json:"shift_id"`
+
+	Employee *user.Employee `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	Shift    *master.Shift  `gorm:"foreignKey:ShiftID" json:"shift,omitempty"`
+}
+
+func (ShiftRoster) TableName() string {
+	return "shift_rosters"
+}
+
+// RotationPattern is a repeating schedule, e.g. a week of morning then a week of evening then a week of night shift
+type RotationPattern struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Name string `gorm:"type:varchar(100);not null" json:"name"`
+
+	Steps []RotationPatternStep `gorm:"foreignKey:RotationPatternID;constraint:OnDelete:CASCADE" json:"steps"`
+}
+
+func (RotationPattern) TableName() string {
+	return "rotation_patterns"
+}
+
+// RotationPatternStep last for Days on the shift, nil ShiftID is days off
+type RotationPatternStep struct {
+	ID                uint `gorm:"primaryKey" json:"id"`
+	RotationPatternID uint `gorm:"not null;index" json:"rotation_pattern_id"`
+
+	Sequence int   `gorm:"not null" json:"sequence"`
+	ShiftID  *uint `json:"shift_id"`
+	Days     int   `gorm:"not null" json:"days"`
+
+	Shift *master.Shift `gorm:"foreignKey:ShiftID" json:"shift,omitempty"`
+}
+
+func (RotationPatternStep) TableName() string {
+	return "rotation_pattern_steps"
+}
@@ -0,0 +1,244 @@
+package roster
+
+import (
+	"basekarya-backend/pkg/logger"
+	"basekarya-backend/pkg/response"
+	"basekarya-backend/pkg/utils"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service}
+}
+
+func (h *Handler) GetAll(ctx echo.Context) error {
+	filter := parseFilter(ctx)
+	departmentID, _ := strconv.Atoi(ctx.QueryParam("department_id"))
+	filter.DepartmentID = uint(departmentID)
+
+	resp, err := h.service.GetRoster(ctx.Request().Context(), filter)
+	if err != nil {
+		logger.Errorw("get roster failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Get Roster Successfully", resp, nil, nil)
+}
+
+func (h *Handler) GetMyRoster(ctx echo.Context) error {
+	userContext, err := utils.GetUserContext(ctx)
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	if userContext.EmployeeID == nil {
+		err := errors.New("employee data not found")
+		return response.NewResponses[any](ctx, http.StatusBadRequest, err.Error(), nil, err, nil)
+	}
+
+	filter := parseFilter(ctx)
+	filter.EmployeeID = *userContext.EmployeeID
+
+	resp, err := h.service.GetRoster(ctx.Request().Context(), filter)
+	if err != nil {
+		logger.Errorw("get my roster failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	var data any
+	if len(resp) > 0 {
+		data = resp[0]
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Get My Roster Successfully", data, nil, nil)
+}
+
+func (h *Handler) Assign(ctx echo.Context) error {
+	var req AssignRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	resp, err := h.service.Assign(ctx.Request().Context(), &req)
+	if err != nil {
+		logger.Errorw("assign roster failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Roster assigned successfully", resp, nil, nil)
+}
+
+func (h *Handler) ApplyPattern(ctx echo.Context) error {
+	var req ApplyPatternRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	resp, err := h.service.ApplyPattern(ctx.Request().Context(), &req)
+	if err != nil {
+		logger.Errorw("apply rotation pattern failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Rotation pattern applied successfully", resp, nil, nil)
+}
+
+func (h *Handler) Import(ctx echo.Context) error {
+	month, _ := strconv.Atoi(ctx.FormValue("month"))
+	year, _ := strconv.Atoi(ctx.FormValue("year"))
+	if month < 1 || month > 12 || year < 2024 {
+		err := errors.New("invalid month or year")
+		return response.NewResponses[any](ctx, http.StatusBadRequest, err.Error(), nil, err, nil)
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "file required", nil, err, nil)
+	}
+
+	if fileHeader.Size > 5*1024*1024 {
+		err := errors.New("File size exceeds 5MB limit")
+		return response.NewResponses[any](ctx, http.StatusBadRequest, err.Error(), nil, err, nil)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "failed to open file", nil, err, nil)
+	}
+	defer file.Close()
+
+	resp, err := h.service.Import(ctx.Request().Context(), month, year, file)
+	if err != nil {
+		logger.Errorw("import roster failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Roster imported successfully", resp, nil, nil)
+}
+
+func (h *Handler) Export(ctx echo.Context) error {
+	filter := parseFilter(ctx)
+	departmentID, _ := strconv.Atoi(ctx.QueryParam("department_id"))
+	filter.DepartmentID = uint(departmentID)
+
+	excelFile, err := h.service.Export(ctx.Request().Context(), filter)
+	if err != nil {
+		logger.Errorw("export roster failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	filename := fmt.Sprintf("Shift_Roster_%d_%02d.xlsx", filter.Year, filter.Month)
+	ctx.Response().Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	ctx.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+
+	return ctx.Blob(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", excelFile)
+}
+
+func (h *Handler) GetPatterns(ctx echo.Context) error {
+	resp, err := h.service.GetPatterns(ctx.Request().Context())
+	if err != nil {
+		logger.Errorw("get rotation patterns failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Get Rotation Patterns Successfully", resp, nil, nil)
+}
+
+func (h *Handler) CreatePattern(ctx echo.Context) error {
+	var req RotationPatternRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	resp, err := h.service.CreatePattern(ctx.Request().Context(), &req)
+	if err != nil {
+		logger.Errorw("create rotation pattern failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusCreated, "Rotation pattern created successfully", resp, nil, nil)
+}
+
+func (h *Handler) UpdatePattern(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "invalid id", nil, err, nil)
+	}
+
+	var req RotationPatternRequest
+	if err := ctx.Bind(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := ctx.Validate(&req); err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "Invalid Request", nil, err, nil)
+	}
+
+	if err := h.service.UpdatePattern(ctx.Request().Context(), uint(id), &req); err != nil {
+		logger.Errorw("update rotation pattern failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Rotation pattern updated successfully", nil, nil, nil)
+}
+
+func (h *Handler) DeletePattern(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return response.NewResponses[any](ctx, http.StatusBadRequest, "invalid id", nil, err, nil)
+	}
+
+	if err := h.service.DeletePattern(ctx.Request().Context(), uint(id)); err != nil {
+		logger.Errorw("delete rotation pattern failed: ", err)
+
+		return response.NewResponses[any](ctx, http.StatusInternalServerError, err.Error(), nil, err, nil)
+	}
+
+	return response.NewResponses[any](ctx, http.StatusOK, "Rotation pattern deleted successfully", nil, nil, nil)
+}
+
+// parseFilter read month & year of the query, default to the current month
+func parseFilter(ctx echo.Context) RosterFilter {
+	now := time.Now()
+	month, _ := strconv.Atoi(ctx.QueryParam("month"))
+	year, _ := strconv.Atoi(ctx.QueryParam("year"))
+	if month < 1 || month > 12 {
+		month = int(now.Month())
+	}
+	if year == 0 {
+		year = now.Year()
+	}
+
+	return RosterFilter{Month: month, Year: year}
+}
@@ -0,0 +1,101 @@
+package roster
+
+import (
+	"basekarya-backend/internal/modules/master"
+	"basekarya-backend/internal/modules/user"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dayOffLabel is written on the roster sheet for a day off
+const dayOffLabel = "OFF"
+
+// parseRosterSheet read the monthly roster sheet, first row is header of NIK, name then day of month.
+// a cell is a shift name or OFF, empty cell keep the current schedule of the date. row with any invalid cell is skipped
+func parseRosterSheet(rows [][]string, month, year int, shifts []master.Shift, employees []user.Employee) ([]ShiftRoster, *ImportResponse, error) {
+	if len(rows) == 0 {
+		return nil, nil, errors.New("roster sheet is empty")
+	}
+
+	start, end := periodRange(month, year)
+
+	dayColumns := make(map[int]time.Time)
+	for col, header := range rows[0] {
+		if col < 2 {
+			continue
+		}
+
+		day, err := strconv.Atoi(strings.TrimSpace(header))
+		if err != nil || day < 1 || day > end.Day() {
+			return nil, nil, fmt.Errorf("invalid day header %q on column %d", header, col+1)
+		}
+		dayColumns[col] = start.AddDate(0, 0, day-1)
+	}
+	if len(dayColumns) == 0 {
+		return nil, nil, errors.New("roster sheet has no day column")
+	}
+
+	shiftByName := make(map[string]*master.Shift)
+	for i := range shifts {
+		shiftByName[strings.ToLower(strings.TrimSpace(shifts[i].Name))] = &shifts[i]
+	}
+
+	employeeByNIK := make(map[string]user.Employee)
+	for _, emp := range employees {
+		employeeByNIK[emp.NIK] = emp
+	}
+
+	resp := &ImportResponse{Month: month, Year: year, FailedDetails: []ImportError{}}
+	var rosters []ShiftRoster
+	for i, row := range rows[1:] {
+		rowNumber := i + 2
+		if len(row) == 0 || strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		resp.TotalRows++
+
+		nik := strings.TrimSpace(row[0])
+		emp, ok := employeeByNIK[nik]
+		if !ok {
+			resp.FailedDetails = append(resp.FailedDetails, ImportError{Row: rowNumber, NIK: nik, Message: "active employee not found"})
+			continue
+		}
+
+		var rowRosters []ShiftRoster
+		var rowErr error
+		for col := 2; col < len(row); col++ {
+			value := strings.TrimSpace(row[col])
+			date, ok := dayColumns[col]
+			if value == "" || !ok {
+				continue
+			}
+
+			roster := ShiftRoster{EmployeeID: emp.ID, Date: date}
+			if !strings.EqualFold(value, dayOffLabel) {
+				shift, ok := shiftByName[strings.ToLower(value)]
+				if !ok {
+					rowErr = fmt.Errorf("unknown shift %q on day %d", value, date.Day())
+					break
+				}
+				roster.ShiftID = &shift.ID
+			}
+			rowRosters = append(rowRosters, roster)
+		}
+
+		if rowErr != nil {
+			resp.FailedDetails = append(resp.FailedDetails, ImportError{Row: rowNumber, NIK: nik, Message: rowErr.Error()})
+			continue
+		}
+
+		rosters = append(rosters, rowRosters...)
+		resp.ImportedRows++
+	}
+
+	resp.RosterCount = len(rosters)
+	resp.FailedRows = len(resp.FailedDetails)
+
+	return rosters, resp, nil
+}
@@ -0,0 +1,43 @@
+package roster
+
+import (
+	"basekarya-backend/internal/modules/master"
+	"basekarya-backend/internal/modules/user"
+	"testing"
+)
+
+func TestParseRosterSheet(t *testing.T) {
+	shifts := []master.Shift{{ID: 1, Name: "Pagi"}, {ID: 2, Name: "Malam"}}
+	employees := []user.Employee{{ID: 10, NIK: "EMP001"}, {ID: 11, NIK: "EMP002"}}
+
+	rows := [][]string{
+		{"NIK", "Nama", "1", "2", "3"},
+		{"EMP001", "Budi", "pagi", "OFF", ""},
+		{"EMP002", "Sari", "Malam", "Siang", "Pagi"},
+		{"EMP999", "Unknown", "Pagi"},
+		{},
+	}
+
+	rosters, resp, err := parseRosterSheet(rows, 10, 2026, shifts, employees)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.TotalRows != 3 || resp.ImportedRows != 1 || resp.FailedRows != 2 {
+		t.Errorf("total=%d imported=%d failed=%d, want 3 1 2", resp.TotalRows, resp.ImportedRows, resp.FailedRows)
+	}
+
+	if len(rosters) != 2 {
+		t.Fatalf("got %d rosters, want 2", len(rosters))
+	}
+	if rosters[0].ShiftID == nil || *rosters[0].ShiftID != 1 || rosters[0].Date.Day() != 1 {
+		t.Errorf("day 1 = %+v, want shift 1", rosters[0])
+	}
+	if !rosters[1].IsDayOff() || rosters[1].Date.Day() != 2 {
+		t.Errorf("day 2 = %+v, want day off", rosters[1])
+	}
+
+	if _, _, err := parseRosterSheet([][]string{{"NIK", "Nama", "31"}}, 2, 2026, shifts, employees); err == nil {
+		t.Error("day 31 of february should be rejected")
+	}
+}
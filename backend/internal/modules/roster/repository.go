@@ -0,0 +1,196 @@
+package roster
+
+import (
+	"basekarya-backend/internal/modules/master"
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/utils"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	FindByPeriod(ctx context.Context, start, end time.Time, employeeIDs []uint) ([]ShiftRoster, error)
+	FindByEmployeeAndDate(ctx context.Context, employeeID uint, date time.Time) (*ShiftRoster, error)
+	FindByDate(ctx context.Context, date time.Time) (map[uint]ShiftRoster, error)
+	Upsert(ctx context.Context, rosters []ShiftRoster) error
+
+	FindAllShifts(ctx context.Context) ([]master.Shift, error)
+
+	FindAllPatterns(ctx context.Context) ([]RotationPattern, error)
+	FindPatternByID(ctx context.Context, id uint) (*RotationPattern, error)
+	CreatePattern(ctx context.Context, pattern *RotationPattern) error
+	UpdatePattern(ctx context.Context, pattern *RotationPattern) error
+	DeletePattern(ctx context.Context, id uint) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db}
+}
+
+func (r *repository) FindByPeriod(ctx context.Context, start, end time.Time, employeeIDs []uint) ([]ShiftRoster, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var rosters []ShiftRoster
+
+	err := db.
+		Preload("Shift").
+		Where("employee_id IN ?", employeeIDs).
+		Where("date BETWEEN ? AND ?", start.Format(constants.DefaultTimeFormat), end.Format(constants.DefaultTimeFormat)).
+		Order("date ASC").
+		Find(&rosters).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rosters, nil
+}
+
+// FindByEmployeeAndDate return nil when the employee is not rostered on the date
+func (r *repository) FindByEmployeeAndDate(ctx context.Context, employeeID uint, date time.Time) (*ShiftRoster, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var roster ShiftRoster
+
+	err := db.
+		Preload("Shift").
+		Where("employee_id = ? AND date = ?", employeeID, date.Format(constants.DefaultTimeFormat)).
+		First(&roster).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &roster, nil
+}
+
+// FindByDate return roster of every rostered employee on the date, keyed by employee id
+func (r *repository) FindByDate(ctx context.Context, date time.Time) (map[uint]ShiftRoster, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var rosters []ShiftRoster
+
+	err := db.
+		Preload("Shift").
+		Where("date = ?", date.Format(constants.DefaultTimeFormat)).
+		Find(&rosters).Error
+	if err != nil {
+		return nil, err
+	}
+
+	dataMap := make(map[uint]ShiftRoster)
+	for _, roster := range rosters {
+		dataMap[roster.EmployeeID] = roster
+	}
+
+	return dataMap, nil
+}
+
+// Upsert replace the shift of rostered dates & create the others
+func (r *repository) Upsert(ctx context.Context, rosters []ShiftRoster) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	if len(rosters) == 0 {
+		return nil
+	}
+
+	return db.Omit("Employee", "Shift").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "employee_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"shift_id", "updated_at"}),
+	}).CreateInBatches(rosters, 500).Error
+}
+
+func (r *repository) FindAllShifts(ctx context.Context) ([]master.Shift, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var shifts []master.Shift
+
+	if err := db.Find(&shifts).Error; err != nil {
+		return nil, err
+	}
+
+	return shifts, nil
+}
+
+func (r *repository) FindAllPatterns(ctx context.Context) ([]RotationPattern, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var patterns []RotationPattern
+
+	err := db.
+		Preload("Steps", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence ASC")
+		}).
+		Preload("Steps.Shift").
+		Order("name ASC").
+		Find(&patterns).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return patterns, nil
+}
+
+func (r *repository) FindPatternByID(ctx context.Context, id uint) (*RotationPattern, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var pattern RotationPattern
+
+	err := db.
+		Preload("Steps", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence ASC")
+		}).
+		Preload("Steps.Shift").
+		First(&pattern, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &pattern, nil
+}
+
+// CreatePattern save the pattern & its steps, run inside a transaction
+func (r *repository) CreatePattern(ctx context.Context, pattern *RotationPattern) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+
+	if err := db.Omit("Steps").Create(pattern).Error; err != nil {
+		return err
+	}
+
+	return r.createSteps(db, pattern)
+}
+
+// UpdatePattern save the pattern & replace all of its steps, run inside a transaction
+func (r *repository) UpdatePattern(ctx context.Context, pattern *RotationPattern) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+
+	if err := db.Omit("Steps").Save(pattern).Error; err != nil {
+		return err
+	}
+
+	if err := db.Where("rotation_pattern_id = ?", pattern.ID).Delete(&RotationPatternStep{}).Error; err != nil {
+		return err
+	}
+
+	return r.createSteps(db, pattern)
+}
+
+func (r *repository) createSteps(db *gorm.DB, pattern *RotationPattern) error {
+	for i := range pattern.Steps {
+		pattern.Steps[i].RotationPatternID = pattern.ID
+	}
+	if len(pattern.Steps) > 0 {
+		if err := db.Omit("Shift").Create(&pattern.Steps).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *repository) DeletePattern(ctx context.Context, id uint) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Delete(&RotationPattern{}, id).Error
+}
@@ -0,0 +1,55 @@
+package roster
+
+import (
+	"basekarya-backend/internal/modules/master"
+	"basekarya-backend/internal/modules/user"
+	"time"
+)
+
+// ResolveShift return the shift of the employee on a date, the roster entry of the date override the fixed shift
+// of the employee. nil mean the employee is off or has no shift at all
+func ResolveShift(emp *user.Employee, entry *ShiftRoster) *master.Shift {
+	if entry == nil {
+		return emp.Shift
+	}
+
+	return entry.Shift
+}
+
+// IsDayOff return true when the roster entry is a day off
+func (r *ShiftRoster) IsDayOff() bool {
+	return r.ShiftID == nil
+}
+
+// stepOn return the step of the pattern on date, the first step start on anchor & the cycle repeat both way.
+// steps must be ordered by sequence
+func (p *RotationPattern) stepOn(anchor, date time.Time) *RotationPatternStep {
+	cycle := 0
+	for _, s := range p.Steps {
+		cycle += s.Days
+	}
+	if cycle <= 0 {
+		return nil
+	}
+
+	offset := daysBetween(anchor, date) % cycle
+	if offset < 0 {
+		offset += cycle
+	}
+
+	for i := range p.Steps {
+		if offset < p.Steps[i].Days {
+			return &p.Steps[i]
+		}
+		offset -= p.Steps[i].Days
+	}
+
+	return nil
+}
+
+// daysBetween count calendar days from start to end, ignoring the time of day
+func daysBetween(start, end time.Time) int {
+	s := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	e := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	return int(e.Sub(s).Hours() / 24)
+}
@@ -0,0 +1,47 @@
+package roster
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRotationPatternStepOn(t *testing.T) {
+	morning, evening, night := uint(1), uint(2), uint(3)
+	pattern := RotationPattern{Steps: []RotationPatternStep{
+		{Sequence: 1, ShiftID: &morning, Days: 7},
+		{Sequence: 2, ShiftID: &evening, Days: 7},
+		{Sequence: 3, ShiftID: &night, Days: 5},
+		{Sequence: 4, ShiftID: nil, Days: 2},
+	}}
+	anchor := time.Date(2026, 10, 5, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name string
+		date time.Time
+		want *uint
+	}{
+		{"first day", anchor, &morning},
+		{"second week", anchor.AddDate(0, 0, 7), &evening},
+		{"night week", anchor.AddDate(0, 0, 18), &night},
+		{"day off at end of cycle", anchor.AddDate(0, 0, 20), nil},
+		{"next cycle", anchor.AddDate(0, 0, 21), &morning},
+		{"before anchor wrap around", anchor.AddDate(0, 0, -1), nil},
+		{"before anchor night", anchor.AddDate(0, 0, -3), &night},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := pattern.stepOn(anchor, tt.date)
+			if step == nil {
+				t.Fatal("step is nil")
+			}
+			if (step.ShiftID == nil) != (tt.want == nil) || (tt.want != nil && *step.ShiftID != *tt.want) {
+				t.Errorf("shift = %v, want %v", step.ShiftID, tt.want)
+			}
+		})
+	}
+
+	if step := (&RotationPattern{}).stepOn(anchor, anchor); step != nil {
+		t.Errorf("empty pattern should have no step, got %+v", step)
+	}
+}
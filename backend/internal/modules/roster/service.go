@@ -0,0 +1,423 @@
+package roster
+
+import (
+	"basekarya-backend/internal/infrastructure"
+	"basekarya-backend/internal/modules/master"
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/constants"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// maxAssignDays limit the date range of a single assignment
+const maxAssignDays = 93
+
+type Service interface {
+	GetRoster(ctx context.Context, filter RosterFilter) ([]EmployeeRosterResponse, error)
+	Assign(ctx context.Context, req *AssignRequest) (*AssignResponse, error)
+	ApplyPattern(ctx context.Context, req *ApplyPatternRequest) (*AssignResponse, error)
+	Import(ctx context.Context, month, year int, file io.Reader) (*ImportResponse, error)
+	Export(ctx context.Context, filter RosterFilter) ([]byte, error)
+
+	GetPatterns(ctx context.Context) ([]RotationPattern, error)
+	CreatePattern(ctx context.Context, req *RotationPatternRequest) (*RotationPattern, error)
+	UpdatePattern(ctx context.Context, id uint, req *RotationPatternRequest) error
+	DeletePattern(ctx context.Context, id uint) error
+}
+
+type service struct {
+	repo               Repository
+	user               UserProvider
+	transactionManager infrastructure.TransactionManager
+	excel              infrastructure.ExcelProvider
+}
+
+func NewService(repo Repository, user UserProvider, transactionManager infrastructure.TransactionManager, excel infrastructure.ExcelProvider) Service {
+	return &service{repo, user, transactionManager, excel}
+}
+
+func (s *service) GetRoster(ctx context.Context, filter RosterFilter) ([]EmployeeRosterResponse, error) {
+	employees, err := s.filterEmployees(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := periodRange(filter.Month, filter.Year)
+	entries, err := s.rosterMap(ctx, start, end, employees)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := []EmployeeRosterResponse{}
+	for _, emp := range employees {
+		department := ""
+		if emp.Department != nil {
+			department = emp.Department.Name
+		}
+
+		item := EmployeeRosterResponse{
+			EmployeeID:   emp.ID,
+			EmployeeName: emp.FullName,
+			EmployeeNIK:  emp.NIK,
+			Department:   department,
+			Days:         []RosterDayResponse{},
+		}
+
+		for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+			day := date.Format(constants.DefaultTimeFormat)
+			entry, rostered := entries[emp.ID][day]
+
+			var shift *master.Shift
+			if rostered {
+				shift = ResolveShift(&emp, &entry)
+			} else {
+				shift = ResolveShift(&emp, nil)
+			}
+
+			dayResp := RosterDayResponse{Date: day, IsRostered: rostered, IsDayOff: shift == nil}
+			if shift != nil {
+				dayResp.ShiftID = &shift.ID
+				dayResp.ShiftName = shift.Name
+			}
+			item.Days = append(item.Days, dayResp)
+		}
+
+		resp = append(resp, item)
+	}
+
+	return resp, nil
+}
+
+func (s *service) Assign(ctx context.Context, req *AssignRequest) (*AssignResponse, error) {
+	start, end, err := parseRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	employees, err := s.targetEmployees(ctx, req.EmployeeIDs, req.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var shiftID *uint
+	if !req.IsDayOff {
+		if err := s.validateShifts(ctx, req.ShiftID); err != nil {
+			return nil, err
+		}
+		shiftID = req.ShiftID
+	}
+
+	var rosters []ShiftRoster
+	for _, emp := range employees {
+		for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+			rosters = append(rosters, ShiftRoster{EmployeeID: emp.ID, Date: date, ShiftID: shiftID})
+		}
+	}
+
+	if err := s.repo.Upsert(ctx, rosters); err != nil {
+		return nil, err
+	}
+
+	return &AssignResponse{EmployeeCount: len(employees), RosterCount: len(rosters)}, nil
+}
+
+func (s *service) ApplyPattern(ctx context.Context, req *ApplyPatternRequest) (*AssignResponse, error) {
+	start, end, err := parseRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	anchor := start
+	if req.AnchorDate != "" {
+		anchor, err = time.ParseInLocation(constants.DefaultTimeFormat, req.AnchorDate, time.Local)
+		if err != nil {
+			return nil, errors.New("invalid anchor date format, use YYYY-MM-DD")
+		}
+	}
+
+	pattern, err := s.repo.FindPatternByID(ctx, req.PatternID)
+	if err != nil {
+		return nil, errors.New("rotation pattern not found")
+	}
+
+	employees, err := s.targetEmployees(ctx, req.EmployeeIDs, req.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rosters []ShiftRoster
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		step := pattern.stepOn(anchor, date)
+		if step == nil {
+			return nil, errors.New("rotation pattern has no step")
+		}
+
+		for _, emp := range employees {
+			rosters = append(rosters, ShiftRoster{EmployeeID: emp.ID, Date: date, ShiftID: step.ShiftID})
+		}
+	}
+
+	if err := s.repo.Upsert(ctx, rosters); err != nil {
+		return nil, err
+	}
+
+	return &AssignResponse{EmployeeCount: len(employees), RosterCount: len(rosters)}, nil
+}
+
+// Import roster a month from excel with the layout of Export, failed rows are skipped & reported
+func (s *service) Import(ctx context.Context, month, year int, file io.Reader) (*ImportResponse, error) {
+	rows, err := s.excel.ReadRows(file)
+	if err != nil {
+		return nil, errors.New("invalid excel file")
+	}
+
+	employees, err := s.user.FindAllEmployeeActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shifts, err := s.repo.FindAllShifts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rosters, resp, err := parseRosterSheet(rows, month, year, shifts, employees)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		return s.repo.Upsert(ctx, rosters)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Export write the roster of a month as excel, one row per employee & one column per date. it is also the import template
+func (s *service) Export(ctx context.Context, filter RosterFilter) ([]byte, error) {
+	roster, err := s.GetRoster(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := periodRange(filter.Month, filter.Year)
+	headers := []string{"NIK", "Nama"}
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		headers = append(headers, fmt.Sprintf("%d", date.Day()))
+	}
+
+	var rows [][]interface{}
+	for _, emp := range roster {
+		row := []interface{}{emp.EmployeeNIK, emp.EmployeeName}
+		for _, day := range emp.Days {
+			if day.IsDayOff {
+				row = append(row, dayOffLabel)
+			} else {
+				row = append(row, day.ShiftName)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return s.excel.GenerateSimpleExcel(fmt.Sprintf("Roster %02d-%d", filter.Month, filter.Year), headers, rows)
+}
+
+func (s *service) GetPatterns(ctx context.Context) ([]RotationPattern, error) {
+	return s.repo.FindAllPatterns(ctx)
+}
+
+func (s *service) CreatePattern(ctx context.Context, req *RotationPatternRequest) (*RotationPattern, error) {
+	pattern := RotationPattern{}
+	if err := s.fillPattern(ctx, &pattern, req); err != nil {
+		return nil, err
+	}
+
+	err := s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		return s.repo.CreatePattern(ctx, &pattern)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pattern, nil
+}
+
+func (s *service) UpdatePattern(ctx context.Context, id uint, req *RotationPatternRequest) error {
+	pattern, err := s.repo.FindPatternByID(ctx, id)
+	if err != nil {
+		return errors.New("rotation pattern not found")
+	}
+
+	if err := s.fillPattern(ctx, pattern, req); err != nil {
+		return err
+	}
+
+	return s.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		return s.repo.UpdatePattern(ctx, pattern)
+	})
+}
+
+func (s *service) DeletePattern(ctx context.Context, id uint) error {
+	if _, err := s.repo.FindPatternByID(ctx, id); err != nil {
+		return errors.New("rotation pattern not found")
+	}
+
+	return s.repo.DeletePattern(ctx, id)
+}
+
+func (s *service) fillPattern(ctx context.Context, pattern *RotationPattern, req *RotationPatternRequest) error {
+	pattern.Name = strings.TrimSpace(req.Name)
+
+	pattern.Steps = []RotationPatternStep{}
+	var shiftIDs []*uint
+	for i, step := range req.Steps {
+		shiftIDs = append(shiftIDs, step.ShiftID)
+		pattern.Steps = append(pattern.Steps, RotationPatternStep{
+			Sequence: i + 1,
+			ShiftID:  step.ShiftID,
+			Days:     step.Days,
+		})
+	}
+
+	return s.validateShifts(ctx, shiftIDs...)
+}
+
+// validateShifts return error when any of the non nil shift id does not exist
+func (s *service) validateShifts(ctx context.Context, shiftIDs ...*uint) error {
+	shifts, err := s.repo.FindAllShifts(ctx)
+	if err != nil {
+		return err
+	}
+
+	exists := make(map[uint]bool)
+	for _, shift := range shifts {
+		exists[shift.ID] = true
+	}
+
+	for _, id := range shiftIDs {
+		if id != nil && !exists[*id] {
+			return fmt.Errorf("shift %d not found", *id)
+		}
+	}
+
+	return nil
+}
+
+// targetEmployees return active employees of the ids or of the department, exactly one of them must be set
+func (s *service) targetEmployees(ctx context.Context, employeeIDs []uint, departmentID *uint) ([]user.Employee, error) {
+	if (len(employeeIDs) == 0) == (departmentID == nil) {
+		return nil, errors.New("must have either employee_ids or department_id")
+	}
+
+	filter := RosterFilter{}
+	if departmentID != nil {
+		filter.DepartmentID = *departmentID
+	}
+
+	employees, err := s.filterEmployees(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(employeeIDs) > 0 {
+		wanted := make(map[uint]bool)
+		for _, id := range employeeIDs {
+			wanted[id] = true
+		}
+
+		var selected []user.Employee
+		for _, emp := range employees {
+			if wanted[emp.ID] {
+				selected = append(selected, emp)
+			}
+		}
+		employees = selected
+	}
+
+	if len(employees) == 0 {
+		return nil, errors.New("no active employee found")
+	}
+
+	return employees, nil
+}
+
+func (s *service) filterEmployees(ctx context.Context, filter RosterFilter) ([]user.Employee, error) {
+	employees, err := s.user.FindAllEmployeeActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []user.Employee
+	for _, emp := range employees {
+		if filter.DepartmentID != 0 && emp.DepartmentID != filter.DepartmentID {
+			continue
+		}
+		if filter.EmployeeID != 0 && emp.ID != filter.EmployeeID {
+			continue
+		}
+		filtered = append(filtered, emp)
+	}
+
+	return filtered, nil
+}
+
+// rosterMap return roster of the employees on the period, keyed by employee id then date
+func (s *service) rosterMap(ctx context.Context, start, end time.Time, employees []user.Employee) (map[uint]map[string]ShiftRoster, error) {
+	dataMap := make(map[uint]map[string]ShiftRoster)
+	if len(employees) == 0 {
+		return dataMap, nil
+	}
+
+	var ids []uint
+	for _, emp := range employees {
+		ids = append(ids, emp.ID)
+	}
+
+	rosters, err := s.repo.FindByPeriod(ctx, start, end, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range rosters {
+		if dataMap[r.EmployeeID] == nil {
+			dataMap[r.EmployeeID] = make(map[string]ShiftRoster)
+		}
+		dataMap[r.EmployeeID][r.Date.Format(constants.DefaultTimeFormat)] = r
+	}
+
+	return dataMap, nil
+}
+
+func parseRange(startDate, endDate string) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation(constants.DefaultTimeFormat, startDate, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("invalid start date format, use YYYY-MM-DD")
+	}
+
+	end, err := time.ParseInLocation(constants.DefaultTimeFormat, endDate, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("invalid end date format, use YYYY-MM-DD")
+	}
+
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, errors.New("end date must be after start date")
+	}
+
+	if daysBetween(start, end) >= maxAssignDays {
+		return time.Time{}, time.Time{}, fmt.Errorf("date range cannot exceed %d days", maxAssignDays)
+	}
+
+	return start, end, nil
+}
+
+func periodRange(month, year int) (time.Time, time.Time) {
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+	return start, start.AddDate(0, 1, -1)
+}
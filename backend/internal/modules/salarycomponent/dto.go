@@ -0,0 +1,54 @@
+package salarycomponent
+
+import (
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"time"
+)
+
+type SalaryComponentFilter struct {
+	Page   int
+	Limit  int
+	Search string
+}
+
+type SalaryComponentRequest struct {
+	Name            string                                   `json:"name" validate:"required,max=150"`
+	Type            constants.PayrollDetailType              `json:"type" validate:"required,oneof=ALLOWANCE DEDUCTION"`
+	CalculationType constants.SalaryComponentCalculationType `json:"calculation_type" validate:"required,oneof=FIXED PERCENTAGE"`
+	Amount          money.Money                              `json:"amount" validate:"min=0"`
+	Percentage      float64                                  `json:"percentage" validate:"min=0,max=100"`
+	IsTaxable       *bool                                    `json:"is_taxable"`
+	IsActive        *bool                                    `json:"is_active"`
+}
+
+type AssignmentRequest struct {
+	SalaryComponentID uint         `json:"-"`
+	EmployeeID        *uint        `json:"employee_id"`
+	DepartmentID      *uint        `json:"department_id"`
+	Amount            *money.Money `json:"amount" validate:"omitempty,min=0"`
+	Percentage        *float64     `json:"percentage" validate:"omitempty,min=0,max=100"`
+}
+
+type SalaryComponentResponse struct {
+	ID              uint                                     `json:"id"`
+	Name            string                                   `json:"name"`
+	Type            constants.PayrollDetailType              `json:"type"`
+	CalculationType constants.SalaryComponentCalculationType `json:"calculation_type"`
+	Amount          money.Money                              `json:"amount"`
+	Percentage      float64                                  `json:"percentage"`
+	IsTaxable       bool                                     `json:"is_taxable"`
+	IsActive        bool                                     `json:"is_active"`
+	CreatedAt       time.Time                                `json:"created_at"`
+	Assignments     []AssignmentResponse                     `json:"assignments,omitempty"`
+}
+
+type AssignmentResponse struct {
+	ID             uint         `json:"id"`
+	EmployeeID     *uint        `json:"employee_id"`
+	EmployeeName   string       `json:"employee_name"`
+	DepartmentID   *uint        `json:"department_id"`
+	DepartmentName string       `json:"department_name"`
+	Amount         *money.Money `json:"amount"`
+	Percentage     *float64     `json:"percentage"`
+}
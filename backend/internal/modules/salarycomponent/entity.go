@@ -0,0 +1,84 @@
+package salarycomponent
+
+import (
+	"basekarya-backend/internal/modules/master"
+	"basekarya-backend/internal/modules/user"
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SalaryComponent struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Name string `gorm:"type:varchar(150);not null" json:"name"`
+
+	Type            constants.PayrollDetailType              `gorm:"type:varchar(20);not null" json:"type"`
+	CalculationType constants.SalaryComponentCalculationType `gorm:"type:varchar(20);not null;default:'FIXED'" json:"calculation_type"`
+
+	// Amount is the nominal of FIXED components, Percentage is the share of base salary of PERCENTAGE components
+	Amount     money.Money `gorm:"type:decimal(15,2);not null" json:"amount"`
+	Percentage float64     `gorm:"type:decimal(5,2);not null" json:"percentage"`
+
+	IsTaxable bool `json:"is_taxable"`
+	IsActive  bool `json:"is_active"`
+
+	Assignments []SalaryComponentAssignment `gorm:"foreignKey:SalaryComponentID;constraint:OnDelete:CASCADE" json:"assignments,omitempty"`
+}
+
+type SalaryComponentAssignment struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	SalaryComponentID uint  `gorm:"not null;index" json:"salary_component_id"`
+	EmployeeID        *uint `json:"employee_id"`
+	DepartmentID      *uint `json:"department_id"`
+
+	// Amount & Percentage override the value of the component when not nil
+	Amount     *money.Money `gorm:"type:decimal(15,2)" json:"amount"`
+	Percentage *float64     `gorm:"type:decimal(5,2)" json:"percentage"`
+
+	SalaryComponent *SalaryComponent   `gorm:"foreignKey:SalaryComponentID" json:"salary_component,omitempty"`
+	Employee        *user.Employee     `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	Department      *master.Department `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
+}
+
+func (SalaryComponent) TableName() string {
+	return "salary_components"
+}
+
+func (SalaryComponentAssignment) TableName() string {
+	return "salary_component_assignments"
+}
+
+// ResolveAmount return the nominal of this assignment, falling back to the component default
+func (a *SalaryComponentAssignment) ResolveAmount() money.Money {
+	if a.Amount != nil {
+		return *a.Amount
+	}
+
+	if a.SalaryComponent != nil {
+		return a.SalaryComponent.Amount
+	}
+
+	return 0
+}
+
+// ResolvePercentage return the percentage of base salary of this assignment, falling back to the component default
+func (a *SalaryComponentAssignment) ResolvePercentage() float64 {
+	if a.Percentage != nil {
+		return *a.Percentage
+	}
+
+	if a.SalaryComponent != nil {
+		return a.SalaryComponent.Percentage
+	}
+
+	return 0
+}
@@ -0,0 +1,110 @@
+package salarycomponent
+
+import (
+	"basekarya-backend/pkg/utils"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, component *SalaryComponent) error
+	FindByID(ctx context.Context, id uint) (*SalaryComponent, error)
+	FindAll(ctx context.Context, filter *SalaryComponentFilter) ([]SalaryComponent, int64, error)
+	Update(ctx context.Context, component *SalaryComponent) error
+	Delete(ctx context.Context, id uint) error
+	CreateAssignment(ctx context.Context, assignment *SalaryComponentAssignment) error
+	DeleteAssignment(ctx context.Context, id uint) error
+	FindAllActiveAssignments(ctx context.Context) ([]SalaryComponentAssignment, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db}
+}
+
+func (r *repository) Create(ctx context.Context, component *SalaryComponent) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Create(component).Error
+}
+
+func (r *repository) FindByID(ctx context.Context, id uint) (*SalaryComponent, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var component SalaryComponent
+
+	err := db.
+		Preload("Assignments").
+		Preload("Assignments.Employee").
+		Preload("Assignments.Department").
+		First(&component, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &component, nil
+}
+
+func (r *repository) FindAll(ctx context.Context, filter *SalaryComponentFilter) ([]SalaryComponent, int64, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var components []SalaryComponent
+	var total int64
+
+	query := db.Model(&SalaryComponent{})
+
+	if filter.Search != "" {
+		searchParam := "%" + filter.Search + "%"
+		query = query.Where("LOWER(name) LIKE LOWER(?)", searchParam)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	err := query.
+		Order("type ASC, name ASC").
+		Limit(filter.Limit).
+		Offset(offset).
+		Find(&components).Error
+
+	return components, total, err
+}
+
+func (r *repository) Update(ctx context.Context, component *SalaryComponent) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Omit("Assignments").Save(component).Error
+}
+
+func (r *repository) Delete(ctx context.Context, id uint) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Delete(&SalaryComponent{}, id).Error
+}
+
+func (r *repository) CreateAssignment(ctx context.Context, assignment *SalaryComponentAssignment) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Create(assignment).Error
+}
+
+func (r *repository) DeleteAssignment(ctx context.Context, id uint) error {
+	db := utils.GetDBFromContext(ctx, r.db)
+	return db.Delete(&SalaryComponentAssignment{}, id).Error
+}
+
+func (r *repository) FindAllActiveAssignments(ctx context.Context) ([]SalaryComponentAssignment, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var assignments []SalaryComponentAssignment
+
+	err := db.Model(&SalaryComponentAssignment{}).
+		Joins("JOIN salary_components ON salary_components.id = salary_component_assignments.salary_component_id").
+		Where("salary_components.is_active = ? AND salary_components.deleted_at IS NULL", true).
+		Preload("SalaryComponent").
+		Find(&assignments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
@@ -0,0 +1,211 @@
+package salarycomponent
+
+import (
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/response"
+	"context"
+	"errors"
+	"fmt"
+)
+
+type Service interface {
+	Create(ctx context.Context, req *SalaryComponentRequest) error
+	Update(ctx context.Context, id uint, req *SalaryComponentRequest) error
+	Delete(ctx context.Context, id uint) error
+	GetList(ctx context.Context, filter *SalaryComponentFilter) ([]SalaryComponentResponse, *response.Meta, error)
+	GetDetail(ctx context.Context, id uint) (*SalaryComponentResponse, error)
+	Assign(ctx context.Context, req *AssignmentRequest) error
+	Unassign(ctx context.Context, assignmentID uint) error
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo}
+}
+
+func (s *service) Create(ctx context.Context, req *SalaryComponentRequest) error {
+	if err := validateComponentRequest(req); err != nil {
+		return err
+	}
+
+	isTaxable := true
+	if req.IsTaxable != nil {
+		isTaxable = *req.IsTaxable
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	component := &SalaryComponent{
+		Name:            req.Name,
+		Type:            req.Type,
+		CalculationType: req.CalculationType,
+		Amount:          req.Amount,
+		Percentage:      req.Percentage,
+		IsTaxable:       isTaxable,
+		IsActive:        isActive,
+	}
+
+	return s.repo.Create(ctx, component)
+}
+
+func (s *service) Update(ctx context.Context, id uint, req *SalaryComponentRequest) error {
+	if err := validateComponentRequest(req); err != nil {
+		return err
+	}
+
+	component, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return errors.New("salary component not found")
+	}
+
+	component.Name = req.Name
+	component.Type = req.Type
+	component.CalculationType = req.CalculationType
+	component.Amount = req.Amount
+	component.Percentage = req.Percentage
+	if req.IsTaxable != nil {
+		component.IsTaxable = *req.IsTaxable
+	}
+	if req.IsActive != nil {
+		component.IsActive = *req.IsActive
+	}
+
+	return s.repo.Update(ctx, component)
+}
+
+func (s *service) Delete(ctx context.Context, id uint) error {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return errors.New("salary component not found")
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *service) GetList(ctx context.Context, filter *SalaryComponentFilter) ([]SalaryComponentResponse, *response.Meta, error) {
+	components, total, err := s.repo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(components) == 0 {
+		return []SalaryComponentResponse{}, nil, nil
+	}
+
+	var list []SalaryComponentResponse
+	for _, c := range components {
+		list = append(list, toComponentResponse(&c))
+	}
+
+	meta := response.NewMetaOffset(filter.Page, filter.Limit, total)
+	return list, meta, nil
+}
+
+func (s *service) GetDetail(ctx context.Context, id uint) (*SalaryComponentResponse, error) {
+	component, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toComponentResponse(component)
+	resp.Assignments = []AssignmentResponse{}
+
+	for _, a := range component.Assignments {
+		employeeName := "-"
+		departmentName := "-"
+
+		if a.Employee != nil {
+			employeeName = a.Employee.FullName
+		}
+		if a.Department != nil {
+			departmentName = a.Department.Name
+		}
+
+		resp.Assignments = append(resp.Assignments, AssignmentResponse{
+			ID:             a.ID,
+			EmployeeID:     a.EmployeeID,
+			EmployeeName:   employeeName,
+			DepartmentID:   a.DepartmentID,
+			DepartmentName: departmentName,
+			Amount:         a.Amount,
+			Percentage:     a.Percentage,
+		})
+	}
+
+	return &resp, nil
+}
+
+func (s *service) Assign(ctx context.Context, req *AssignmentRequest) error {
+	// assignment target must be exactly one of employee or department
+	if (req.EmployeeID == nil) == (req.DepartmentID == nil) {
+		return errors.New("assignment requires either employee_id or department_id")
+	}
+
+	component, err := s.repo.FindByID(ctx, req.SalaryComponentID)
+	if err != nil {
+		return errors.New("salary component not found")
+	}
+
+	if err := validateValue(component.CalculationType, req.Amount != nil && *req.Amount != 0, req.Percentage != nil && *req.Percentage != 0); err != nil {
+		return err
+	}
+
+	for _, a := range component.Assignments {
+		if req.EmployeeID != nil && a.EmployeeID != nil && *a.EmployeeID == *req.EmployeeID {
+			return fmt.Errorf("component already assigned to employee %d", *req.EmployeeID)
+		}
+		if req.DepartmentID != nil && a.DepartmentID != nil && *a.DepartmentID == *req.DepartmentID {
+			return fmt.Errorf("component already assigned to department %d", *req.DepartmentID)
+		}
+	}
+
+	assignment := &SalaryComponentAssignment{
+		SalaryComponentID: component.ID,
+		EmployeeID:        req.EmployeeID,
+		DepartmentID:      req.DepartmentID,
+		Amount:            req.Amount,
+		Percentage:        req.Percentage,
+	}
+
+	return s.repo.CreateAssignment(ctx, assignment)
+}
+
+func (s *service) Unassign(ctx context.Context, assignmentID uint) error {
+	return s.repo.DeleteAssignment(ctx, assignmentID)
+}
+
+func validateComponentRequest(req *SalaryComponentRequest) error {
+	return validateValue(req.CalculationType, req.Amount != 0, req.Percentage != 0)
+}
+
+// validateValue reject the value that does not belong to the calculation type, FIXED use amount & PERCENTAGE use percentage
+func validateValue(calculationType constants.SalaryComponentCalculationType, hasAmount, hasPercentage bool) error {
+	if calculationType == constants.SalaryComponentCalculationPercentage && hasAmount {
+		return errors.New("percentage component use percentage, not amount")
+	}
+
+	if calculationType != constants.SalaryComponentCalculationPercentage && hasPercentage {
+		return errors.New("fixed component use amount, not percentage")
+	}
+
+	return nil
+}
+
+func toComponentResponse(c *SalaryComponent) SalaryComponentResponse {
+	return SalaryComponentResponse{
+		ID:              c.ID,
+		Name:            c.Name,
+		Type:            c.Type,
+		CalculationType: c.CalculationType,
+		Amount:          c.Amount,
+		Percentage:      c.Percentage,
+		IsTaxable:       c.IsTaxable,
+		IsActive:        c.IsActive,
+		CreatedAt:       c.CreatedAt,
+	}
+}
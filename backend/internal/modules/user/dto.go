@@ -1,24 +1,32 @@
 package user
 
+import (
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
+)
+
 type UserProfileResponse struct {
-	ID                 uint    `json:"id"`
-	Username           string  `json:"username"`
-	Role               string  `json:"role"`
-	FullName           string  `json:"full_name"`
-	NIK                string  `json:"nik"`
-	DepartmentName     string  `json:"department_name"`
-	ShiftName          string  `json:"shift_name"`
-	ShiftStartTime     string  `json:"shift_start_time"`
-	ShiftEndTime       string  `json:"shift_end_time"`
-	PhoneNumber        string  `json:"phone_number"`
-	ProfilePictureUrl  string  `json:"profile_picture_url"`
-	MustChangePassword bool    `json:"must_change_password"`
-	BankName           string  `json:"bank_name"`
-	BankAccountNumber  string  `json:"bank_account_number"`
-	BankAccountHolder  string  `json:"bank_account_holder"`
-	NPWP               string  `json:"npwp"`
-	Email              string  `json:"email"`
-	BaseSalary         float64 `json:"base_salary"`
+	ID                 uint        `json:"id"`
+	Username           string      `json:"username"`
+	Role               string      `json:"role"`
+	FullName           string      `json:"full_name"`
+	NIK                string      `json:"nik"`
+	DepartmentName     string      `json:"department_name"`
+	ShiftName          string      `json:"shift_name"`
+	ShiftStartTime     string      `json:"shift_start_time"`
+	ShiftEndTime       string      `json:"shift_end_time"`
+	PhoneNumber        string      `json:"phone_number"`
+	ProfilePictureUrl  string      `json:"profile_picture_url"`
+	MustChangePassword bool        `json:"must_change_password"`
+	BankName           string      `json:"bank_name"`
+	BankAccountNumber  string      `json:"bank_account_number"`
+	BankAccountHolder  string      `json:"bank_account_holder"`
+	NPWP               string      `json:"npwp"`
+	Email              string      `json:"email"`
+	BaseSalary         money.Money `json:"base_salary"`
+	MaritalStatus      string      `json:"marital_status"`
+	Dependents         int         `json:"dependents"`
+	BirthDate          *string     `json:"birth_date"`
 }
 
 type UpdateProfileRequest struct {
@@ -38,31 +46,47 @@ type ChangePasswordRequest struct {
 }
 
 type EmployeeListResponse struct {
-	ID             uint    `json:"id"`
-	FullName       string  `json:"full_name"`
-	NIK            string  `json:"nik"`
-	Username       string  `json:"username"`
-	DepartmentName string  `json:"department_name"`
-	ShiftName      string  `json:"shift_name"`
-	BaseSalary     float64 `json:"base_salary"`
-	Email          string  `json:"email"`
+	ID             uint        `json:"id"`
+	FullName       string      `json:"full_name"`
+	NIK            string      `json:"nik"`
+	Username       string      `json:"username"`
+	DepartmentName string      `json:"department_name"`
+	ShiftName      string      `json:"shift_name"`
+	BaseSalary     money.Money `json:"base_salary"`
+	Email          string      `json:"email"`
+	BirthDate      *string     `json:"birth_date"`
+	JoinDate       *string     `json:"join_date"`
+	EndDate        *string     `json:"end_date"`
 }
 
 type CreateEmployeeRequest struct {
-	Username     string  `json:"username" validate:"required"`
-	FullName     string  `json:"full_name" validate:"required"`
-	NIK          string  `json:"nik" validate:"required"`
-	DepartmentID uint    `json:"department_id" validate:"required"`
-	ShiftID      uint    `json:"shift_id" validate:"required"`
-	BaseSalary   float64 `json:"base_salary" validate:"required"`
-	Email        string  `json:"email" validate:"required"`
+	Username     string      `json:"username" validate:"required"`
+	FullName     string      `json:"full_name" validate:"required"`
+	NIK          string      `json:"nik" validate:"required"`
+	DepartmentID uint        `json:"department_id" validate:"required"`
+	ShiftID      uint        `json:"shift_id" validate:"required"`
+	BaseSalary   money.Money `json:"base_salary" validate:"required"`
+	Email        string      `json:"email" validate:"required"`
+
+	MaritalStatus constants.MaritalStatus `json:"marital_status" validate:"omitempty,oneof=TK K"`
+	Dependents    int                     `json:"dependents" validate:"min=0,max=3"`
+
+	BirthDate string `json:"birth_date" validate:"omitempty,datetime=2006-01-02"`
+	JoinDate  string `json:"join_date" validate:"omitempty,datetime=2006-01-02"`
 }
 
 type UpdateEmployeeRequest struct {
-	FullName     string  `json:"full_name"`
-	NIK          string  `json:"nik"`
-	DepartmentID uint    `json:"department_id"`
-	ShiftID      uint    `json:"shift_id"`
-	BaseSalary   float64 `json:"base_salary"`
-	Email        string  `json:"email"`
+	FullName     string      `json:"full_name"`
+	NIK          string      `json:"nik"`
+	DepartmentID uint        `json:"department_id"`
+	ShiftID      uint        `json:"shift_id"`
+	BaseSalary   money.Money `json:"base_salary"`
+	Email        string      `json:"email"`
+
+	MaritalStatus constants.MaritalStatus `json:"marital_status" validate:"omitempty,oneof=TK K"`
+	Dependents    *int                    `json:"dependents" validate:"omitempty,min=0,max=3"`
+
+	BirthDate string `json:"birth_date" validate:"omitempty,datetime=2006-01-02"`
+	JoinDate  string `json:"join_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   string `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
 }
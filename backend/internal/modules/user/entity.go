@@ -2,6 +2,8 @@ package user
 
 import (
 	"basekarya-backend/internal/modules/master"
+	"basekarya-backend/pkg/constants"
+	"basekarya-backend/pkg/money"
 	"time"
 )
 
@@ -28,7 +30,7 @@ type Employee struct {
 	PhoneNumber       string `json:"phone_number"`
 	ProfilePictureUrl string `json:"profile_picture_url"`
 
-	BaseSalary float64 `gorm:"type:decimal(15,2);default:0" json:"base_salary"`
+	BaseSalary money.Money `gorm:"type:decimal(15,2);default:0" json:"base_salary"`
 
 	BankName          string `gorm:"type:varchar(50)" json:"bank_name"`
 	BankAccountNumber string `gorm:"type:varchar(50)" json:"bank_account_number"`
@@ -37,6 +39,13 @@ type Employee struct {
 	NPWP  string `gorm:"type:varchar(30)" json:"npwp"`
 	Email string `gorm:"type:varchar(255)" json:"email"`
 
+	MaritalStatus constants.MaritalStatus `gorm:"type:varchar(5);default:'TK'" json:"marital_status"`
+	Dependents    int                     `gorm:"default:0" json:"dependents"`
+
+	BirthDate *time.Time `gorm:"type:date" json:"birth_date"`
+	JoinDate  *time.Time `gorm:"type:date" json:"join_date"`
+	EndDate   *time.Time `gorm:"type:date" json:"end_date"`
+
 	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
 
 	Department *master.Department `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
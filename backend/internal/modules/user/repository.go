@@ -22,6 +22,7 @@ type Repository interface {
 	CountActiveEmployee(ctx context.Context) (int64, error)
 	FindAllEmployeeActive(ctx context.Context) ([]Employee, error)
 	FindAdminID(ctx context.Context) (uint, error)
+	HasPayrollHistory(ctx context.Context, employeeID uint) (bool, error)
 }
 
 type repository struct {
@@ -164,3 +165,18 @@ func (r *repository) FindAdminID(ctx context.Context) (uint, error) {
 
 	return id, nil
 }
+
+// HasPayrollHistory return true when any payroll, including void & final settlement, is ever generated for the employee
+func (r *repository) HasPayrollHistory(ctx context.Context, employeeID uint) (bool, error) {
+	db := utils.GetDBFromContext(ctx, r.db)
+	var count int64
+
+	err := db.Table("payrolls").
+		Where("employee_id = ?", employeeID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
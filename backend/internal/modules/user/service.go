@@ -7,6 +7,7 @@ import (
 	"basekarya-backend/internal/infrastructure"
 	"basekarya-backend/pkg/constants"
 	"basekarya-backend/pkg/response"
+	"basekarya-backend/pkg/money"
 	"mime/multipart"
 	"time"
 )
@@ -57,6 +58,9 @@ func (s *service) GetProfile(userID uint) (*UserProfileResponse, error) {
 		resp.BankAccountHolder = user.Employee.BankAccountHolder
 		resp.NPWP = user.Employee.NPWP
 		resp.Email = user.Employee.Email
+		resp.MaritalStatus = string(user.Employee.MaritalStatus)
+		resp.Dependents = user.Employee.Dependents
+		resp.BirthDate = formatOptionalDate(user.Employee.BirthDate)
 
 		if user.Employee.Department != nil {
 			resp.DepartmentName = user.Employee.Department.Name
@@ -126,7 +130,7 @@ func (s *service) GetAllEmployees(ctx context.Context, page, limit int, search s
 	for _, u := range users {
 		deptName := "-"
 		shiftName := "-"
-		baseSalary := 0.0
+		var baseSalary money.Money
 
 		if u.Employee != nil {
 			if u.Employee.Department != nil {
@@ -148,6 +152,9 @@ func (s *service) GetAllEmployees(ctx context.Context, page, limit int, search s
 				ShiftName:      shiftName,
 				BaseSalary:     baseSalary,
 				Email:          u.Employee.Email,
+				BirthDate:      formatOptionalDate(u.Employee.BirthDate),
+				JoinDate:       formatOptionalDate(u.Employee.JoinDate),
+				EndDate:        formatOptionalDate(u.Employee.EndDate),
 			})
 		}
 	}
@@ -184,6 +191,29 @@ func (s *service) CreateEmployee(ctx context.Context, req *CreateEmployeeRequest
 			ShiftID:      req.ShiftID,
 			BaseSalary:   req.BaseSalary,
 			Email:        req.Email,
+
+			MaritalStatus: constants.MaritalStatusSingle,
+			Dependents:    req.Dependents,
+		}
+
+		if req.MaritalStatus != "" {
+			newEmp.MaritalStatus = req.MaritalStatus
+		}
+
+		if req.BirthDate != "" {
+			birthDate, err := time.Parse(constants.DefaultTimeFormat, req.BirthDate)
+			if err != nil {
+				return fmt.Errorf("invalid birth date format: %w", err)
+			}
+			newEmp.BirthDate = &birthDate
+		}
+
+		if req.JoinDate != "" {
+			joinDate, err := time.Parse(constants.DefaultTimeFormat, req.JoinDate)
+			if err != nil {
+				return fmt.Errorf("invalid join date format: %w", err)
+			}
+			newEmp.JoinDate = &joinDate
 		}
 
 		if err := s.repo.CreateEmployee(ctx, &newEmp); err != nil {
@@ -223,6 +253,37 @@ func (s *service) UpdateEmployee(ctx context.Context, id uint, req *UpdateEmploy
 	if req.Email != "" {
 		emp.Email = req.Email
 	}
+	if req.MaritalStatus != "" {
+		emp.MaritalStatus = req.MaritalStatus
+	}
+	if req.Dependents != nil {
+		emp.Dependents = *req.Dependents
+	}
+	if req.BirthDate != "" {
+		birthDate, err := time.Parse(constants.DefaultTimeFormat, req.BirthDate)
+		if err != nil {
+			return fmt.Errorf("invalid birth date format: %w", err)
+		}
+		emp.BirthDate = &birthDate
+	}
+	if req.JoinDate != "" {
+		joinDate, err := time.Parse(constants.DefaultTimeFormat, req.JoinDate)
+		if err != nil {
+			return fmt.Errorf("invalid join date format: %w", err)
+		}
+		emp.JoinDate = &joinDate
+	}
+	if req.EndDate != "" {
+		endDate, err := time.Parse(constants.DefaultTimeFormat, req.EndDate)
+		if err != nil {
+			return fmt.Errorf("invalid end date format: %w", err)
+		}
+		emp.EndDate = &endDate
+	}
+
+	if emp.JoinDate != nil && emp.EndDate != nil && emp.EndDate.Before(*emp.JoinDate) {
+		return errors.New("end date cannot be before join date")
+	}
 
 	return s.repo.UpdateEmployee(ctx, emp)
 }
@@ -233,6 +294,15 @@ func (s *service) DeleteEmployee(ctx context.Context, id uint) error {
 		return errors.New("employee not found")
 	}
 
+	// deleting the user cascade to payroll, settlement & tax history of the employee
+	hasPayroll, err := s.repo.HasPayrollHistory(ctx, emp.ID)
+	if err != nil {
+		return err
+	}
+	if hasPayroll {
+		return errors.New("employee has payroll history and cannot be deleted, create a final settlement instead")
+	}
+
 	return s.repo.DeleteUser(ctx, emp.UserID)
 }
 
@@ -277,3 +347,12 @@ func (s *service) buildEmployeeData(ctx context.Context, user *User, req *Update
 
 	return user, nil
 }
+
+func formatOptionalDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+
+	formatted := date.Format(constants.DefaultTimeFormat)
+	return &formatted
+}
@@ -58,6 +58,12 @@ func (r *Router) setupRoutes() {
 		userOnly.GET("/attendances/today", r.container.AttendanceHandler.GetTodayStatus)
 		userOnly.GET("/attendances/history", r.container.AttendanceHandler.GetHistory)
 
+		userOnly.GET("/attendance-corrections", r.container.CorrectionHandler.GetAll)
+		userOnly.POST("/attendance-corrections", r.container.CorrectionHandler.Create)
+		userOnly.GET("/attendance-corrections/:id", r.container.CorrectionHandler.GetDetail)
+
+		userOnly.GET("/rosters/me", r.container.RosterHandler.GetMyRoster)
+
 		userOnly.GET("/reimbursements", r.container.ReimbursementHandler.GetAll)
 		userOnly.GET("/reimbursements/export", r.container.ReimbursementHandler.Export)
 		userOnly.POST("/reimbursements", r.container.ReimbursementHandler.Create)
@@ -90,6 +96,11 @@ func (r *Router) setupRoutes() {
 		userOnly.POST("/overtimes", r.container.OvertimeHandler.Create)
 		userOnly.GET("/overtimes/:id", r.container.OvertimeHandler.GetDetail)
 		userOnly.PUT("/overtimes/:id/action", r.container.OvertimeHandler.ProcessAction)
+
+		// Payroll
+		userOnly.GET("/payrolls/me", r.container.PayrollHandler.GetMyList)
+		userOnly.GET("/payrolls/me/tax-forms/download", r.container.PayrollHandler.DownloadMyTaxForm)
+		userOnly.GET("/payrolls/me/:id/download", r.container.PayrollHandler.DownloadMyPayslipPDF)
 	}
 
 	// only admin can access
@@ -111,10 +122,76 @@ func (r *Router) setupRoutes() {
 
 		adminOnly.GET("/payrolls", r.container.PayrollHandler.GetList)
 		adminOnly.POST("/payrolls/generate", r.container.PayrollHandler.Generate)
+		adminOnly.POST("/payrolls/thr/generate", r.container.PayrollHandler.GenerateTHR)
+		adminOnly.POST("/payrolls/simulate", r.container.PayrollHandler.Simulate)
+		adminOnly.POST("/payrolls/regenerate", r.container.PayrollHandler.RegeneratePeriod)
+		adminOnly.POST("/payrolls/send-email", r.container.PayrollHandler.SendAllPayslipEmail)
+		adminOnly.GET("/payrolls/bpjs-recap/export", r.container.PayrollHandler.ExportBPJSRecap)
+		adminOnly.GET("/payrolls/bank-transfer/export", r.container.PayrollHandler.ExportBankTransfer)
+		adminOnly.GET("/payrolls/compare", r.container.PayrollHandler.ComparePeriods)
+		adminOnly.GET("/payrolls/compare/export", r.container.PayrollHandler.ExportComparison)
+		adminOnly.GET("/payrolls/journal/export", r.container.PayrollHandler.ExportJournal)
+		adminOnly.GET("/payrolls/journal/setting", r.container.PayrollHandler.GetJournalSetting)
+		adminOnly.PUT("/payrolls/journal/accounts/:id", r.container.PayrollHandler.UpdateJournalAccount)
+		adminOnly.PUT("/payrolls/journal/mappings", r.container.PayrollHandler.ReplaceJournalMappings)
+		adminOnly.GET("/payrolls/tax-forms/export", r.container.PayrollHandler.ExportTaxForms)
+		adminOnly.POST("/payrolls/tax-forms/send-email", r.container.PayrollHandler.SendTaxFormEmails)
+		adminOnly.GET("/payrolls/tax-forms/:employeeId/download", r.container.PayrollHandler.DownloadTaxForm)
+		adminOnly.POST("/payrolls/final-settlement", r.container.PayrollHandler.CreateFinalSettlement)
+		adminOnly.GET("/payrolls/severance-rules", r.container.PayrollHandler.GetSeveranceRules)
+		adminOnly.PUT("/payrolls/severance-rules/:id", r.container.PayrollHandler.UpdateSeveranceRule)
 		adminOnly.GET("/payrolls/:id", r.container.PayrollHandler.GetDetail)
 		adminOnly.GET("/payrolls/:id/download", r.container.PayrollHandler.DownloadPayslipPDF)
+		adminOnly.GET("/payrolls/:id/exit-document", r.container.PayrollHandler.DownloadExitDocument)
 		adminOnly.PUT("/payrolls/:id/status", r.container.PayrollHandler.MarkAsPaid)
 		adminOnly.POST("/payrolls/:id/send-email", r.container.PayrollHandler.BlastPayslipEmail)
+		adminOnly.POST("/payrolls/:id/regenerate", r.container.PayrollHandler.Regenerate)
+		adminOnly.PUT("/payrolls/:id/void", r.container.PayrollHandler.Void)
+
+		adminOnly.GET("/payroll-runs", r.container.PayrollHandler.GetRuns)
+		adminOnly.GET("/payroll-runs/:id", r.container.PayrollHandler.GetRunDetail)
+		adminOnly.PUT("/payroll-runs/:id/action", r.container.PayrollHandler.ProcessRunAction)
+
+		adminOnly.GET("/payslip-email-jobs", r.container.PayrollHandler.GetPayslipEmailJobs)
+		adminOnly.GET("/payslip-email-jobs/:id", r.container.PayrollHandler.GetPayslipEmailJobDetail)
+		adminOnly.POST("/payslip-email-jobs/:id/retry", r.container.PayrollHandler.RetryPayslipEmailJob)
+
+		adminOnly.GET("/salary-components", r.container.SalaryComponentHandler.GetAll)
+		adminOnly.POST("/salary-components", r.container.SalaryComponentHandler.Create)
+		adminOnly.GET("/salary-components/:id", r.container.SalaryComponentHandler.GetDetail)
+		adminOnly.PUT("/salary-components/:id", r.container.SalaryComponentHandler.Update)
+		adminOnly.DELETE("/salary-components/:id", r.container.SalaryComponentHandler.Delete)
+		adminOnly.POST("/salary-components/:id/assignments", r.container.SalaryComponentHandler.Assign)
+		adminOnly.DELETE("/salary-components/:id/assignments/:assignmentId", r.container.SalaryComponentHandler.Unassign)
+
+		adminOnly.GET("/bpjs/rates", r.container.BPJSHandler.GetRates)
+		adminOnly.PUT("/bpjs/rates/:id", r.container.BPJSHandler.UpdateRate)
+
+		adminOnly.GET("/holidays", r.container.HolidayHandler.GetAll)
+		adminOnly.POST("/holidays", r.container.HolidayHandler.Create)
+		adminOnly.PUT("/holidays/:id", r.container.HolidayHandler.Update)
+		adminOnly.DELETE("/holidays/:id", r.container.HolidayHandler.Delete)
+
+		adminOnly.PUT("/attendance-corrections/:id/action", r.container.CorrectionHandler.ProcessAction)
+
+		adminOnly.GET("/office-locations", r.container.OfficeHandler.GetAll)
+		adminOnly.POST("/office-locations", r.container.OfficeHandler.Create)
+		adminOnly.PUT("/office-locations/:id", r.container.OfficeHandler.Update)
+		adminOnly.DELETE("/office-locations/:id", r.container.OfficeHandler.Delete)
+
+		adminOnly.GET("/rosters", r.container.RosterHandler.GetAll)
+		adminOnly.GET("/rosters/export", r.container.RosterHandler.Export)
+		adminOnly.POST("/rosters/assign", r.container.RosterHandler.Assign)
+		adminOnly.POST("/rosters/apply-pattern", r.container.RosterHandler.ApplyPattern)
+		adminOnly.POST("/rosters/import", r.container.RosterHandler.Import)
+
+		adminOnly.GET("/rotation-patterns", r.container.RosterHandler.GetPatterns)
+		adminOnly.POST("/rotation-patterns", r.container.RosterHandler.CreatePattern)
+		adminOnly.PUT("/rotation-patterns/:id", r.container.RosterHandler.UpdatePattern)
+		adminOnly.DELETE("/rotation-patterns/:id", r.container.RosterHandler.DeletePattern)
+
+		adminOnly.GET("/late-policy", r.container.LatePolicyHandler.Get)
+		adminOnly.PUT("/late-policy", r.container.LatePolicyHandler.Update)
 
 		adminOnly.GET("/company/profile", r.container.CompanyHandler.GetProfile)
 		adminOnly.PUT("/company/profile", r.container.CompanyHandler.UpdateProfile)
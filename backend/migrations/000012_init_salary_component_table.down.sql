@@ -0,0 +1,5 @@
+ALTER TABLE payroll_details
+DROP COLUMN is_taxable;
+
+DROP TABLE IF EXISTS salary_component_assignments;
+DROP TABLE IF EXISTS salary_components;
@@ -0,0 +1,49 @@
+CREATE TABLE salary_components (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMP NULL,
+
+  name VARCHAR(150) NOT NULL,
+  type VARCHAR(20) NOT NULL COMMENT 'ALLOWANCE or DEDUCTION',
+  calculation_type VARCHAR(20) NOT NULL DEFAULT 'FIXED' COMMENT 'FIXED nominal or PERCENTAGE of base salary',
+  amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  is_taxable BOOLEAN DEFAULT TRUE,
+  is_active BOOLEAN DEFAULT TRUE,
+
+  INDEX idx_salary_components_deleted_at (deleted_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE salary_component_assignments (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  salary_component_id BIGINT NOT NULL,
+  employee_id BIGINT NULL,
+  department_id BIGINT NULL,
+  amount DECIMAL(15, 2) NULL COMMENT 'Override of the component amount, NULL uses the component default',
+
+  INDEX idx_salary_component_assignments_component_id (salary_component_id),
+
+  CONSTRAINT fk_salary_component_assignments_component
+    FOREIGN KEY (salary_component_id)
+    REFERENCES salary_components(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE,
+
+  CONSTRAINT fk_salary_component_assignments_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE,
+
+  CONSTRAINT fk_salary_component_assignments_department
+    FOREIGN KEY (department_id)
+    REFERENCES ref_departments(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE payroll_details
+ADD COLUMN is_taxable BOOLEAN DEFAULT FALSE;
@@ -0,0 +1,7 @@
+ALTER TABLE payrolls
+DROP COLUMN tax_amount,
+DROP COLUMN taxable_income;
+
+ALTER TABLE employees
+DROP COLUMN dependents,
+DROP COLUMN marital_status;
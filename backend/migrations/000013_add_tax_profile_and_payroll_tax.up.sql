@@ -0,0 +1,7 @@
+ALTER TABLE employees
+ADD COLUMN marital_status VARCHAR(5) NOT NULL DEFAULT 'TK' COMMENT 'PTKP marital status, TK (tidak kawin) or K (kawin)',
+ADD COLUMN dependents INT NOT NULL DEFAULT 0 COMMENT 'PTKP dependants, max 3';
+
+ALTER TABLE payrolls
+ADD COLUMN taxable_income DECIMAL(15, 2) NOT NULL DEFAULT 0,
+ADD COLUMN tax_amount DECIMAL(15, 2) NOT NULL DEFAULT 0;
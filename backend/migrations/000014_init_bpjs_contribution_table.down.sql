@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS payroll_contributions;
+DROP TABLE IF EXISTS bpjs_contribution_rates;
@@ -0,0 +1,40 @@
+CREATE TABLE bpjs_contribution_rates (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  program VARCHAR(20) NOT NULL,
+  employee_rate DECIMAL(5, 2) NOT NULL DEFAULT 0 COMMENT 'Percentage paid by employee',
+  employer_rate DECIMAL(5, 2) NOT NULL DEFAULT 0 COMMENT 'Percentage paid by employer',
+  salary_cap DECIMAL(15, 2) NOT NULL DEFAULT 0 COMMENT 'Maximum contribution base, 0 means no cap',
+  is_active BOOLEAN DEFAULT TRUE,
+
+  UNIQUE KEY uq_bpjs_contribution_rates_program (program)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO bpjs_contribution_rates (program, employee_rate, employer_rate, salary_cap) VALUES
+('KESEHATAN', 1.00, 4.00, 12000000),
+('JHT', 2.00, 3.70, 0),
+('JP', 1.00, 2.00, 10042300),
+('JKK', 0.00, 0.24, 0),
+('JKM', 0.00, 0.30, 0);
+
+CREATE TABLE payroll_contributions (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  payroll_id BIGINT NOT NULL,
+
+  program VARCHAR(20) NOT NULL,
+  base_amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  employee_rate DECIMAL(5, 2) NOT NULL DEFAULT 0,
+  employer_rate DECIMAL(5, 2) NOT NULL DEFAULT 0,
+  employee_amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  employer_amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+
+  INDEX idx_payroll_contributions_payroll_id (payroll_id),
+
+  CONSTRAINT fk_payroll_contributions_payroll
+    FOREIGN KEY (payroll_id)
+    REFERENCES payrolls(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
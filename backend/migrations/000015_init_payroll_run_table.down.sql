@@ -0,0 +1,6 @@
+ALTER TABLE payrolls
+DROP FOREIGN KEY fk_payrolls_payroll_run,
+DROP INDEX idx_payrolls_payroll_run_id,
+DROP COLUMN payroll_run_id;
+
+DROP TABLE IF EXISTS payroll_runs;
@@ -0,0 +1,37 @@
+CREATE TABLE payroll_runs (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  period_date DATE NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'DRAFT' COMMENT 'DRAFT, REVIEWED, APPROVED, PAID, CLOSED',
+
+  total_employee INT NOT NULL DEFAULT 0,
+  total_net_salary DECIMAL(15, 2) NOT NULL DEFAULT 0,
+
+  reviewed_by BIGINT NULL,
+  reviewed_at TIMESTAMP NULL,
+  approved_by BIGINT NULL,
+  approved_at TIMESTAMP NULL,
+  paid_by BIGINT NULL,
+  paid_at TIMESTAMP NULL,
+  closed_by BIGINT NULL,
+  closed_at TIMESTAMP NULL,
+
+  UNIQUE KEY uq_payroll_runs_period_date (period_date),
+  INDEX idx_payroll_runs_status (status),
+
+  CONSTRAINT fk_payroll_runs_reviewer FOREIGN KEY (reviewed_by) REFERENCES users(id),
+  CONSTRAINT fk_payroll_runs_approver FOREIGN KEY (approved_by) REFERENCES users(id),
+  CONSTRAINT fk_payroll_runs_payer FOREIGN KEY (paid_by) REFERENCES users(id),
+  CONSTRAINT fk_payroll_runs_closer FOREIGN KEY (closed_by) REFERENCES users(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE payrolls
+ADD COLUMN payroll_run_id BIGINT NULL AFTER employee_id,
+ADD INDEX idx_payrolls_payroll_run_id (payroll_run_id),
+ADD CONSTRAINT fk_payrolls_payroll_run
+  FOREIGN KEY (payroll_run_id)
+  REFERENCES payroll_runs(id)
+  ON DELETE SET NULL
+  ON UPDATE CASCADE;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS payroll_audits;
+
+ALTER TABLE payrolls
+DROP COLUMN loan_id;
@@ -0,0 +1,27 @@
+ALTER TABLE payrolls
+ADD COLUMN loan_id BIGINT NULL COMMENT 'Loan deducted on this payroll' AFTER payroll_run_id;
+
+CREATE TABLE payroll_audits (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+  payroll_id BIGINT NOT NULL,
+  actor_id BIGINT NOT NULL COMMENT 'User ID of the superadmin who did the action',
+  action VARCHAR(20) NOT NULL COMMENT 'REGENERATE or VOID',
+  reason TEXT NULL,
+
+  previous_status VARCHAR(20) NOT NULL,
+  previous_net_salary DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  new_net_salary DECIMAL(15, 2) NOT NULL DEFAULT 0,
+
+  INDEX idx_payroll_audits_payroll_id (payroll_id),
+
+  CONSTRAINT fk_payroll_audits_payroll
+    FOREIGN KEY (payroll_id)
+    REFERENCES payrolls(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE,
+
+  CONSTRAINT fk_payroll_audits_actor
+    FOREIGN KEY (actor_id) REFERENCES users(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
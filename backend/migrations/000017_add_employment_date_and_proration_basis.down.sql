@@ -0,0 +1,6 @@
+ALTER TABLE companies
+DROP COLUMN proration_basis;
+
+ALTER TABLE employees
+DROP COLUMN end_date,
+DROP COLUMN join_date;
@@ -0,0 +1,6 @@
+ALTER TABLE employees
+ADD COLUMN join_date DATE NULL,
+ADD COLUMN end_date DATE NULL;
+
+ALTER TABLE companies
+ADD COLUMN proration_basis VARCHAR(20) NOT NULL DEFAULT 'WORKING_DAYS' COMMENT 'WORKING_DAYS or CALENDAR_DAYS';
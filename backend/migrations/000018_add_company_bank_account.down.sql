@@ -0,0 +1,3 @@
+ALTER TABLE companies
+DROP COLUMN bank_account_number,
+DROP COLUMN bank_name;
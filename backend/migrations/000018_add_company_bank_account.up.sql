@@ -0,0 +1,3 @@
+ALTER TABLE companies
+ADD COLUMN bank_name VARCHAR(50) NULL COMMENT 'Source bank of payroll bulk transfer',
+ADD COLUMN bank_account_number VARCHAR(50) NULL;
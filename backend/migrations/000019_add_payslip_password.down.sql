@@ -0,0 +1,6 @@
+ALTER TABLE companies
+DROP COLUMN payslip_password_rule,
+DROP COLUMN payslip_password_enabled;
+
+ALTER TABLE employees
+DROP COLUMN birth_date;
@@ -0,0 +1,6 @@
+ALTER TABLE employees
+ADD COLUMN birth_date DATE NULL AFTER email;
+
+ALTER TABLE companies
+ADD COLUMN payslip_password_enabled TINYINT(1) NOT NULL DEFAULT 0,
+ADD COLUMN payslip_password_rule VARCHAR(20) NOT NULL DEFAULT 'NIK_BIRTH_DATE' COMMENT 'NIK_BIRTH_DATE, BIRTH_DATE or NIK';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS payslip_email_deliveries;
+DROP TABLE IF EXISTS payslip_email_jobs;
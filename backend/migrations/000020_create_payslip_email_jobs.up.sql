@@ -0,0 +1,50 @@
+CREATE TABLE payslip_email_jobs (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  period_date DATE NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'QUEUED' COMMENT 'QUEUED, RUNNING or COMPLETED',
+
+  total_count INT NOT NULL DEFAULT 0,
+  sent_count INT NOT NULL DEFAULT 0,
+  failed_count INT NOT NULL DEFAULT 0,
+
+  created_by BIGINT NOT NULL COMMENT 'User ID of the superadmin who started the job',
+  finished_at TIMESTAMP NULL,
+
+  INDEX idx_payslip_email_jobs_period_date (period_date),
+
+  CONSTRAINT fk_payslip_email_jobs_created_by
+    FOREIGN KEY (created_by) REFERENCES users(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE payslip_email_deliveries (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  job_id BIGINT NOT NULL,
+  payroll_id BIGINT NOT NULL,
+  employee_id BIGINT NOT NULL,
+  email VARCHAR(255) NULL,
+
+  status VARCHAR(20) NOT NULL DEFAULT 'PENDING' COMMENT 'PENDING, SENT or FAILED',
+  attempts INT NOT NULL DEFAULT 0,
+  error_message TEXT NULL,
+  sent_at TIMESTAMP NULL,
+
+  INDEX idx_payslip_email_deliveries_job_id (job_id),
+
+  CONSTRAINT fk_payslip_email_deliveries_job
+    FOREIGN KEY (job_id)
+    REFERENCES payslip_email_jobs(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE,
+
+  CONSTRAINT fk_payslip_email_deliveries_payroll
+    FOREIGN KEY (payroll_id) REFERENCES payrolls(id),
+
+  CONSTRAINT fk_payslip_email_deliveries_employee
+    FOREIGN KEY (employee_id) REFERENCES employees(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS journal_account_mappings;
+DROP TABLE IF EXISTS journal_accounts;
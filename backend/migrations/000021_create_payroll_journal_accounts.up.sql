@@ -0,0 +1,46 @@
+CREATE TABLE journal_accounts (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  account_key VARCHAR(30) NOT NULL COMMENT 'Role of the account on payroll journal',
+  code VARCHAR(30) NOT NULL COMMENT 'Account code on the chart of accounts',
+  name VARCHAR(150) NOT NULL,
+
+  UNIQUE KEY uq_journal_accounts_account_key (account_key)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO journal_accounts (account_key, code, name) VALUES
+('SALARY_EXPENSE', '6-1100', 'Beban Gaji'),
+('ALLOWANCE_EXPENSE', '6-1200', 'Beban Tunjangan'),
+('BPJS_EXPENSE', '6-1300', 'Beban BPJS'),
+('TAX_PAYABLE', '2-1300', 'Utang PPh 21'),
+('BPJS_PAYABLE', '2-1400', 'Utang BPJS'),
+('LOAN_RECEIVABLE', '1-1400', 'Piutang Karyawan'),
+('SALARY_PAYABLE', '2-1200', 'Utang Gaji');
+
+CREATE TABLE journal_account_mappings (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  detail_type VARCHAR(20) NOT NULL COMMENT 'ALLOWANCE or DEDUCTION',
+  title_prefix VARCHAR(150) NOT NULL DEFAULT '' COMMENT 'Matched against payroll detail title, empty means any title',
+  account_key VARCHAR(30) NOT NULL,
+
+  INDEX idx_journal_account_mappings_detail_type (detail_type),
+
+  CONSTRAINT fk_journal_account_mappings_account
+    FOREIGN KEY (account_key)
+    REFERENCES journal_accounts(account_key)
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO journal_account_mappings (detail_type, title_prefix, account_key) VALUES
+('ALLOWANCE', '', 'ALLOWANCE_EXPENSE'),
+('ALLOWANCE', 'Base Salary', 'SALARY_EXPENSE'),
+('ALLOWANCE', 'Pengembalian Kelebihan PPh 21', 'TAX_PAYABLE'),
+('DEDUCTION', '', 'SALARY_EXPENSE'),
+('DEDUCTION', 'PPh 21', 'TAX_PAYABLE'),
+('DEDUCTION', 'BPJS', 'BPJS_PAYABLE'),
+('DEDUCTION', 'Potongan Kasbon', 'LOAN_RECEIVABLE');
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS late_policy_exemptions;
+DROP TABLE IF EXISTS late_policy_tiers;
+DROP TABLE IF EXISTS late_policies;
@@ -0,0 +1,58 @@
+CREATE TABLE late_policies (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  grace_minutes INT NOT NULL DEFAULT 0 COMMENT 'Tolerance of each check-in, not marked LATE nor penalized',
+  flat_penalty DECIMAL(15, 2) NOT NULL DEFAULT 0 COMMENT 'Charged once per late check-in',
+  monthly_cap DECIMAL(15, 2) NOT NULL DEFAULT 0 COMMENT 'Maximum penalty of a month, 0 means no cap'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE late_policy_tiers (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  late_policy_id BIGINT NOT NULL,
+
+  from_minute INT NOT NULL COMMENT 'First late minute after grace charged with this rate',
+  rate_per_minute DECIMAL(15, 2) NOT NULL DEFAULT 0,
+
+  INDEX idx_late_policy_tiers_policy_id (late_policy_id),
+
+  CONSTRAINT fk_late_policy_tiers_policy
+    FOREIGN KEY (late_policy_id)
+    REFERENCES late_policies(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE late_policy_exemptions (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  late_policy_id BIGINT NOT NULL,
+
+  employee_id BIGINT NULL,
+  department_id BIGINT NULL,
+
+  INDEX idx_late_policy_exemptions_policy_id (late_policy_id),
+
+  CONSTRAINT fk_late_policy_exemptions_policy
+    FOREIGN KEY (late_policy_id)
+    REFERENCES late_policies(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE,
+
+  CONSTRAINT fk_late_policy_exemptions_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE,
+
+  CONSTRAINT fk_late_policy_exemptions_department
+    FOREIGN KEY (department_id)
+    REFERENCES ref_departments(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- same 15 minutes late threshold & Rp 1.000 per minute as before the policy exist
+INSERT INTO late_policies (id, grace_minutes, flat_penalty, monthly_cap) VALUES (1, 15, 0, 0);
+INSERT INTO late_policy_tiers (late_policy_id, from_minute, rate_per_minute) VALUES (1, 1, 1000);
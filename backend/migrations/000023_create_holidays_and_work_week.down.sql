@@ -0,0 +1,4 @@
+ALTER TABLE companies
+DROP COLUMN work_days_per_week;
+
+DROP TABLE IF EXISTS holidays;
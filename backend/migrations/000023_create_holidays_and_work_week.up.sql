@@ -0,0 +1,13 @@
+CREATE TABLE holidays (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  date DATE NOT NULL,
+  name VARCHAR(150) NOT NULL,
+
+  UNIQUE KEY uq_holidays_date (date)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE companies
+ADD COLUMN work_days_per_week TINYINT NOT NULL DEFAULT 5 COMMENT '5 (monday - friday) or 6 (monday - saturday)';
@@ -0,0 +1,8 @@
+ALTER TABLE ref_leave_types
+DROP COLUMN is_paid;
+
+ALTER TABLE companies
+DROP COLUMN absence_deduction_enabled,
+DROP COLUMN unpaid_leave_deduction_enabled,
+DROP COLUMN daily_rate_basis,
+DROP COLUMN daily_rate_divisor;
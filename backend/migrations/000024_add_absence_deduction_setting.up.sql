@@ -0,0 +1,10 @@
+ALTER TABLE companies
+ADD COLUMN absence_deduction_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+ADD COLUMN unpaid_leave_deduction_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+ADD COLUMN daily_rate_basis VARCHAR(20) NOT NULL DEFAULT 'WORKING_DAYS' COMMENT 'WORKING_DAYS or FIXED_DIVISOR',
+ADD COLUMN daily_rate_divisor TINYINT NOT NULL DEFAULT 21 COMMENT 'used when daily_rate_basis is FIXED_DIVISOR';
+
+ALTER TABLE ref_leave_types
+ADD COLUMN is_paid BOOLEAN NOT NULL DEFAULT TRUE;
+
+UPDATE ref_leave_types SET is_paid = FALSE WHERE name = 'Unpaid';
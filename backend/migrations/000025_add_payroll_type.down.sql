@@ -0,0 +1,8 @@
+ALTER TABLE payroll_runs
+DROP INDEX uq_payroll_runs_period_type,
+ADD UNIQUE KEY uq_payroll_runs_period_date (period_date),
+DROP COLUMN type;
+
+ALTER TABLE payrolls
+DROP INDEX idx_payrolls_period_type,
+DROP COLUMN type;
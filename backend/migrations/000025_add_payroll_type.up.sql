@@ -0,0 +1,8 @@
+ALTER TABLE payrolls
+ADD COLUMN type VARCHAR(20) NOT NULL DEFAULT 'REGULAR' COMMENT 'REGULAR, THR' AFTER payroll_run_id,
+ADD INDEX idx_payrolls_period_type (period_date, type);
+
+ALTER TABLE payroll_runs
+ADD COLUMN type VARCHAR(20) NOT NULL DEFAULT 'REGULAR' COMMENT 'REGULAR, THR' AFTER period_date,
+DROP INDEX uq_payroll_runs_period_date,
+ADD UNIQUE KEY uq_payroll_runs_period_type (period_date, type);
@@ -0,0 +1,8 @@
+DELETE FROM journal_account_mappings
+WHERE detail_type = 'ALLOWANCE' AND title_prefix IN ('Uang Pesangon', 'Uang Penghargaan Masa Kerja');
+
+ALTER TABLE payrolls
+MODIFY COLUMN type VARCHAR(20) NOT NULL DEFAULT 'REGULAR' COMMENT 'REGULAR, THR';
+
+DROP TABLE IF EXISTS final_settlements;
+DROP TABLE IF EXISTS severance_rules;
@@ -0,0 +1,63 @@
+CREATE TABLE severance_rules (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  reason VARCHAR(30) NOT NULL COMMENT 'Termination reason',
+  severance_multiplier DECIMAL(4, 2) NOT NULL DEFAULT 0 COMMENT 'Multiplier of uang pesangon',
+  service_pay_multiplier DECIMAL(4, 2) NOT NULL DEFAULT 0 COMMENT 'Multiplier of uang penghargaan masa kerja',
+
+  UNIQUE KEY uq_severance_rules_reason (reason)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- PP 35/2021
+INSERT INTO severance_rules (reason, severance_multiplier, service_pay_multiplier) VALUES
+('RESIGNATION', 0.00, 0.00),
+('EFFICIENCY', 0.50, 1.00),
+('RETIREMENT', 1.75, 1.00),
+('DEATH', 2.00, 1.00),
+('MISCONDUCT', 0.50, 1.00),
+('CONTRACT_END', 0.00, 0.00);
+
+CREATE TABLE final_settlements (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  payroll_id BIGINT NOT NULL,
+  employee_id BIGINT NOT NULL,
+
+  end_date DATE NOT NULL,
+  reason VARCHAR(30) NOT NULL,
+  tenure_months INT NOT NULL DEFAULT 0,
+  unused_leave_days INT NOT NULL DEFAULT 0,
+
+  outstanding_loan DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  severance_pay DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  service_pay DECIMAL(15, 2) NOT NULL DEFAULT 0,
+
+  notes TEXT,
+  created_by BIGINT NOT NULL,
+
+  UNIQUE KEY uq_final_settlements_payroll_id (payroll_id),
+  INDEX idx_final_settlements_employee_id (employee_id),
+
+  CONSTRAINT fk_final_settlements_payroll
+    FOREIGN KEY (payroll_id)
+    REFERENCES payrolls(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE,
+
+  CONSTRAINT fk_final_settlements_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE payrolls
+MODIFY COLUMN type VARCHAR(20) NOT NULL DEFAULT 'REGULAR' COMMENT 'REGULAR, THR, FINAL_SETTLEMENT';
+
+INSERT INTO journal_account_mappings (detail_type, title_prefix, account_key) VALUES
+('ALLOWANCE', 'Uang Pesangon', 'SALARY_EXPENSE'),
+('ALLOWANCE', 'Uang Penghargaan Masa Kerja', 'SALARY_EXPENSE');
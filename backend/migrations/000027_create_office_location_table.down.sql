@@ -0,0 +1,10 @@
+DELETE FROM ref_leave_types WHERE name = 'WFH' AND is_remote_work = TRUE;
+
+ALTER TABLE ref_leave_types
+DROP COLUMN is_remote_work;
+
+ALTER TABLE companies
+DROP COLUMN geofence_policy;
+
+DROP TABLE IF EXISTS office_location_assignments;
+DROP TABLE IF EXISTS office_locations;
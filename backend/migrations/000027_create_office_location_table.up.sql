@@ -0,0 +1,53 @@
+CREATE TABLE office_locations (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  name VARCHAR(150) NOT NULL,
+  address VARCHAR(500) NOT NULL DEFAULT '',
+  latitude DECIMAL(10, 8) NOT NULL,
+  longitude DECIMAL(11, 8) NOT NULL,
+  radius_meter INT NOT NULL DEFAULT 100 COMMENT 'Clock-in & clock-out allowed within this distance',
+  is_active BOOLEAN DEFAULT TRUE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE office_location_assignments (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  office_location_id BIGINT NOT NULL,
+
+  employee_id BIGINT NULL,
+  department_id BIGINT NULL,
+
+  INDEX idx_office_location_assignments_location_id (office_location_id),
+  INDEX idx_office_location_assignments_employee_id (employee_id),
+  INDEX idx_office_location_assignments_department_id (department_id),
+
+  CONSTRAINT fk_office_location_assignments_location
+    FOREIGN KEY (office_location_id)
+    REFERENCES office_locations(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE,
+
+  CONSTRAINT fk_office_location_assignments_employee
+    FOREIGN KEY (employee_id)
+    REFERENCES employees(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE,
+
+  CONSTRAINT fk_office_location_assignments_department
+    FOREIGN KEY (department_id)
+    REFERENCES ref_departments(id)
+    ON DELETE CASCADE
+    ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE companies
+ADD COLUMN geofence_policy VARCHAR(20) NOT NULL DEFAULT 'FLAG' COMMENT 'REJECT or FLAG attendance outside office radius';
+
+-- approved remote work leave allow clock-in from anywhere instead of excusing the attendance
+ALTER TABLE ref_leave_types
+ADD COLUMN is_remote_work BOOLEAN NOT NULL DEFAULT FALSE;
+
+INSERT IGNORE INTO ref_leave_types (name, default_quota, is_deducted, is_paid, is_remote_work) VALUES
+('WFH', 0, FALSE, TRUE, TRUE);
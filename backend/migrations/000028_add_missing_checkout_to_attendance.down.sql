@@ -0,0 +1,6 @@
+ALTER TABLE companies
+DROP COLUMN auto_checkout_grace_minutes;
+
+ALTER TABLE attendances
+DROP INDEX idx_attendances_is_missing_checkout,
+DROP COLUMN is_missing_checkout;
@@ -0,0 +1,6 @@
+ALTER TABLE attendances
+ADD COLUMN is_missing_checkout BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Check-out is closed by system at end of shift',
+ADD INDEX idx_attendances_is_missing_checkout (is_missing_checkout);
+
+ALTER TABLE companies
+ADD COLUMN auto_checkout_grace_minutes INT NOT NULL DEFAULT 120 COMMENT 'Wait after shift end before open attendance is closed';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS attendance_histories;
+DROP TABLE IF EXISTS attendance_corrections;
@@ -0,0 +1,70 @@
+CREATE TABLE IF NOT EXISTS attendance_corrections (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at DATETIME NULL,
+  updated_at DATETIME NULL,
+
+  user_id BIGINT NOT NULL,
+  employee_id BIGINT NOT NULL,
+  approved_by BIGINT NULL,
+  attendance_id BIGINT NULL COMMENT 'Attendance of the date when the request was made, NULL when there was none',
+
+  date DATE NOT NULL,
+  check_in_time TIME NULL,
+  check_out_time TIME NULL,
+  reason TEXT NOT NULL,
+
+  status ENUM('PENDING', 'APPROVED', 'REJECTED') NOT NULL DEFAULT 'PENDING',
+  rejection_reason TEXT NULL,
+
+  INDEX idx_attendance_corrections_user_id (user_id),
+  INDEX idx_attendance_corrections_employee_date (employee_id, date),
+  INDEX idx_attendance_corrections_status (status),
+
+  CONSTRAINT fk_attendance_corrections_user
+      FOREIGN KEY (user_id) REFERENCES users(id)
+      ON DELETE RESTRICT ON UPDATE CASCADE,
+
+  CONSTRAINT fk_attendance_corrections_employee
+      FOREIGN KEY (employee_id) REFERENCES employees(id)
+      ON DELETE RESTRICT ON UPDATE CASCADE,
+
+  CONSTRAINT fk_attendance_corrections_approver
+      FOREIGN KEY (approved_by) REFERENCES users(id)
+      ON DELETE SET NULL ON UPDATE CASCADE,
+
+  CONSTRAINT fk_attendance_corrections_attendance
+      FOREIGN KEY (attendance_id) REFERENCES attendances(id)
+      ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+-- original values of an attendance before it was changed by an approved correction
+CREATE TABLE IF NOT EXISTS attendance_histories (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at DATETIME NULL,
+
+  attendance_id BIGINT NOT NULL,
+  correction_id BIGINT NOT NULL,
+  changed_by BIGINT NOT NULL,
+
+  check_in_time DATETIME NOT NULL,
+  check_out_time DATETIME NULL,
+  status VARCHAR(20) NOT NULL,
+  late_duration_minute INT NOT NULL DEFAULT 0,
+  is_suspicious BOOLEAN NOT NULL DEFAULT FALSE,
+  is_missing_checkout BOOLEAN NOT NULL DEFAULT FALSE,
+  notes VARCHAR(500) NULL,
+
+  INDEX idx_attendance_histories_attendance_id (attendance_id),
+
+  CONSTRAINT fk_attendance_histories_attendance
+      FOREIGN KEY (attendance_id) REFERENCES attendances(id)
+      ON DELETE CASCADE ON UPDATE CASCADE,
+
+  CONSTRAINT fk_attendance_histories_correction
+      FOREIGN KEY (correction_id) REFERENCES attendance_corrections(id)
+      ON DELETE CASCADE ON UPDATE CASCADE,
+
+  CONSTRAINT fk_attendance_histories_changed_by
+      FOREIGN KEY (changed_by) REFERENCES users(id)
+      ON DELETE RESTRICT ON UPDATE CASCADE
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS rotation_pattern_steps;
+DROP TABLE IF EXISTS rotation_patterns;
+DROP TABLE IF EXISTS shift_rosters;
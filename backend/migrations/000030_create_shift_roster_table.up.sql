@@ -0,0 +1,49 @@
+-- shift of an employee on a date, override the fixed shift of the employee. NULL shift_id is a day off
+CREATE TABLE IF NOT EXISTS shift_rosters (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at DATETIME NULL,
+  updated_at DATETIME NULL,
+
+  employee_id BIGINT NOT NULL,
+  date DATE NOT NULL,
+  shift_id BIGINT NULL,
+
+  UNIQUE INDEX idx_shift_rosters_employee_date (employee_id, date),
+  INDEX idx_shift_rosters_date (date),
+
+  CONSTRAINT fk_shift_rosters_employee
+      FOREIGN KEY (employee_id) REFERENCES employees(id)
+      ON DELETE CASCADE ON UPDATE CASCADE,
+
+  CONSTRAINT fk_shift_rosters_shift
+      FOREIGN KEY (shift_id) REFERENCES ref_shifts(id)
+      ON DELETE RESTRICT ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS rotation_patterns (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  created_at DATETIME NULL,
+  updated_at DATETIME NULL,
+
+  name VARCHAR(100) NOT NULL
+);
+
+-- steps are repeated in sequence order, each one last for its number of days
+CREATE TABLE IF NOT EXISTS rotation_pattern_steps (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  rotation_pattern_id BIGINT NOT NULL,
+
+  sequence INT NOT NULL,
+  shift_id BIGINT NULL COMMENT 'NULL is day off',
+  days INT NOT NULL,
+
+  INDEX idx_rotation_pattern_steps_pattern_id (rotation_pattern_id),
+
+  CONSTRAINT fk_rotation_pattern_steps_pattern
+      FOREIGN KEY (rotation_pattern_id) REFERENCES rotation_patterns(id)
+      ON DELETE CASCADE ON UPDATE CASCADE,
+
+  CONSTRAINT fk_rotation_pattern_steps_shift
+      FOREIGN KEY (shift_id) REFERENCES ref_shifts(id)
+      ON DELETE RESTRICT ON UPDATE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE payrolls
+DROP COLUMN ptkp_status;
@@ -0,0 +1,7 @@
+ALTER TABLE payrolls
+ADD COLUMN ptkp_status VARCHAR(10) NULL COMMENT 'PTKP code the PPh 21 is calculated with, e.g. TK/0 or K/2';
+
+-- existing payroll best guess is the current status of the employee
+UPDATE payrolls p
+JOIN employees e ON e.id = p.employee_id
+SET p.ptkp_status = CONCAT(e.marital_status, '/', LEAST(GREATEST(e.dependents, 0), 3));
@@ -0,0 +1,2 @@
+ALTER TABLE payrolls
+DROP COLUMN loan_deduction;
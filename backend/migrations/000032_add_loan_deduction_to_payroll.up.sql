@@ -0,0 +1,12 @@
+ALTER TABLE payrolls
+ADD COLUMN loan_deduction DECIMAL(15, 2) NOT NULL DEFAULT 0 COMMENT 'Installment of loan_id deducted on this payroll';
+
+UPDATE payrolls p
+JOIN (
+  SELECT payroll_id, SUM(amount) AS amount
+  FROM payroll_details
+  WHERE title = 'Potongan Kasbon' AND type = 'DEDUCTION'
+  GROUP BY payroll_id
+) d ON d.payroll_id = p.id
+SET p.loan_deduction = d.amount
+WHERE p.loan_id IS NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE final_settlements
+DROP COLUMN previous_user_active,
+DROP COLUMN previous_end_date;
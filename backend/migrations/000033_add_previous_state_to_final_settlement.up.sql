@@ -0,0 +1,3 @@
+ALTER TABLE final_settlements
+ADD COLUMN previous_end_date DATE NULL COMMENT 'End date of the employee before the settlement, restored on void',
+ADD COLUMN previous_user_active BOOLEAN NOT NULL DEFAULT TRUE COMMENT 'User active flag before the settlement, restored on void';
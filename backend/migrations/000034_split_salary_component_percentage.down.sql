@@ -0,0 +1,14 @@
+UPDATE salary_components
+SET amount = percentage
+WHERE calculation_type = 'PERCENTAGE';
+
+UPDATE salary_component_assignments a
+JOIN salary_components c ON c.id = a.salary_component_id
+SET a.amount = a.percentage
+WHERE c.calculation_type = 'PERCENTAGE' AND a.percentage IS NOT NULL;
+
+ALTER TABLE salary_component_assignments
+DROP COLUMN percentage;
+
+ALTER TABLE salary_components
+DROP COLUMN percentage;
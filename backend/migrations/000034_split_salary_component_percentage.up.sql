@@ -0,0 +1,15 @@
+ALTER TABLE salary_components
+ADD COLUMN percentage DECIMAL(5, 2) NOT NULL DEFAULT 0 COMMENT 'Share of base salary of PERCENTAGE components' AFTER amount;
+
+ALTER TABLE salary_component_assignments
+ADD COLUMN percentage DECIMAL(5, 2) NULL COMMENT 'Override of the component percentage, NULL uses the component default' AFTER amount;
+
+-- amount only hold the nominal of FIXED components from now on
+UPDATE salary_component_assignments a
+JOIN salary_components c ON c.id = a.salary_component_id
+SET a.percentage = a.amount, a.amount = NULL
+WHERE c.calculation_type = 'PERCENTAGE';
+
+UPDATE salary_components
+SET percentage = amount, amount = 0
+WHERE calculation_type = 'PERCENTAGE';
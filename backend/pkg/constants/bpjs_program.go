@@ -0,0 +1,11 @@
+package constants
+
+type BPJSProgram string
+
+const (
+	BPJSProgramKesehatan BPJSProgram = "KESEHATAN"
+	BPJSProgramJHT       BPJSProgram = "JHT"
+	BPJSProgramJP        BPJSProgram = "JP"
+	BPJSProgramJKK       BPJSProgram = "JKK"
+	BPJSProgramJKM       BPJSProgram = "JKM"
+)
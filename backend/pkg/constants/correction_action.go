@@ -0,0 +1,8 @@
+package constants
+
+type CorrectionAction string
+
+const (
+	CorrectionActionApprove CorrectionAction = "APPROVE"
+	CorrectionActionReject  CorrectionAction = "REJECT"
+)
@@ -0,0 +1,9 @@
+package constants
+
+type CorrectionStatus string
+
+const (
+	CorrectionStatusPending  CorrectionStatus = "PENDING"
+	CorrectionStatusApproved CorrectionStatus = "APPROVED"
+	CorrectionStatusRejected CorrectionStatus = "REJECTED"
+)
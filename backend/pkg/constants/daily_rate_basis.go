@@ -0,0 +1,8 @@
+package constants
+
+type DailyRateBasis string
+
+const (
+	DailyRateBasisWorkingDays  DailyRateBasis = "WORKING_DAYS"
+	DailyRateBasisFixedDivisor DailyRateBasis = "FIXED_DIVISOR"
+)
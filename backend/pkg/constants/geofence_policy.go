@@ -0,0 +1,8 @@
+package constants
+
+type GeofencePolicy string
+
+const (
+	GeofencePolicyReject GeofencePolicy = "REJECT"
+	GeofencePolicyFlag   GeofencePolicy = "FLAG"
+)
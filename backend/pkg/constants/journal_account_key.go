@@ -0,0 +1,13 @@
+package constants
+
+type JournalAccountKey string
+
+const (
+	JournalAccountSalaryExpense    JournalAccountKey = "SALARY_EXPENSE"
+	JournalAccountAllowanceExpense JournalAccountKey = "ALLOWANCE_EXPENSE"
+	JournalAccountBPJSExpense      JournalAccountKey = "BPJS_EXPENSE"
+	JournalAccountTaxPayable       JournalAccountKey = "TAX_PAYABLE"
+	JournalAccountBPJSPayable      JournalAccountKey = "BPJS_PAYABLE"
+	JournalAccountLoanReceivable   JournalAccountKey = "LOAN_RECEIVABLE"
+	JournalAccountSalaryPayable    JournalAccountKey = "SALARY_PAYABLE"
+)
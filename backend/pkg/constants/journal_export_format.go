@@ -0,0 +1,8 @@
+package constants
+
+type JournalExportFormat string
+
+const (
+	JournalExportFormatCSV  JournalExportFormat = "csv"
+	JournalExportFormatJSON JournalExportFormat = "json"
+)
@@ -1,3 +1,5 @@
 package constants
 
-const LoanMaximumTotalAmount float64 = 10000000
+import "basekarya-backend/pkg/money"
+
+const LoanMaximumTotalAmount money.Money = 10_000_000 * money.Rupiah
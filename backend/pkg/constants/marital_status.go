@@ -0,0 +1,8 @@
+package constants
+
+type MaritalStatus string
+
+const (
+	MaritalStatusSingle  MaritalStatus = "TK"
+	MaritalStatusMarried MaritalStatus = "K"
+)
@@ -6,9 +6,12 @@ const (
 	NotificationTypeApproved NotificationType = "APPROVED"
 	NotificationTypeRejected NotificationType = "REJECTED"
 
-	NotificationTypeLeaveApprovalReq     NotificationType = "LEAVE_APPROVAL_REQ"
-	NotificationTypeReimburseApprovalReq NotificationType = "REIMBURSE_APPROVAL_REQ"
-	NotificationTypePayrollPaid          NotificationType = "PAYROLL_PAID"
-	NotificationTypeLoanApprovalReq      NotificationType = "LOAN_APPROVAL_REQ"
-	NotificationTypeOvertimeApprovalReq  NotificationType = "OVERTIME_APPROVAL_REQ"
+	NotificationTypeLeaveApprovalReq      NotificationType = "LEAVE_APPROVAL_REQ"
+	NotificationTypeReimburseApprovalReq  NotificationType = "REIMBURSE_APPROVAL_REQ"
+	NotificationTypePayrollPaid           NotificationType = "PAYROLL_PAID"
+	NotificationTypeLoanApprovalReq       NotificationType = "LOAN_APPROVAL_REQ"
+	NotificationTypeOvertimeApprovalReq   NotificationType = "OVERTIME_APPROVAL_REQ"
+	NotificationTypeCorrectionApprovalReq NotificationType = "CORRECTION_APPROVAL_REQ"
+	NotificationTypePayslipEmailProgress  NotificationType = "PAYSLIP_EMAIL_PROGRESS"
+	NotificationTypeMissingCheckout       NotificationType = "MISSING_CHECKOUT"
 )
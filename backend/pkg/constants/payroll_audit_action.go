@@ -0,0 +1,8 @@
+package constants
+
+type PayrollAuditAction string
+
+const (
+	PayrollAuditActionRegenerate PayrollAuditAction = "REGENERATE"
+	PayrollAuditActionVoid       PayrollAuditAction = "VOID"
+)
@@ -0,0 +1,10 @@
+package constants
+
+type PayrollRunAction string
+
+const (
+	PayrollRunActionReview  PayrollRunAction = "REVIEW"
+	PayrollRunActionApprove PayrollRunAction = "APPROVE"
+	PayrollRunActionPay     PayrollRunAction = "PAY"
+	PayrollRunActionClose   PayrollRunAction = "CLOSE"
+)
@@ -0,0 +1,11 @@
+package constants
+
+type PayrollRunStatus string
+
+const (
+	PayrollRunStatusDraft    PayrollRunStatus = "DRAFT"
+	PayrollRunStatusReviewed PayrollRunStatus = "REVIEWED"
+	PayrollRunStatusApproved PayrollRunStatus = "APPROVED"
+	PayrollRunStatusPaid     PayrollRunStatus = "PAID"
+	PayrollRunStatusClosed   PayrollRunStatus = "CLOSED"
+)
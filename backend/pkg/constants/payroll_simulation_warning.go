@@ -0,0 +1,9 @@
+package constants
+
+type PayrollSimulationWarning string
+
+const (
+	PayrollSimulationZeroBaseSalary     PayrollSimulationWarning = "ZERO_BASE_SALARY"
+	PayrollSimulationMissingBankAccount PayrollSimulationWarning = "MISSING_BANK_ACCOUNT"
+	PayrollSimulationNegativeNetSalary  PayrollSimulationWarning = "NEGATIVE_NET_SALARY"
+)
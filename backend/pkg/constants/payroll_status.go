@@ -5,4 +5,5 @@ type PayrollStatus string
 const (
 	PayrollStatusDraft PayrollStatus = "DRAFT"
 	PayrollStatusPaid  PayrollStatus = "PAID"
+	PayrollStatusVoid  PayrollStatus = "VOID"
 )
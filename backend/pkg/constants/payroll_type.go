@@ -0,0 +1,9 @@
+package constants
+
+type PayrollType string
+
+const (
+	PayrollTypeRegular         PayrollType = "REGULAR"
+	PayrollTypeTHR             PayrollType = "THR"
+	PayrollTypeFinalSettlement PayrollType = "FINAL_SETTLEMENT"
+)
@@ -0,0 +1,9 @@
+package constants
+
+type PayrollVarianceFlag string
+
+const (
+	PayrollVarianceNewJoiner      PayrollVarianceFlag = "NEW_JOINER"
+	PayrollVarianceLeaver         PayrollVarianceFlag = "LEAVER"
+	PayrollVarianceAboveThreshold PayrollVarianceFlag = "ABOVE_THRESHOLD"
+)
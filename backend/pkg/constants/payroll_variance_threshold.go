@@ -0,0 +1,4 @@
+package constants
+
+// PayrollVarianceThresholdPercent is the default change in percent flagged on period comparison
+const PayrollVarianceThresholdPercent = 10.0
@@ -0,0 +1,9 @@
+package constants
+
+type PayslipEmailDeliveryStatus string
+
+const (
+	PayslipEmailDeliveryStatusPending PayslipEmailDeliveryStatus = "PENDING"
+	PayslipEmailDeliveryStatusSent    PayslipEmailDeliveryStatus = "SENT"
+	PayslipEmailDeliveryStatusFailed  PayslipEmailDeliveryStatus = "FAILED"
+)
@@ -0,0 +1,9 @@
+package constants
+
+type PayslipEmailJobStatus string
+
+const (
+	PayslipEmailJobStatusQueued    PayslipEmailJobStatus = "QUEUED"
+	PayslipEmailJobStatusRunning   PayslipEmailJobStatus = "RUNNING"
+	PayslipEmailJobStatusCompleted PayslipEmailJobStatus = "COMPLETED"
+)
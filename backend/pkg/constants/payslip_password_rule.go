@@ -0,0 +1,9 @@
+package constants
+
+type PayslipPasswordRule string
+
+const (
+	PayslipPasswordRuleNIKBirthDate PayslipPasswordRule = "NIK_BIRTH_DATE"
+	PayslipPasswordRuleBirthDate    PayslipPasswordRule = "BIRTH_DATE"
+	PayslipPasswordRuleNIK          PayslipPasswordRule = "NIK"
+)
@@ -1,3 +0,0 @@
-package constants
-
-const PenaltyPerMinuteLate = 1000
@@ -0,0 +1,8 @@
+package constants
+
+type ProrationBasis string
+
+const (
+	ProrationBasisWorkingDays  ProrationBasis = "WORKING_DAYS"
+	ProrationBasisCalendarDays ProrationBasis = "CALENDAR_DAYS"
+)
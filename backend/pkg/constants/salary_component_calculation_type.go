@@ -0,0 +1,8 @@
+package constants
+
+type SalaryComponentCalculationType string
+
+const (
+	SalaryComponentCalculationFixed      SalaryComponentCalculationType = "FIXED"
+	SalaryComponentCalculationPercentage SalaryComponentCalculationType = "PERCENTAGE"
+)
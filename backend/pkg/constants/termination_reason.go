@@ -0,0 +1,12 @@
+package constants
+
+type TerminationReason string
+
+const (
+	TerminationReasonResignation TerminationReason = "RESIGNATION"
+	TerminationReasonEfficiency  TerminationReason = "EFFICIENCY"
+	TerminationReasonRetirement  TerminationReason = "RETIREMENT"
+	TerminationReasonDeath       TerminationReason = "DEATH"
+	TerminationReasonMisconduct  TerminationReason = "MISCONDUCT"
+	TerminationReasonContractEnd TerminationReason = "CONTRACT_END"
+)
@@ -0,0 +1,301 @@
+// Package money hold rupiah amount as fixed-point sen (1/100 rupiah), so it map exactly to DECIMAL(15,2)
+// and never drift like float64 does.
+//
+// Rounding rules:
+//   - add, subtract & multiply by an integer are exact
+//   - multiply by a rate (Percent) or a ratio (MulRatio) round half away from zero to sen
+//   - amount that is paid or deducted (payroll line, BPJS contribution, tax, installment) is rounded
+//     to whole rupiah with Round before it is stored, so totals are always sum of stored lines
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in sen
+type Money int64
+
+const senPerRupiah = 100
+
+// Rupiah is one rupiah, used as unit of rounding
+const Rupiah Money = senPerRupiah
+
+// New return money of whole rupiah
+func New(rupiah int64) Money {
+	return Money(rupiah * senPerRupiah)
+}
+
+// FromFloat convert float rupiah into money rounded to sen, only use it on untyped input such as rates
+func FromFloat(rupiah float64) Money {
+	return Money(math.Round(rupiah * senPerRupiah))
+}
+
+// Parse read decimal string like "1500000.50" exactly, more than two fraction digits are rounded to sen
+func Parse(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	negative := false
+	if s[0] == '-' || s[0] == '+' {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" {
+		intPart = "0"
+	}
+
+	rupiah, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid money %q", s)
+	}
+
+	for _, c := range fracPart {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("invalid money %q", s)
+		}
+	}
+
+	sen := int64(0)
+	for i := 0; i < 2; i++ {
+		sen *= 10
+		if i < len(fracPart) {
+			sen += int64(fracPart[i] - '0')
+		}
+	}
+
+	if len(fracPart) > 2 && fracPart[2] >= '5' {
+		sen++
+	}
+
+	m := Money(rupiah*senPerRupiah + sen)
+	if negative {
+		m = -m
+	}
+
+	return m, nil
+}
+
+// Float64 return the amount in rupiah, for output that only accept number such as excel cell
+func (m Money) Float64() float64 {
+	return float64(m) / senPerRupiah
+}
+
+// Round round half away from zero to whole rupiah
+func (m Money) Round() Money {
+	return m.roundTo(Rupiah)
+}
+
+// Whole return the amount in whole rupiah, rounded with Round
+func (m Money) Whole() int64 {
+	return int64(m.Round()) / senPerRupiah
+}
+
+// Floor round down to multiple of unit, e.g. Floor(money.New(1000)) for PKP
+func (m Money) Floor(unit Money) Money {
+	if unit <= 0 {
+		return m
+	}
+
+	floored := (m / unit) * unit
+	if floored > m {
+		floored -= unit
+	}
+
+	return floored
+}
+
+func (m Money) roundTo(unit Money) Money {
+	half := unit / 2
+	if m < 0 {
+		return -((-m + half) / unit * unit)
+	}
+
+	return (m + half) / unit * unit
+}
+
+// Mul multiply by an integer, e.g. annualize monthly amount
+func (m Money) Mul(n int64) Money {
+	return m * Money(n)
+}
+
+// MulRatio return m * num / den, rounded half away from zero to sen
+func (m Money) MulRatio(num, den int64) Money {
+	if den == 0 {
+		return 0
+	}
+
+	product := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(num))
+	denominator := big.NewInt(den)
+
+	quotient, remainder := new(big.Int).QuoRem(product, denominator, new(big.Int))
+
+	// round half away from zero: compare twice the remainder with the denominator
+	remainder.Abs(remainder).Mul(remainder, big.NewInt(2))
+	if remainder.Cmp(new(big.Int).Abs(denominator)) >= 0 {
+		if product.Sign()*denominator.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return Money(quotient.Int64())
+}
+
+// Percent return rate percent of the amount, rate is kept up to four decimals (e.g. 0.24 or 3.7)
+func (m Money) Percent(rate float64) Money {
+	return m.MulRatio(int64(math.Round(rate*10_000)), 1_000_000)
+}
+
+// Min return the smaller amount
+func Min(a, b Money) Money {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// Max return the bigger amount
+func Max(a, b Money) Money {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// String return plain decimal, e.g. 1500000.50
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign = "-"
+		m = -m
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, int64(m)/senPerRupiah, int64(m)%senPerRupiah)
+}
+
+// Format return rupiah with thousand separator and no sen, e.g. 1.500.000
+func (m Money) Format() string {
+	rupiah := m.Whole()
+
+	sign := ""
+	if rupiah < 0 {
+		sign = "-"
+		rupiah = -rupiah
+	}
+
+	s := strconv.FormatInt(rupiah, 10)
+	formatted := ""
+	for i, j := len(s)-1, 0; i >= 0; i, j = i-1, j+1 {
+		if j > 0 && j%3 == 0 {
+			formatted = "." + formatted
+		}
+		formatted = string(s[i]) + formatted
+	}
+
+	return sign + formatted
+}
+
+// MarshalJSON write a json number without trailing zero sen, e.g. 1500000 or 1500000.5
+func (m Money) MarshalJSON() ([]byte, error) {
+	s := m.String()
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || s == "-" {
+		s = "0"
+	}
+
+	return []byte(s), nil
+}
+
+// UnmarshalJSON accept json number or numeric string, parsed without going through float
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var raw json.Number
+	if data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		raw = json.Number(s)
+	} else {
+		raw = json.Number(data)
+	}
+
+	if strings.ContainsAny(raw.String(), "eE") {
+		f, err := raw.Float64()
+		if err != nil {
+			return err
+		}
+		*m = FromFloat(f)
+		return nil
+	}
+
+	parsed, err := Parse(raw.String())
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+// UnmarshalParam bind form & query value
+func (m *Money) UnmarshalParam(param string) error {
+	parsed, err := Parse(param)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+// Value store money as decimal string so the database never see a float
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan read DECIMAL column, mysql driver return it as []byte
+func (m *Money) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+	case []byte:
+		parsed, err := Parse(string(v))
+		if err != nil {
+			return err
+		}
+		*m = parsed
+	case string:
+		parsed, err := Parse(v)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+	case int64:
+		*m = New(v)
+	case float64:
+		*m = FromFloat(v)
+	default:
+		return errors.New("unsupported money source type")
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]Money{
+		"1500000":     New(1_500_000),
+		"1500000.50":  150_000_050,
+		"1500000.5":   150_000_050,
+		"0.125":       13,
+		"-2500.75":    -250_075,
+		"":            0,
+		"12345678.99": 1_234_567_899,
+	}
+
+	for input, expected := range tests {
+		m, err := Parse(input)
+		if err != nil {
+			t.Fatalf("%q: unexpected error %v", input, err)
+		}
+		if m != expected {
+			t.Errorf("%q: expected %d, got %d", input, expected, m)
+		}
+	}
+
+	if _, err := Parse("12a"); err == nil {
+		t.Errorf("expected error on invalid input")
+	}
+}
+
+func TestRounding(t *testing.T) {
+	if got := Money(150_050).Round(); got != New(1501) {
+		t.Errorf("expected half to round up, got %s", got)
+	}
+	if got := Money(-150_050).Round(); got != New(-1501) {
+		t.Errorf("expected half to round away from zero, got %s", got)
+	}
+	if got := New(5_430_999).Floor(New(1000)); got != New(5_430_000) {
+		t.Errorf("expected floor to thousand, got %s", got)
+	}
+	if got := New(10_000_000).MulRatio(12, 22); got != 545_454_545 {
+		t.Errorf("expected 5454545.45, got %s", got)
+	}
+	if got := New(12_000_000).Percent(0.24); got != New(28_800) {
+		t.Errorf("expected 28800, got %s", got)
+	}
+}
+
+func TestLoanBalanceHasNoDrift(t *testing.T) {
+	remaining := New(1_000_000)
+	installment := FromFloat(333_333.33)
+
+	for i := 0; i < 3; i++ {
+		remaining -= Min(installment, remaining)
+	}
+
+	if remaining != 1 {
+		t.Errorf("expected 0.01 left, got %s", remaining)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		A Money `json:"a"`
+		B Money `json:"b"`
+	}{New(1_500_000), 150_000_050})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"a":1500000,"b":1500000.5}` {
+		t.Errorf("unexpected json %s", data)
+	}
+
+	var body struct {
+		A Money `json:"a"`
+		B Money `json:"b"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":0.1,"b":"250000.25"}`), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.A != 10 || body.B != 25_000_025 {
+		t.Errorf("unexpected value %d %d", body.A, body.B)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	if got := New(1_500_000).Format(); got != "1.500.000" {
+		t.Errorf("expected 1.500.000, got %s", got)
+	}
+	if got := Money(-99_950).Format(); got != "-1.000" {
+		t.Errorf("expected -1.000, got %s", got)
+	}
+}
@@ -1,8 +1,10 @@
 package utils
 
 import (
+	"basekarya-backend/pkg/money"
 	"fmt"
 	"net/http"
+	"reflect"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -51,5 +53,15 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 }
 
 func NewValidator() *CustomValidator {
-	return &CustomValidator{Validator: validator.New()}
+	v := validator.New()
+
+	// validate money in rupiah, so tag like min=1000 mean Rp 1.000 instead of 1000 sen
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		if m, ok := field.Interface().(money.Money); ok {
+			return m.Float64()
+		}
+		return nil
+	}, money.Money(0))
+
+	return &CustomValidator{Validator: v}
 }